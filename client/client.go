@@ -0,0 +1,86 @@
+package client
+
+//go:generate sh -c "oapi-codegen -generate types,client -package client ../openapi.yml > client.gen.go"
+//go:generate go run ./internal/errgen ../openapi.yml errors.gen.go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Config configures New. Only Server is required.
+type Config struct {
+	// Server is the base URL of the API, e.g. http://localhost:8080.
+	Server string
+	// HTTPClient sends the requests; http.Client with a 30s timeout when nil.
+	HTTPClient HttpRequestDoer
+	// Auth signs every attempt of every request; nil sends no credentials.
+	Auth Authenticator
+	// Retry controls retries of failed requests.
+	Retry RetryPolicy
+	// UserAgent is sent in User-Agent when set.
+	UserAgent string
+}
+
+// New returns a client for the API described by openapi.yml. Unlike
+// NewClientWithResponses, its calls return an error for every response
+// outside 2xx: the error type of the response code (NotFoundError,
+// TeamExistsError, ...), or *APIError when the body has no known code.
+// Failed requests are retried according to cfg.Retry.
+func New(cfg Config) (*ClientWithResponses, error) {
+	if cfg.Server == "" {
+		return nil, errors.New("client: Server is required")
+	}
+	next := cfg.HTTPClient
+	if next == nil {
+		next = &http.Client{Timeout: 30 * time.Second}
+	}
+	return NewClientWithResponses(strings.TrimSuffix(cfg.Server, "/"), WithHTTPClient(&doer{
+		next:      next,
+		auth:      cfg.Auth,
+		retry:     cfg.Retry.withDefaults(),
+		userAgent: cfg.UserAgent,
+	}))
+}
+
+// Authenticator adds credentials to a request. It is called before each
+// attempt, so an implementation may refresh an expiring token.
+type Authenticator interface {
+	Authenticate(ctx context.Context, req *http.Request) error
+}
+
+// AuthFunc adapts a function to Authenticator.
+type AuthFunc func(ctx context.Context, req *http.Request) error
+
+func (f AuthFunc) Authenticate(ctx context.Context, req *http.Request) error {
+	return f(ctx, req)
+}
+
+// BearerToken sends an API key or a JWT as "Authorization: Bearer <token>".
+type BearerToken string
+
+func (t BearerToken) Authenticate(_ context.Context, req *http.Request) error {
+	req.Header.Set("Authorization", "Bearer "+string(t))
+	return nil
+}
+
+// TokenSource returns the current token, e.g. from a cache that refreshes
+// it before expiry.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// BearerTokenSource asks src for a token on every attempt.
+func BearerTokenSource(src TokenSource) Authenticator {
+	return AuthFunc(func(ctx context.Context, req *http.Request) error {
+		token, err := src.Token(ctx)
+		if err != nil {
+			return fmt.Errorf("client: get token: %w", err)
+		}
+		return BearerToken(token).Authenticate(ctx, req)
+	})
+}
@@ -0,0 +1,101 @@
+// Code generated by errgen from openapi.yml. DO NOT EDIT.
+
+package client
+
+// TeamExistsError is returned for responses with code TEAM_EXISTS.
+type TeamExistsError struct{ APIError }
+
+func (e *TeamExistsError) Unwrap() error { return &e.APIError }
+
+// PRExistsError is returned for responses with code PR_EXISTS.
+type PRExistsError struct{ APIError }
+
+func (e *PRExistsError) Unwrap() error { return &e.APIError }
+
+// PRMergedError is returned for responses with code PR_MERGED.
+type PRMergedError struct{ APIError }
+
+func (e *PRMergedError) Unwrap() error { return &e.APIError }
+
+// NotAssignedError is returned for responses with code NOT_ASSIGNED.
+type NotAssignedError struct{ APIError }
+
+func (e *NotAssignedError) Unwrap() error { return &e.APIError }
+
+// NoCandidateError is returned for responses with code NO_CANDIDATE.
+type NoCandidateError struct{ APIError }
+
+func (e *NoCandidateError) Unwrap() error { return &e.APIError }
+
+// NotFoundError is returned for responses with code NOT_FOUND.
+type NotFoundError struct{ APIError }
+
+func (e *NotFoundError) Unwrap() error { return &e.APIError }
+
+// BadRequestError is returned for responses with code BAD_REQUEST.
+type BadRequestError struct{ APIError }
+
+func (e *BadRequestError) Unwrap() error { return &e.APIError }
+
+// UnauthorizedError is returned for responses with code UNAUTHORIZED.
+type UnauthorizedError struct{ APIError }
+
+func (e *UnauthorizedError) Unwrap() error { return &e.APIError }
+
+// ForbiddenError is returned for responses with code FORBIDDEN.
+type ForbiddenError struct{ APIError }
+
+func (e *ForbiddenError) Unwrap() error { return &e.APIError }
+
+// IdempotencyKeyReusedError is returned for responses with code IDEMPOTENCY_KEY_REUSED.
+type IdempotencyKeyReusedError struct{ APIError }
+
+func (e *IdempotencyKeyReusedError) Unwrap() error { return &e.APIError }
+
+// IdempotencyKeyInUseError is returned for responses with code IDEMPOTENCY_KEY_IN_USE.
+type IdempotencyKeyInUseError struct{ APIError }
+
+func (e *IdempotencyKeyInUseError) Unwrap() error { return &e.APIError }
+
+// RateLimitedError is returned for responses with code RATE_LIMITED.
+type RateLimitedError struct{ APIError }
+
+func (e *RateLimitedError) Unwrap() error { return &e.APIError }
+
+// UserHasActivityError is returned for responses with code USER_HAS_ACTIVITY.
+type UserHasActivityError struct{ APIError }
+
+func (e *UserHasActivityError) Unwrap() error { return &e.APIError }
+
+// typedError wraps e into the error type of its code.
+func typedError(e APIError) error {
+	switch e.Code {
+	case "TEAM_EXISTS":
+		return &TeamExistsError{e}
+	case "PR_EXISTS":
+		return &PRExistsError{e}
+	case "PR_MERGED":
+		return &PRMergedError{e}
+	case "NOT_ASSIGNED":
+		return &NotAssignedError{e}
+	case "NO_CANDIDATE":
+		return &NoCandidateError{e}
+	case "NOT_FOUND":
+		return &NotFoundError{e}
+	case "BAD_REQUEST":
+		return &BadRequestError{e}
+	case "UNAUTHORIZED":
+		return &UnauthorizedError{e}
+	case "FORBIDDEN":
+		return &ForbiddenError{e}
+	case "IDEMPOTENCY_KEY_REUSED":
+		return &IdempotencyKeyReusedError{e}
+	case "IDEMPOTENCY_KEY_IN_USE":
+		return &IdempotencyKeyInUseError{e}
+	case "RATE_LIMITED":
+		return &RateLimitedError{e}
+	case "USER_HAS_ACTIVITY":
+		return &UserHasActivityError{e}
+	}
+	return &e
+}
@@ -0,0 +1,49 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// maxErrorBody caps how much of an error response is read.
+const maxErrorBody = 1 << 20
+
+var errNoGetBody = errors.New("client: cannot retry a request whose body cannot be reread")
+
+// APIError is a response outside 2xx. Every code of ErrorResponse has its
+// own type embedding APIError, e.g. NotFoundError, so callers can match a
+// code with errors.As and any error response with errors.As(err, &apiErr)
+// for apiErr of type *APIError.
+type APIError struct {
+	StatusCode int
+	// Code and Message are empty when the body is not an ErrorResponse,
+	// e.g. a 502 from a proxy.
+	Code    ErrorResponseErrorCode
+	Message string
+	// RetryAfter is the Retry-After header of 429 and 503 responses.
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("client: server responded %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("client: %d %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+// readError reads and closes the body of a response outside 2xx.
+func readError(resp *http.Response) APIError {
+	e := APIError{StatusCode: resp.StatusCode, RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"))}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	drain(resp.Body)
+
+	var er ErrorResponse
+	if json.Unmarshal(body, &er) == nil {
+		e.Code, e.Message = er.Error.Code, er.Error.Message
+	}
+	return e
+}
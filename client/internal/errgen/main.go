@@ -0,0 +1,95 @@
+// Command errgen generates the typed errors of package client from the
+// ErrorResponse.error.code enum of openapi.yml:
+//
+//	go run ./internal/errgen ../openapi.yml errors.gen.go
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// initialisms keep their case in type names: PR_EXISTS is PRExistsError.
+var initialisms = map[string]bool{"PR": true, "ID": true, "API": true}
+
+type spec struct {
+	Components struct {
+		Schemas struct {
+			ErrorResponse struct {
+				Properties struct {
+					Error struct {
+						Properties struct {
+							Code struct {
+								Enum []string `yaml:"enum"`
+							} `yaml:"code"`
+						} `yaml:"properties"`
+					} `yaml:"error"`
+				} `yaml:"properties"`
+			} `yaml:"ErrorResponse"`
+		} `yaml:"schemas"`
+	} `yaml:"components"`
+}
+
+func main() {
+	if len(os.Args) != 3 {
+		fmt.Fprintln(os.Stderr, "usage: errgen openapi.yml errors.gen.go")
+		os.Exit(2)
+	}
+	if err := run(os.Args[1], os.Args[2]); err != nil {
+		fmt.Fprintln(os.Stderr, "errgen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(in, out string) error {
+	data, err := os.ReadFile(in)
+	if err != nil {
+		return err
+	}
+	var s spec
+	if err := yaml.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	codes := s.Components.Schemas.ErrorResponse.Properties.Error.Properties.Code.Enum
+	if len(codes) == 0 {
+		return fmt.Errorf("%s: no ErrorResponse.error.code enum", in)
+	}
+
+	var b bytes.Buffer
+	b.WriteString("// Code generated by errgen from openapi.yml. DO NOT EDIT.\n\npackage client\n\n")
+	for _, code := range codes {
+		name := typeName(code)
+		fmt.Fprintf(&b, "// %s is returned for responses with code %s.\n", name, code)
+		fmt.Fprintf(&b, "type %s struct{ APIError }\n\n", name)
+		fmt.Fprintf(&b, "func (e *%s) Unwrap() error { return &e.APIError }\n\n", name)
+	}
+	b.WriteString("// typedError wraps e into the error type of its code.\n")
+	b.WriteString("func typedError(e APIError) error {\n\tswitch e.Code {\n")
+	for _, code := range codes {
+		fmt.Fprintf(&b, "\tcase %q:\n\t\treturn &%s{e}\n", code, typeName(code))
+	}
+	b.WriteString("\t}\n\treturn &e\n}\n")
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(out, src, 0o644)
+}
+
+func typeName(code string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(code, "_") {
+		if initialisms[part] {
+			b.WriteString(part)
+			continue
+		}
+		b.WriteString(part[:1] + strings.ToLower(part[1:]))
+	}
+	return b.String() + "Error"
+}
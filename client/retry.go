@@ -0,0 +1,170 @@
+package client
+
+import (
+	"context"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// HeaderIdempotencyKey makes the server answer a repeated POST from its
+// cache instead of executing it again, which also makes it safe to retry.
+const HeaderIdempotencyKey = "Idempotency-Key"
+
+// RetryPolicy retries requests answered with 429 or 5xx. 429 is always
+// retried since the server rejected the request without handling it.
+// 5xx is retried for GET and HEAD, and for other methods only when the
+// request carries an Idempotency-Key, since the server may have applied
+// the change before failing. Zero fields take the defaults noted below.
+type RetryPolicy struct {
+	// MaxAttempts counts the first attempt; 1 disables retries. Default 3.
+	MaxAttempts int
+	// MinBackoff is the delay before the first retry. Default 100ms.
+	MinBackoff time.Duration
+	// MaxBackoff caps the delay, which doubles after each retry. Default 2s.
+	// A longer Retry-After from the server is not waited out: the request
+	// fails with the 429 or 503 at once.
+	MaxBackoff time.Duration
+}
+
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = 3
+	}
+	if p.MinBackoff <= 0 {
+		p.MinBackoff = 100 * time.Millisecond
+	}
+	if p.MaxBackoff <= 0 {
+		p.MaxBackoff = 2 * time.Second
+	}
+	if p.MaxBackoff < p.MinBackoff {
+		p.MaxBackoff = p.MinBackoff
+	}
+	return p
+}
+
+// backoff returns the delay before retry n (1-based): exponential with
+// jitter in [d/2, d]. A Retry-After from the server is used as is, and
+// ok is false when it exceeds MaxBackoff.
+func (p RetryPolicy) backoff(n int, retryAfter time.Duration) (d time.Duration, ok bool) {
+	if retryAfter > 0 {
+		return retryAfter, retryAfter <= p.MaxBackoff
+	}
+	d = p.MinBackoff << (n - 1)
+	if d <= 0 || d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	return d/2 + rand.N(d/2+1), true
+}
+
+// beforeDeadline reports whether waiting d still leaves ctx time for
+// another attempt.
+func beforeDeadline(ctx context.Context, d time.Duration) bool {
+	deadline, ok := ctx.Deadline()
+	return !ok || time.Until(deadline) > d
+}
+
+func retryable(req *http.Request, status int) bool {
+	switch {
+	case status == http.StatusTooManyRequests:
+		return true
+	case status < 500:
+		return false
+	case req.Method == http.MethodGet || req.Method == http.MethodHead:
+		return true
+	}
+	return req.Header.Get(HeaderIdempotencyKey) != ""
+}
+
+// doer signs, sends and retries requests, and turns responses outside 2xx
+// into errors.
+type doer struct {
+	next      HttpRequestDoer
+	auth      Authenticator
+	retry     RetryPolicy
+	userAgent string
+}
+
+func (d *doer) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	for attempt := 1; ; attempt++ {
+		r, err := d.prepare(ctx, req, attempt)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := d.next.Do(r)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode < 300 {
+			return resp, nil
+		}
+
+		apiErr := readError(resp)
+		if attempt >= d.retry.MaxAttempts || !retryable(req, resp.StatusCode) {
+			return nil, typedError(apiErr)
+		}
+		// Waiting longer than allowed only to fail would hide the
+		// server's answer behind a cancelled context.
+		wait, ok := d.retry.backoff(attempt, apiErr.RetryAfter)
+		if !ok || !beforeDeadline(ctx, wait) {
+			return nil, typedError(apiErr)
+		}
+		if err := sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// prepare copies req for one attempt, with a fresh body and credentials.
+func (d *doer) prepare(ctx context.Context, req *http.Request, attempt int) (*http.Request, error) {
+	r := req.Clone(ctx)
+	if attempt > 1 && req.Body != nil && req.Body != http.NoBody {
+		if req.GetBody == nil {
+			return nil, errNoGetBody
+		}
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		r.Body = body
+	}
+	if d.userAgent != "" {
+		r.Header.Set("User-Agent", d.userAgent)
+	}
+	if d.auth != nil {
+		if err := d.auth.Authenticate(ctx, r); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// parseRetryAfter reads Retry-After in seconds; dates are not used by the
+// server and are ignored.
+func parseRetryAfter(h string) time.Duration {
+	secs, err := strconv.Atoi(h)
+	if err != nil || secs < 0 {
+		return 0
+	}
+	return time.Duration(secs) * time.Second
+}
+
+// drain lets the connection be reused.
+func drain(body io.ReadCloser) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(body, maxErrorBody))
+	_ = body.Close()
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"syscall"
+
+	"github.com/chimort/avito_test_task/iternal/app"
+	"github.com/chimort/avito_test_task/iternal/backup"
+	"github.com/chimort/avito_test_task/iternal/config"
+	"github.com/chimort/avito_test_task/iternal/pkg/logger"
+	"github.com/chimort/avito_test_task/iternal/repository"
+	"github.com/chimort/avito_test_task/iternal/service"
+	"github.com/chimort/avito_test_task/migrations"
+)
+
+// runBackup writes every entity and the schema version to an archive:
+//
+//	app backup [flags] backup.ndjson.gz
+//
+// The archive is written to a temporary file next to FILE and renamed when
+// complete, so a failed backup never leaves a truncated file behind.
+func runBackup(args []string) int {
+	cfg, code := loadFileCommand("backup", args)
+	if cfg == nil {
+		return code
+	}
+	path := cfg.Args[0]
+
+	level, _ := logger.ParseLevel(cfg.Log.Level)
+	log := logger.NewLoggerWithFormat("backup", level, cfg.Log.Format)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	db, err := app.InitDB(ctx, log, cfg.DB)
+	if err != nil {
+		log.Error("backup failed", "error", err)
+		return 1
+	}
+	defer func() { _ = db.Close() }()
+
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		log.Error("backup failed", "error", err)
+		return 1
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+
+	w := backup.NewWriter(f)
+	svc := service.NewUserService(repository.NewUserRepository(db), cfg.Assignment, log)
+	err = svc.Backup(ctx, w)
+	if err == nil {
+		err = w.Close()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		log.Error("backup failed", "error", err)
+		return 1
+	}
+	fmt.Println("backup written to", path)
+	return 0
+}
+
+// runRestore migrates an empty database and loads an archive written by
+// runBackup into it:
+//
+//	app restore [flags] backup.ndjson.gz
+func runRestore(args []string) int {
+	cfg, code := loadFileCommand("restore", args)
+	if cfg == nil {
+		return code
+	}
+	path := cfg.Args[0]
+
+	f, err := os.Open(path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer func() { _ = f.Close() }()
+	r, err := backup.NewReader(f)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+		return 1
+	}
+	expected, err := migrations.ExpectedVersion()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if err := r.Manifest().Check(expected); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+		return 1
+	}
+
+	level, _ := logger.ParseLevel(cfg.Log.Level)
+	log := logger.NewLoggerWithFormat("restore", level, cfg.Log.Format)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	db, err := app.InitDB(ctx, log, cfg.DB)
+	if err != nil {
+		log.Error("restore failed", "error", err)
+		return 1
+	}
+	defer func() { _ = db.Close() }()
+	if err := app.RunMigrations(log, db, cfg.Migrations.Source); err != nil {
+		log.Error("restore failed", "error", err)
+		return 1
+	}
+
+	svc := service.NewUserService(repository.NewUserRepository(db), cfg.Assignment, log)
+	counts, err := svc.Restore(ctx, r)
+	if err != nil {
+		log.Error("restore failed", "error", err)
+		return 1
+	}
+
+	tables := make([]string, 0, len(counts))
+	for t := range counts {
+		tables = append(tables, t)
+	}
+	sort.Strings(tables)
+	for _, t := range tables {
+		fmt.Printf("%s: %d\n", t, counts[t])
+	}
+	return 0
+}
+
+// loadFileCommand parses the flags of a subcommand that takes one file
+// argument. On failure it returns a nil config and the exit code.
+func loadFileCommand(name string, args []string) (*config.Config, int) {
+	cfg, err := config.Load(args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil, 0
+		}
+		fmt.Fprintln(os.Stderr, "invalid config:", err)
+		return nil, 2
+	}
+	if len(cfg.Args) != 1 {
+		fmt.Fprintf(os.Stderr, "usage: app %s [flags] FILE\n", name)
+		return nil, 2
+	}
+	return cfg, 0
+}
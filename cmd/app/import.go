@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/chimort/avito_test_task/iternal/app"
+	"github.com/chimort/avito_test_task/iternal/config"
+	"github.com/chimort/avito_test_task/iternal/importer"
+	"github.com/chimort/avito_test_task/iternal/pkg/logger"
+	"github.com/chimort/avito_test_task/iternal/repository"
+	"github.com/chimort/avito_test_task/iternal/service"
+)
+
+// runImport loads a CSV or YAML roster into the database:
+//
+//	app import [flags] teams.csv
+//
+// It takes the same flags as the server for the database connection.
+func runImport(args []string) int {
+	cfg, err := config.Load(args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		fmt.Fprintln(os.Stderr, "invalid config:", err)
+		return 2
+	}
+	if len(cfg.Args) != 1 {
+		fmt.Fprintln(os.Stderr, "usage: app import [flags] FILE.csv|FILE.yaml")
+		return 2
+	}
+	path := cfg.Args[0]
+
+	format, err := importer.FormatFromPath(path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	teams, err := importer.Parse(f, format)
+	_ = f.Close()
+	var lineErrs importer.Errors
+	if errors.As(err, &lineErrs) {
+		for _, le := range lineErrs {
+			fmt.Fprintf(os.Stderr, "%s:%d: %s\n", path, le.Line, le.Message)
+		}
+		return 1
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	level, _ := logger.ParseLevel(cfg.Log.Level)
+	log := logger.NewLoggerWithFormat("import", level, cfg.Log.Format)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	db, err := app.InitDB(ctx, log, cfg.DB)
+	if err != nil {
+		log.Error("import failed", "error", err)
+		return 1
+	}
+	defer func() { _ = db.Close() }()
+	if err := app.RunMigrations(log, db, cfg.Migrations.Source); err != nil {
+		log.Error("import failed", "error", err)
+		return 1
+	}
+
+	svc := service.NewUserService(repository.NewUserRepository(db), cfg.Assignment, log)
+	res, err := svc.ImportTeams(ctx, teams)
+	if err != nil {
+		log.Error("import failed", "error", err)
+		return 1
+	}
+	fmt.Printf("teams: %d (%d created), users: %d, memberships added: %d\n",
+		res.Teams, res.TeamsCreated, res.Users, res.MembersAdded)
+	return 0
+}
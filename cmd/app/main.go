@@ -1,15 +1,123 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
 	"github.com/chimort/avito_test_task/iternal/app"
+	"github.com/chimort/avito_test_task/iternal/config"
 	"github.com/chimort/avito_test_task/iternal/pkg/logger"
+	"github.com/chimort/avito_test_task/iternal/pkg/tracing"
 )
 
 func main() {
-	log := logger.NewLogger("app", logger.LevelInfo)
-	db := app.InitDB(log)
-	app.RunMigrations(log, db)
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "import":
+			os.Exit(runImport(os.Args[2:]))
+		case "backup":
+			os.Exit(runBackup(os.Args[2:]))
+		case "restore":
+			os.Exit(runRestore(os.Args[2:]))
+		}
+	}
+
+	cfg, err := config.Load(os.Args[1:])
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
+		fmt.Fprintln(os.Stderr, "invalid config:", err)
+		os.Exit(2)
+	}
+
+	if cfg.PrintConfig {
+		if err := cfg.Dump(os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	level, _ := logger.ParseLevel(cfg.Log.Level)
+	log := logger.NewLoggerWithFormat("app", level, cfg.Log.Format)
+	if err := run(log, cfg); err != nil {
+		log.Error("app stopped with error", "error", err)
+		os.Exit(1)
+	}
+}
+
+func run(log *logger.Logger, cfg *config.Config) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	shutdownTracing, err := tracing.Init(ctx, tracing.Config{
+		Exporter:    cfg.Tracing.Exporter,
+		FilePath:    cfg.Tracing.FilePath,
+		ServiceName: cfg.Tracing.ServiceName,
+	})
+	if err != nil {
+		return fmt.Errorf("init tracing: %w", err)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			log.Error("failed to flush traces", "error", err)
+		}
+	}()
+
+	// Probes are answered while the database comes up; the listener then
+	// goes to the full server.
+	probes, err := app.ServeProbes(cfg.HTTP.Addr, log)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = probes.Close() }()
+
+	db, err := app.InitDB(ctx, log, cfg.DB)
+	if err != nil {
+		return err
+	}
+	probes.SetStage(app.StageMigrationsRunning)
+	if err := app.RunMigrations(log, db, cfg.Migrations.Source); err != nil {
+		_ = db.Close()
+		return err
+	}
+
+	server, err := app.NewServer(log, db, cfg)
+	if err != nil {
+		_ = db.Close()
+		return err
+	}
+	handoverCtx, cancelHandover := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout)
+	lis, err := probes.Handover(handoverCtx)
+	cancelHandover()
+	if err != nil {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout)
+		defer cancel()
+		return errors.Join(err, server.Shutdown(shutdownCtx))
+	}
+	serveErr := make(chan error, 2)
+	go func() { serveErr <- server.Serve(lis) }()
+	if cfg.GRPC.Addr != "" {
+		go func() { serveErr <- server.StartGRPC(cfg.GRPC.Addr) }()
+	}
+
+	select {
+	case err = <-serveErr:
+	case <-ctx.Done():
+		log.Info("shutdown signal received", "drain_delay", cfg.HTTP.DrainDelay.String(), "timeout", cfg.HTTP.ShutdownTimeout.String())
+	}
 
-	server := app.NewServer(log, db)
-	server.Start(":8080")
+	// The grace period for in-flight requests starts after the drain delay.
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.HTTP.DrainDelay+cfg.HTTP.ShutdownTimeout)
+	defer cancel()
+	if shutdownErr := server.Shutdown(shutdownCtx); shutdownErr != nil {
+		return shutdownErr
+	}
+	return err
 }
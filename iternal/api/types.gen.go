@@ -7,14 +7,36 @@ import (
 	"time"
 )
 
+const (
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Defines values for ApiKeyRole.
+const (
+	ApiKeyRoleAdmin ApiKeyRole = "admin"
+	ApiKeyRoleUser  ApiKeyRole = "user"
+)
+
 // Defines values for ErrorResponseErrorCode.
 const (
-	NOCANDIDATE ErrorResponseErrorCode = "NO_CANDIDATE"
-	NOTASSIGNED ErrorResponseErrorCode = "NOT_ASSIGNED"
-	NOTFOUND    ErrorResponseErrorCode = "NOT_FOUND"
-	PREXISTS    ErrorResponseErrorCode = "PR_EXISTS"
-	PRMERGED    ErrorResponseErrorCode = "PR_MERGED"
-	TEAMEXISTS  ErrorResponseErrorCode = "TEAM_EXISTS"
+	BADREQUEST           ErrorResponseErrorCode = "BAD_REQUEST"
+	FORBIDDEN            ErrorResponseErrorCode = "FORBIDDEN"
+	IDEMPOTENCYKEYINUSE  ErrorResponseErrorCode = "IDEMPOTENCY_KEY_IN_USE"
+	IDEMPOTENCYKEYREUSED ErrorResponseErrorCode = "IDEMPOTENCY_KEY_REUSED"
+	NOCANDIDATE          ErrorResponseErrorCode = "NO_CANDIDATE"
+	NOTASSIGNED          ErrorResponseErrorCode = "NOT_ASSIGNED"
+	NOTFOUND             ErrorResponseErrorCode = "NOT_FOUND"
+	PREXISTS             ErrorResponseErrorCode = "PR_EXISTS"
+	PRMERGED             ErrorResponseErrorCode = "PR_MERGED"
+	RATELIMITED          ErrorResponseErrorCode = "RATE_LIMITED"
+	TEAMEXISTS           ErrorResponseErrorCode = "TEAM_EXISTS"
+	UNAUTHORIZED         ErrorResponseErrorCode = "UNAUTHORIZED"
+	USERHASACTIVITY      ErrorResponseErrorCode = "USER_HAS_ACTIVITY"
+)
+
+// Defines values for HealthResponseStatus.
+const (
+	HealthResponseStatusOk HealthResponseStatus = "ok"
 )
 
 // Defines values for PullRequestStatus.
@@ -23,12 +45,122 @@ const (
 	PullRequestStatusOPEN   PullRequestStatus = "OPEN"
 )
 
-// Defines values for PullRequestShortStatus.
+// Defines values for ReadinessCheckStatus.
+const (
+	ReadinessCheckStatusFail ReadinessCheckStatus = "fail"
+	ReadinessCheckStatusOk   ReadinessCheckStatus = "ok"
+)
+
+// Defines values for ReadinessResponseStatus.
+const (
+	NotReady ReadinessResponseStatus = "not_ready"
+	Ready    ReadinessResponseStatus = "ready"
+)
+
+// Defines values for ReviewAssignmentStatus.
+const (
+	ReviewAssignmentStatusMERGED ReviewAssignmentStatus = "MERGED"
+	ReviewAssignmentStatusOPEN   ReviewAssignmentStatus = "OPEN"
+)
+
+// Defines values for SortOrder.
+const (
+	Asc  SortOrder = "asc"
+	Desc SortOrder = "desc"
+)
+
+// Defines values for TeamMemberRole.
+const (
+	Lead   TeamMemberRole = "lead"
+	Member TeamMemberRole = "member"
+)
+
+// Defines values for UserDeleteMode.
+const (
+	Anonymize UserDeleteMode = "anonymize"
+	Block     UserDeleteMode = "block"
+	Reassign  UserDeleteMode = "reassign"
+)
+
+// Defines values for GetAnalyticsCycleTimeParamsFormat.
+const (
+	Csv  GetAnalyticsCycleTimeParamsFormat = "csv"
+	Json GetAnalyticsCycleTimeParamsFormat = "json"
+)
+
+// Defines values for PostApiKeysCreateJSONBodyRole.
+const (
+	PostApiKeysCreateJSONBodyRoleAdmin PostApiKeysCreateJSONBodyRole = "admin"
+	PostApiKeysCreateJSONBodyRoleUser  PostApiKeysCreateJSONBodyRole = "user"
+)
+
+// Defines values for GetPullRequestListParamsStatus.
 const (
-	PullRequestShortStatusMERGED PullRequestShortStatus = "MERGED"
-	PullRequestShortStatusOPEN   PullRequestShortStatus = "OPEN"
+	GetPullRequestListParamsStatusMERGED GetPullRequestListParamsStatus = "MERGED"
+	GetPullRequestListParamsStatusOPEN   GetPullRequestListParamsStatus = "OPEN"
 )
 
+// Defines values for GetUsersGetReviewParamsStatus.
+const (
+	GetUsersGetReviewParamsStatusMERGED GetUsersGetReviewParamsStatus = "MERGED"
+	GetUsersGetReviewParamsStatusOPEN   GetUsersGetReviewParamsStatus = "OPEN"
+)
+
+// ApiKey defines model for ApiKey.
+type ApiKey struct {
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	Id         int64      `json:"id"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	Name       string     `json:"name"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	Role       ApiKeyRole `json:"role"`
+
+	// TeamName Команда, которой ограничен ключ
+	TeamName *string `json:"team_name,omitempty"`
+
+	// UserId Пользователь, от имени которого действует ключ с ролью user
+	UserId *string `json:"user_id,omitempty"`
+}
+
+// ApiKeyRole defines model for ApiKey.Role.
+type ApiKeyRole string
+
+// CycleTimeBucket defines model for CycleTimeBucket.
+type CycleTimeBucket struct {
+	Count      int       `json:"count"`
+	P50Seconds float64   `json:"p50_seconds"`
+	P90Seconds float64   `json:"p90_seconds"`
+	P99Seconds float64   `json:"p99_seconds"`
+	WeekStart  time.Time `json:"week_start"`
+}
+
+// CycleTimeReport defines model for CycleTimeReport.
+type CycleTimeReport struct {
+	// ByAuthor Время до мержа по автору
+	ByAuthor []CycleTimeStats `json:"by_author"`
+
+	// ByReviewer Время в ревью (от назначения ревьювера до мержа)
+	ByReviewer []CycleTimeStats `json:"by_reviewer"`
+
+	// ByTeam Время до мержа по команде автора
+	ByTeam []CycleTimeStats `json:"by_team"`
+
+	// Weekly Время до мержа по неделям мержа
+	Weekly []CycleTimeBucket `json:"weekly"`
+}
+
+// CycleTimeStats defines model for CycleTimeStats.
+type CycleTimeStats struct {
+	Count int `json:"count"`
+
+	// Key team_name, author_id или reviewer_id в зависимости от группировки
+	Key        string  `json:"key"`
+	P50Seconds float64 `json:"p50_seconds"`
+	P90Seconds float64 `json:"p90_seconds"`
+	P99Seconds float64 `json:"p99_seconds"`
+}
+
 // ErrorResponse defines model for ErrorResponse.
 type ErrorResponse struct {
 	Error struct {
@@ -40,6 +172,39 @@ type ErrorResponse struct {
 // ErrorResponseErrorCode defines model for ErrorResponse.Error.Code.
 type ErrorResponseErrorCode string
 
+// HealthResponse defines model for HealthResponse.
+type HealthResponse struct {
+	Status HealthResponseStatus `json:"status"`
+}
+
+// HealthResponseStatus defines model for HealthResponse.Status.
+type HealthResponseStatus string
+
+// ImportErrorResponse defines model for ImportErrorResponse.
+type ImportErrorResponse struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+	Errors []struct {
+		Line    int    `json:"line"`
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// ImportResult defines model for ImportResult.
+type ImportResult struct {
+	// MembersAdded Новых записей о членстве; уже существующие не меняются
+	MembersAdded int `json:"members_added"`
+
+	// Teams Команд в файле
+	Teams        int `json:"teams"`
+	TeamsCreated int `json:"teams_created"`
+
+	// Users Пользователей в файле, новые создаются, у существующих обновляется is_active
+	Users int `json:"users"`
+}
+
 // PullRequest defines model for PullRequest.
 type PullRequest struct {
 	// AssignedReviewers user_id назначенных ревьюверов (0..2)
@@ -55,16 +220,63 @@ type PullRequest struct {
 // PullRequestStatus defines model for PullRequest.Status.
 type PullRequestStatus string
 
-// PullRequestShort defines model for PullRequestShort.
-type PullRequestShort struct {
+// ReadinessCheck defines model for ReadinessCheck.
+type ReadinessCheck struct {
+	Message *string `json:"message,omitempty"`
+
+	// Name database, migrations или draining
+	Name   string               `json:"name"`
+	Status ReadinessCheckStatus `json:"status"`
+}
+
+// ReadinessCheckStatus defines model for ReadinessCheck.Status.
+type ReadinessCheckStatus string
+
+// ReadinessResponse defines model for ReadinessResponse.
+type ReadinessResponse struct {
+	Checks []ReadinessCheck        `json:"checks"`
+	Status ReadinessResponseStatus `json:"status"`
+}
+
+// ReadinessResponseStatus defines model for ReadinessResponse.Status.
+type ReadinessResponseStatus string
+
+// ReviewAssignment defines model for ReviewAssignment.
+type ReviewAssignment struct {
+	// AssignedAt Когда пользователь назначен ревьювером
+	AssignedAt      time.Time              `json:"assigned_at"`
 	AuthorId        string                 `json:"author_id"`
+	CreatedAt       time.Time              `json:"created_at"`
 	PullRequestId   string                 `json:"pull_request_id"`
 	PullRequestName string                 `json:"pull_request_name"`
-	Status          PullRequestShortStatus `json:"status"`
+	Status          ReviewAssignmentStatus `json:"status"`
 }
 
-// PullRequestShortStatus defines model for PullRequestShort.Status.
-type PullRequestShortStatus string
+// ReviewAssignmentStatus defines model for ReviewAssignment.Status.
+type ReviewAssignmentStatus string
+
+// ReviewerStats defines model for ReviewerStats.
+type ReviewerStats struct {
+	// Assigned Количество назначенных ревью
+	Assigned int `json:"assigned"`
+
+	// Merged Из них по смерженным PR
+	Merged int `json:"merged"`
+
+	// Open Из них по открытым PR
+	Open int `json:"open"`
+
+	// ReassignedIn Сколько раз ревью было переназначено на пользователя
+	ReassignedIn int `json:"reassigned_in"`
+
+	// ReassignedOut Сколько раз ревью было переназначено с пользователя
+	ReassignedOut int    `json:"reassigned_out"`
+	UserId        string `json:"user_id"`
+	Username      string `json:"username"`
+}
+
+// SortOrder defines model for SortOrder.
+type SortOrder string
 
 // Team defines model for Team.
 type Team struct {
@@ -74,25 +286,170 @@ type Team struct {
 
 // TeamMember defines model for TeamMember.
 type TeamMember struct {
-	IsActive bool   `json:"is_active"`
-	UserId   string `json:"user_id"`
-	Username string `json:"username"`
+	IsActive bool `json:"is_active"`
+
+	// Role Лид может менять состав своей команды, флаг активности её
+	// участников и принудительно переназначать ревьюверов в её PR.
+	Role     *TeamMemberRole `json:"role,omitempty"`
+	UserId   string          `json:"user_id"`
+	Username string          `json:"username"`
+}
+
+// TeamMemberRole Лид может менять состав своей команды, флаг активности её
+// участников и принудительно переназначать ревьюверов в её PR.
+type TeamMemberRole string
+
+// TeamStats defines model for TeamStats.
+type TeamStats struct {
+	Assigned      int    `json:"assigned"`
+	Merged        int    `json:"merged"`
+	Open          int    `json:"open"`
+	ReassignedIn  int    `json:"reassigned_in"`
+	ReassignedOut int    `json:"reassigned_out"`
+	TeamName      string `json:"team_name"`
+}
+
+// TeamSyncDiff defines model for TeamSyncDiff.
+type TeamSyncDiff struct {
+	Activated []string `json:"activated"`
+
+	// Added user_id новых участников
+	Added []string `json:"added"`
+
+	// Created Команды не было, она будет создана
+	Created     bool     `json:"created"`
+	Deactivated []string `json:"deactivated"`
+
+	// ReassignedReviews Открытые ревью удалённых участников, переданные другим участникам
+	ReassignedReviews int `json:"reassigned_reviews"`
+
+	// Removed user_id участников, которых нет в желаемом составе
+	Removed []string `json:"removed"`
+
+	// RemovedReviews Открытые ревью удалённых участников, которые некому передать
+	RemovedReviews int    `json:"removed_reviews"`
+	TeamName       string `json:"team_name"`
 }
 
 // User defines model for User.
 type User struct {
-	IsActive bool   `json:"is_active"`
-	TeamName string `json:"team_name"`
-	UserId   string `json:"user_id"`
-	Username string `json:"username"`
+	// DeletedAt Время анонимизации удалённого пользователя
+	DeletedAt *time.Time `json:"deleted_at"`
+	IsActive  bool       `json:"is_active"`
+	TeamName  string     `json:"team_name"`
+	UserId    string     `json:"user_id"`
+	Username  string     `json:"username"`
 }
 
+// UserDeleteMode block — отказать, если пользователь автор или ревьювер хотя бы одного PR;
+// reassign — передать открытые ревью активным коллегам по команде,
+// ревью без кандидата снимаются;
+// anonymize — снять открытые ревью без замены.
+// Если после этого пользователь остаётся в истории PR, имя заменяется на
+// "deleted user", он деактивируется и исключается из команд; иначе
+// запись удаляется.
+type UserDeleteMode string
+
+// UserDeletion defines model for UserDeletion.
+type UserDeletion struct {
+	// Anonymized true — пользователь остался в истории PR и анонимизирован,
+	// false — запись удалена
+	Anonymized bool `json:"anonymized"`
+
+	// Mode block — отказать, если пользователь автор или ревьювер хотя бы одного PR;
+	// reassign — передать открытые ревью активным коллегам по команде,
+	// ревью без кандидата снимаются;
+	// anonymize — снять открытые ревью без замены.
+	// Если после этого пользователь остаётся в истории PR, имя заменяется на
+	// "deleted user", он деактивируется и исключается из команд; иначе
+	// запись удаляется.
+	Mode              UserDeleteMode `json:"mode"`
+	ReassignedReviews int            `json:"reassigned_reviews"`
+	RemovedReviews    int            `json:"removed_reviews"`
+	UserId            string         `json:"user_id"`
+}
+
+// CreatedAfterQuery defines model for CreatedAfterQuery.
+type CreatedAfterQuery = time.Time
+
+// CreatedBeforeQuery defines model for CreatedBeforeQuery.
+type CreatedBeforeQuery = time.Time
+
+// CursorQuery defines model for CursorQuery.
+type CursorQuery = string
+
+// FromQuery defines model for FromQuery.
+type FromQuery = time.Time
+
+// LimitQuery defines model for LimitQuery.
+type LimitQuery = int
+
+// OrderQuery defines model for OrderQuery.
+type OrderQuery = SortOrder
+
 // TeamNameQuery defines model for TeamNameQuery.
 type TeamNameQuery = string
 
+// ToQuery defines model for ToQuery.
+type ToQuery = time.Time
+
 // UserIdQuery defines model for UserIdQuery.
 type UserIdQuery = string
 
+// Forbidden defines model for Forbidden.
+type Forbidden = ErrorResponse
+
+// IdempotencyKeyReused defines model for IdempotencyKeyReused.
+type IdempotencyKeyReused = ErrorResponse
+
+// TooManyRequests defines model for TooManyRequests.
+type TooManyRequests = ErrorResponse
+
+// Unauthorized defines model for Unauthorized.
+type Unauthorized = ErrorResponse
+
+// GetAnalyticsCycleTimeParams defines parameters for GetAnalyticsCycleTime.
+type GetAnalyticsCycleTimeParams struct {
+	// From Начало временного окна (включительно)
+	From *FromQuery `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Конец временного окна (не включительно)
+	To     *ToQuery                           `form:"to,omitempty" json:"to,omitempty"`
+	Format *GetAnalyticsCycleTimeParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// GetAnalyticsCycleTimeParamsFormat defines parameters for GetAnalyticsCycleTime.
+type GetAnalyticsCycleTimeParamsFormat string
+
+// PostApiKeysCreateJSONBody defines parameters for PostApiKeysCreate.
+type PostApiKeysCreateJSONBody struct {
+	ExpiresAt *time.Time                    `json:"expires_at,omitempty"`
+	Name      string                        `json:"name"`
+	Role      PostApiKeysCreateJSONBodyRole `json:"role"`
+	TeamName  *string                       `json:"team_name,omitempty"`
+	UserId    *string                       `json:"user_id,omitempty"`
+}
+
+// PostApiKeysCreateJSONBodyRole defines parameters for PostApiKeysCreate.
+type PostApiKeysCreateJSONBodyRole string
+
+// PostApiKeysRevokeJSONBody defines parameters for PostApiKeysRevoke.
+type PostApiKeysRevokeJSONBody struct {
+	Id int64 `json:"id"`
+}
+
+// GetExportParams defines parameters for GetExport.
+type GetExportParams struct {
+	// Format ndjson (по умолчанию) или csv
+	Format *string `form:"format,omitempty" json:"format,omitempty"`
+
+	// From Начало временного окна (включительно)
+	From *FromQuery `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Конец временного окна (не включительно)
+	To *ToQuery `form:"to,omitempty" json:"to,omitempty"`
+}
+
 // PostPullRequestCreateJSONBody defines parameters for PostPullRequestCreate.
 type PostPullRequestCreateJSONBody struct {
 	AuthorId        string `json:"author_id"`
@@ -100,6 +457,38 @@ type PostPullRequestCreateJSONBody struct {
 	PullRequestName string `json:"pull_request_name"`
 }
 
+// GetPullRequestGetParams defines parameters for GetPullRequestGet.
+type GetPullRequestGetParams struct {
+	PullRequestId string `form:"pull_request_id" json:"pull_request_id"`
+}
+
+// GetPullRequestListParams defines parameters for GetPullRequestList.
+type GetPullRequestListParams struct {
+	AuthorId   *string                         `form:"author_id,omitempty" json:"author_id,omitempty"`
+	TeamName   *string                         `form:"team_name,omitempty" json:"team_name,omitempty"`
+	Status     *GetPullRequestListParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+	ReviewerId *string                         `form:"reviewer_id,omitempty" json:"reviewer_id,omitempty"`
+	Title      *string                         `form:"title,omitempty" json:"title,omitempty"`
+
+	// CreatedAfter PR созданы не раньше (включительно)
+	CreatedAfter *CreatedAfterQuery `form:"created_after,omitempty" json:"created_after,omitempty"`
+
+	// CreatedBefore PR созданы раньше (не включительно)
+	CreatedBefore *CreatedBeforeQuery `form:"created_before,omitempty" json:"created_before,omitempty"`
+
+	// Order Порядок сортировки по created_at, по умолчанию desc
+	Order *OrderQuery `form:"order,omitempty" json:"order,omitempty"`
+
+	// Limit Размер страницы
+	Limit *LimitQuery `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Значение next_cursor из предыдущего ответа
+	Cursor *CursorQuery `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetPullRequestListParamsStatus defines parameters for GetPullRequestList.
+type GetPullRequestListParamsStatus string
+
 // PostPullRequestMergeJSONBody defines parameters for PostPullRequestMerge.
 type PostPullRequestMergeJSONBody struct {
 	PullRequestId string `json:"pull_request_id"`
@@ -111,16 +500,111 @@ type PostPullRequestReassignJSONBody struct {
 	PullRequestId string `json:"pull_request_id"`
 }
 
+// GetStatsReviewersParams defines parameters for GetStatsReviewers.
+type GetStatsReviewersParams struct {
+	// From Начало временного окна (включительно)
+	From *FromQuery `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Конец временного окна (не включительно)
+	To *ToQuery `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// GetStatsTeamsParams defines parameters for GetStatsTeams.
+type GetStatsTeamsParams struct {
+	// From Начало временного окна (включительно)
+	From *FromQuery `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Конец временного окна (не включительно)
+	To *ToQuery `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// PostTeamDeleteJSONBody defines parameters for PostTeamDelete.
+type PostTeamDeleteJSONBody struct {
+	TeamName string `json:"team_name"`
+}
+
 // GetTeamGetParams defines parameters for GetTeamGet.
 type GetTeamGetParams struct {
 	// TeamName Уникальное имя команды
 	TeamName TeamNameQuery `form:"team_name" json:"team_name"`
 }
 
+// PostTeamRemoveMembersJSONBody defines parameters for PostTeamRemoveMembers.
+type PostTeamRemoveMembersJSONBody struct {
+	TeamName string   `json:"team_name"`
+	UserIds  []string `json:"user_ids"`
+}
+
+// PostTeamRenameJSONBody defines parameters for PostTeamRename.
+type PostTeamRenameJSONBody struct {
+	NewTeamName string `json:"new_team_name"`
+	TeamName    string `json:"team_name"`
+}
+
+// PostTeamSyncJSONBody defines parameters for PostTeamSync.
+type PostTeamSyncJSONBody struct {
+	DryRun *bool  `json:"dry_run,omitempty"`
+	Teams  []Team `json:"teams"`
+}
+
+// PostUsersDeleteJSONBody defines parameters for PostUsersDelete.
+type PostUsersDeleteJSONBody struct {
+	// Mode block — отказать, если пользователь автор или ревьювер хотя бы одного PR;
+	// reassign — передать открытые ревью активным коллегам по команде,
+	// ревью без кандидата снимаются;
+	// anonymize — снять открытые ревью без замены.
+	// Если после этого пользователь остаётся в истории PR, имя заменяется на
+	// "deleted user", он деактивируется и исключается из команд; иначе
+	// запись удаляется.
+	Mode   *UserDeleteMode `json:"mode,omitempty"`
+	UserId string          `json:"user_id"`
+}
+
+// GetUsersGetParams defines parameters for GetUsersGet.
+type GetUsersGetParams struct {
+	// UserId Идентификатор пользователя
+	UserId UserIdQuery `form:"user_id" json:"user_id"`
+}
+
 // GetUsersGetReviewParams defines parameters for GetUsersGetReview.
 type GetUsersGetReviewParams struct {
 	// UserId Идентификатор пользователя
-	UserId UserIdQuery `form:"user_id" json:"user_id"`
+	UserId UserIdQuery                    `form:"user_id" json:"user_id"`
+	Status *GetUsersGetReviewParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// CreatedAfter PR созданы не раньше (включительно)
+	CreatedAfter *CreatedAfterQuery `form:"created_after,omitempty" json:"created_after,omitempty"`
+
+	// CreatedBefore PR созданы раньше (не включительно)
+	CreatedBefore *CreatedBeforeQuery `form:"created_before,omitempty" json:"created_before,omitempty"`
+
+	// Order Порядок сортировки по created_at, по умолчанию desc
+	Order *OrderQuery `form:"order,omitempty" json:"order,omitempty"`
+
+	// Limit Размер страницы
+	Limit *LimitQuery `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Значение next_cursor из предыдущего ответа
+	Cursor *CursorQuery `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetUsersGetReviewParamsStatus defines parameters for GetUsersGetReview.
+type GetUsersGetReviewParamsStatus string
+
+// GetUsersListParams defines parameters for GetUsersList.
+type GetUsersListParams struct {
+	TeamName *string `form:"team_name,omitempty" json:"team_name,omitempty"`
+	IsActive *bool   `form:"is_active,omitempty" json:"is_active,omitempty"`
+
+	// Username Подстрока имени без учёта регистра
+	Username       *string `form:"username,omitempty" json:"username,omitempty"`
+	IncludeDeleted *bool   `form:"include_deleted,omitempty" json:"include_deleted,omitempty"`
+
+	// Limit Размер страницы
+	Limit *LimitQuery `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Значение next_cursor из предыдущего ответа
+	Cursor *CursorQuery `form:"cursor,omitempty" json:"cursor,omitempty"`
 }
 
 // PostUsersSetIsActiveJSONBody defines parameters for PostUsersSetIsActive.
@@ -129,6 +613,19 @@ type PostUsersSetIsActiveJSONBody struct {
 	UserId   string `json:"user_id"`
 }
 
+// PostUsersUpdateJSONBody defines parameters for PostUsersUpdate.
+type PostUsersUpdateJSONBody struct {
+	IsActive *bool   `json:"is_active,omitempty"`
+	UserId   string  `json:"user_id"`
+	Username *string `json:"username,omitempty"`
+}
+
+// PostApiKeysCreateJSONRequestBody defines body for PostApiKeysCreate for application/json ContentType.
+type PostApiKeysCreateJSONRequestBody PostApiKeysCreateJSONBody
+
+// PostApiKeysRevokeJSONRequestBody defines body for PostApiKeysRevoke for application/json ContentType.
+type PostApiKeysRevokeJSONRequestBody PostApiKeysRevokeJSONBody
+
 // PostPullRequestCreateJSONRequestBody defines body for PostPullRequestCreate for application/json ContentType.
 type PostPullRequestCreateJSONRequestBody PostPullRequestCreateJSONBody
 
@@ -141,5 +638,26 @@ type PostPullRequestReassignJSONRequestBody PostPullRequestReassignJSONBody
 // PostTeamAddJSONRequestBody defines body for PostTeamAdd for application/json ContentType.
 type PostTeamAddJSONRequestBody = Team
 
+// PostTeamAddMembersJSONRequestBody defines body for PostTeamAddMembers for application/json ContentType.
+type PostTeamAddMembersJSONRequestBody = Team
+
+// PostTeamDeleteJSONRequestBody defines body for PostTeamDelete for application/json ContentType.
+type PostTeamDeleteJSONRequestBody PostTeamDeleteJSONBody
+
+// PostTeamRemoveMembersJSONRequestBody defines body for PostTeamRemoveMembers for application/json ContentType.
+type PostTeamRemoveMembersJSONRequestBody PostTeamRemoveMembersJSONBody
+
+// PostTeamRenameJSONRequestBody defines body for PostTeamRename for application/json ContentType.
+type PostTeamRenameJSONRequestBody PostTeamRenameJSONBody
+
+// PostTeamSyncJSONRequestBody defines body for PostTeamSync for application/json ContentType.
+type PostTeamSyncJSONRequestBody PostTeamSyncJSONBody
+
+// PostUsersDeleteJSONRequestBody defines body for PostUsersDelete for application/json ContentType.
+type PostUsersDeleteJSONRequestBody PostUsersDeleteJSONBody
+
 // PostUsersSetIsActiveJSONRequestBody defines body for PostUsersSetIsActive for application/json ContentType.
 type PostUsersSetIsActiveJSONRequestBody PostUsersSetIsActiveJSONBody
+
+// PostUsersUpdateJSONRequestBody defines body for PostUsersUpdate for application/json ContentType.
+type PostUsersUpdateJSONRequestBody PostUsersUpdateJSONBody
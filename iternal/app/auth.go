@@ -0,0 +1,37 @@
+package app
+
+import (
+	"context"
+
+	"github.com/chimort/avito_test_task/iternal/auth"
+	"github.com/chimort/avito_test_task/iternal/config"
+)
+
+// newAuthenticator accepts static tokens, issued API keys and, when
+// configured, SSO JWTs. ctx bounds the background JWKS refresh.
+func newAuthenticator(ctx context.Context, cfg config.Auth, apiKeys auth.Authenticator) (auth.Authenticator, error) {
+	chain := auth.Chain{staticTokens(cfg.Tokens), apiKeys}
+
+	if cfg.JWT.Enabled {
+		kf, err := auth.LoadJWKS(ctx, cfg.JWT.JWKSFile, cfg.JWT.JWKSURL)
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, auth.NewJWTVerifier(kf, auth.JWTConfig{
+			Issuer:    cfg.JWT.Issuer,
+			Audience:  cfg.JWT.Audience,
+			UserClaim: cfg.JWT.UserClaim,
+			RoleClaim: cfg.JWT.RoleClaim,
+			Leeway:    cfg.JWT.Leeway,
+		}))
+	}
+	return chain, nil
+}
+
+func staticTokens(tokens []config.Token) *auth.StaticTokens {
+	st := auth.NewStaticTokens()
+	for _, t := range tokens {
+		st.Add(t.Token, auth.Principal{Name: t.Name, Role: auth.Role(t.Role), UserID: t.UserID})
+	}
+	return st
+}
@@ -1,40 +1,58 @@
 package app
 
 import (
+	"context"
 	"database/sql"
-	"os"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
 	"time"
 
+	"github.com/XSAM/otelsql"
+	"github.com/chimort/avito_test_task/iternal/config"
 	"github.com/chimort/avito_test_task/iternal/pkg/logger"
 	_ "github.com/lib/pq"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
 )
 
-func InitDB(log *logger.Logger) *sql.DB {
-	dbHost := os.Getenv("DB_HOST")
-	dbPort := os.Getenv("DB_PORT")
-	dbUser := os.Getenv("DB_USER")
-	dbPass := os.Getenv("DB_PASSWORD")
-	dbName := os.Getenv("DB_NAME")
-
-	dsn := "postgres://" + dbUser + ":" + dbPass + "@" + dbHost + ":" + dbPort + "/" + dbName + "?sslmode=disable"
+func DSN(cfg config.DB) string {
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(cfg.User, cfg.Password),
+		Host:     net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+		Path:     "/" + cfg.Name,
+		RawQuery: url.Values{"sslmode": {cfg.SSLMode}}.Encode(),
+	}
+	return u.String()
+}
 
-	var db *sql.DB
-	var err error
+func InitDB(ctx context.Context, log *logger.Logger, cfg config.DB) (*sql.DB, error) {
+	db, err := otelsql.Open("postgres", DSN(cfg), otelsql.WithAttributes(semconv.DBSystemNamePostgreSQL))
+	if err != nil {
+		return nil, fmt.Errorf("open db: %w", err)
+	}
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
 
-	for i := 0; i < 5; i++ {
-		db, err = sql.Open("postgres", dsn)
+	for i := 0; i < cfg.ConnectRetries; i++ {
+		err = db.PingContext(ctx)
 		if err == nil {
-			err = db.Ping()
-			if err == nil {
-				log.Info("DB connected")
-				return db
-			}
+			log.Info("DB connected")
+			return db, nil
 		}
 		log.Error("DB ping failed, retrying...", "error", err)
-		time.Sleep(2 * time.Second)
+
+		select {
+		case <-ctx.Done():
+			_ = db.Close()
+			return nil, ctx.Err()
+		case <-time.After(cfg.ConnectRetryInterval):
+		}
 	}
 
-	log.Error("Failed to connect to DB after retries", "error", err)
-	os.Exit(1)
-	return nil
+	_ = db.Close()
+	return nil, fmt.Errorf("connect to db after retries: %w", err)
 }
@@ -0,0 +1,126 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/chimort/avito_test_task/iternal/auth"
+	"github.com/chimort/avito_test_task/iternal/grpcapi"
+	"github.com/chimort/avito_test_task/iternal/middleware"
+	"github.com/chimort/avito_test_task/iternal/pkg/logger"
+	"github.com/chimort/avito_test_task/iternal/pkg/metrics"
+	"github.com/chimort/avito_test_task/iternal/service"
+	prv1 "github.com/chimort/avito_test_task/proto/pr/v1"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc/filters"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// healthCheckInterval is how often the gRPC health status is refreshed
+// from the readiness checks.
+const healthCheckInterval = 5 * time.Second
+
+// newGRPCServer serves PRService with health and reflection, traced and
+// measured like the HTTP API. authn is nil when authentication is disabled
+// and the limiters are nil when rate limiting is; they are the ones the HTTP
+// API uses, so both APIs share the buckets.
+func newGRPCServer(us service.UserServiceInterface, ks service.APIKeyServiceInterface, authn auth.Authenticator, authFailures, limiter *middleware.RateLimiter, log *logger.Logger) (*grpc.Server, *health.Server) {
+	unary := []grpc.UnaryServerInterceptor{metrics.UnaryServerInterceptor()}
+	stream := []grpc.StreamServerInterceptor{metrics.StreamServerInterceptor()}
+	if authFailures != nil {
+		unary = append(unary, grpcapi.UnaryFailedAuthLimit(authFailures, log))
+		stream = append(stream, grpcapi.StreamFailedAuthLimit(authFailures, log))
+	}
+	if authn != nil {
+		unary = append(unary, grpcapi.UnaryAuth(authn, log))
+		stream = append(stream, grpcapi.StreamAuth(authn, log))
+	}
+	if limiter != nil {
+		unary = append(unary, grpcapi.UnaryRateLimit(limiter, log))
+		stream = append(stream, grpcapi.StreamRateLimit(limiter, log))
+	}
+
+	s := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler(otelgrpc.WithFilter(filters.Not(filters.HealthCheck())))),
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	)
+	grpcapi.NewServer(us, ks, log).Register(s)
+	hs := health.NewServer()
+	hs.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	hs.SetServingStatus(prv1.PRService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_NOT_SERVING)
+	healthpb.RegisterHealthServer(s, hs)
+	reflection.Register(s)
+	return s, hs
+}
+
+// StartGRPC serves the gRPC API until Shutdown is called. It returns nil
+// after a graceful shutdown and the listener error otherwise.
+func (s *Server) StartGRPC(addr string) error {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("listen grpc: %w", err)
+	}
+	s.grpcAddr.Store(lis.Addr())
+	s.Go(func(ctx context.Context) {
+		watchHealth(ctx, s.readiness, s.health, healthCheckInterval)
+	})
+
+	s.log.Info("gRPC server started on " + addr)
+	if err := s.grpc.Serve(lis); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return fmt.Errorf("serve grpc: %w", err)
+	}
+	return nil
+}
+
+// GRPCAddr returns the address the gRPC server listens on, or nil before
+// StartGRPC.
+func (s *Server) GRPCAddr() net.Addr {
+	addr, _ := s.grpcAddr.Load().(net.Addr)
+	return addr
+}
+
+// watchHealth mirrors /readyz into the gRPC health service until ctx is
+// done.
+func watchHealth(ctx context.Context, r *Readiness, hs *health.Server, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		status := healthpb.HealthCheckResponse_SERVING
+		if r.CheckReadiness(ctx).Status != api.Ready {
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+		hs.SetServingStatus("", status)
+		hs.SetServingStatus(prv1.PRService_ServiceDesc.ServiceName, status)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// stopGRPC waits for in-flight calls within ctx, then closes the remaining
+// connections.
+func (s *Server) stopGRPC(ctx context.Context) error {
+	stopped := make(chan struct{})
+	go func() {
+		s.grpc.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.grpc.Stop()
+		return ctx.Err()
+	}
+}
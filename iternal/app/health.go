@@ -0,0 +1,94 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/chimort/avito_test_task/migrations"
+)
+
+const readinessCheckTimeout = 2 * time.Second
+
+// Readiness reports whether the server may receive traffic: the database is
+// reachable, its schema is at the version embedded in the binary and the
+// server is not draining.
+type Readiness struct {
+	db       *sql.DB
+	draining atomic.Bool
+}
+
+func NewReadiness(db *sql.DB) *Readiness {
+	return &Readiness{db: db}
+}
+
+func (r *Readiness) SetDraining(draining bool) {
+	r.draining.Store(draining)
+}
+
+func (r *Readiness) CheckReadiness(ctx context.Context) api.ReadinessResponse {
+	ctx, cancel := context.WithTimeout(ctx, readinessCheckTimeout)
+	defer cancel()
+
+	checks := []api.ReadinessCheck{
+		r.checkDatabase(ctx),
+		r.checkMigrations(ctx),
+		r.checkDraining(),
+	}
+
+	status := api.Ready
+	for _, c := range checks {
+		if c.Status != api.ReadinessCheckStatusOk {
+			status = api.NotReady
+		}
+	}
+	return api.ReadinessResponse{Status: status, Checks: checks}
+}
+
+func (r *Readiness) checkDatabase(ctx context.Context) api.ReadinessCheck {
+	if err := r.db.PingContext(ctx); err != nil {
+		return failedCheck("database", err.Error())
+	}
+	return api.ReadinessCheck{Name: "database", Status: api.ReadinessCheckStatusOk}
+}
+
+func (r *Readiness) checkMigrations(ctx context.Context) api.ReadinessCheck {
+	expected, err := migrations.ExpectedVersion()
+	if err != nil {
+		return failedCheck("migrations", err.Error())
+	}
+
+	var version uint
+	var dirty bool
+	err = r.db.QueryRowContext(ctx, `select version, dirty from schema_migrations limit 1`).Scan(&version, &dirty)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return failedCheck("migrations", "no migrations applied")
+		}
+		return failedCheck("migrations", err.Error())
+	}
+	if dirty {
+		return failedCheck("migrations", fmt.Sprintf("version %d is dirty", version))
+	}
+	if version != expected {
+		return failedCheck("migrations", fmt.Sprintf("version %d, expected %d", version, expected))
+	}
+
+	msg := fmt.Sprintf("version %d", version)
+	return api.ReadinessCheck{Name: "migrations", Status: api.ReadinessCheckStatusOk, Message: &msg}
+}
+
+func (r *Readiness) checkDraining() api.ReadinessCheck {
+	if r.draining.Load() {
+		return failedCheck("draining", "server is shutting down")
+	}
+	return api.ReadinessCheck{Name: "draining", Status: api.ReadinessCheckStatusOk}
+}
+
+func failedCheck(name, msg string) api.ReadinessCheck {
+	return api.ReadinessCheck{Name: name, Status: api.ReadinessCheckStatusFail, Message: &msg}
+}
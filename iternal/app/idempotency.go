@@ -0,0 +1,33 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/chimort/avito_test_task/iternal/pkg/logger"
+	"github.com/chimort/avito_test_task/iternal/repository"
+)
+
+// cleanupIdempotencyKeys deletes expired idempotency keys every interval
+// until ctx is cancelled. Expired keys are ignored on lookup anyway, this
+// only keeps the table small.
+func cleanupIdempotencyKeys(ctx context.Context, repo *repository.IdempotencyRepository, interval time.Duration, log *logger.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := repo.DeleteExpired(ctx)
+			if err != nil {
+				log.Error("failed to delete expired idempotency keys", "error", err)
+				continue
+			}
+			if n > 0 {
+				log.Info("deleted expired idempotency keys", "count", n)
+			}
+		}
+	}
+}
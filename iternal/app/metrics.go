@@ -0,0 +1,86 @@
+package app
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/chimort/avito_test_task/iternal/pkg/logger"
+	"github.com/chimort/avito_test_task/iternal/repository"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// teamMetricsInterval is how often the per-team gauges are reloaded.
+const teamMetricsInterval = 30 * time.Second
+
+// teamCollector serves per-team gauges loaded by run in the background, so
+// that scraping the unauthenticated /metrics never queries the database.
+// The values may lag writes by up to teamMetricsInterval.
+type teamCollector struct {
+	repo *repository.UserRepository
+	log  *logger.Logger
+
+	openPRs     *prometheus.Desc
+	activeUsers *prometheus.Desc
+
+	mu       sync.Mutex
+	activity []repository.TeamActivity
+}
+
+func newTeamCollector(repo *repository.UserRepository, log *logger.Logger) *teamCollector {
+	return &teamCollector{
+		repo: repo,
+		log:  log,
+		openPRs: prometheus.NewDesc("pr_service_team_open_pull_requests",
+			"Open pull requests authored by team members.", []string{"team"}, nil),
+		activeUsers: prometheus.NewDesc("pr_service_team_active_users",
+			"Active users in the team.", []string{"team"}, nil),
+	}
+}
+
+func (c *teamCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.openPRs
+	ch <- c.activeUsers
+}
+
+func (c *teamCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	activity := c.activity
+	c.mu.Unlock()
+
+	for _, a := range activity {
+		ch <- prometheus.MustNewConstMetric(c.openPRs, prometheus.GaugeValue, float64(a.OpenPRs), a.TeamName)
+		ch <- prometheus.MustNewConstMetric(c.activeUsers, prometheus.GaugeValue, float64(a.ActiveUsers), a.TeamName)
+	}
+}
+
+// run reloads the gauges now and then every interval until ctx is done.
+func (c *teamCollector) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		c.refresh(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// refresh keeps the previous values when the query fails.
+func (c *teamCollector) refresh(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	activity, err := c.repo.GetTeamActivity(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			c.log.Error("failed to collect team metrics", "error", err)
+		}
+		return
+	}
+	c.mu.Lock()
+	c.activity = activity
+	c.mu.Unlock()
+}
@@ -2,40 +2,50 @@ package app
 
 import (
 	"database/sql"
-	"os"
+	"errors"
+	"fmt"
 
 	"github.com/chimort/avito_test_task/iternal/pkg/logger"
+	"github.com/chimort/avito_test_task/migrations"
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
 )
 
-func RunMigrations(log *logger.Logger, db *sql.DB) {
-
+// RunMigrations applies migrations from sourceURL, or the migrations
+// embedded into the binary when sourceURL is empty.
+func RunMigrations(log *logger.Logger, db *sql.DB, sourceURL string) error {
 	driver, err := postgres.WithInstance(db, &postgres.Config{})
 	if err != nil {
-		log.Error("failed to create migration driver", "error", err)
-		os.Exit(1)
+		return fmt.Errorf("create migration driver: %w", err)
 	}
 
-	m, err := migrate.NewWithDatabaseInstance(
-		"file:///app/migrations",
-		"postgres",
-		driver,
-	)
-	if err != nil {
-		log.Error("failed to create migrate instance", "error", err)
-		os.Exit(1)
+	var m *migrate.Migrate
+	if sourceURL == "" {
+		src, err := iofs.New(migrations.FS, ".")
+		if err != nil {
+			return fmt.Errorf("open embedded migrations: %w", err)
+		}
+		m, err = migrate.NewWithInstance("iofs", src, "postgres", driver)
+		if err != nil {
+			return fmt.Errorf("create migrate instance: %w", err)
+		}
+	} else {
+		m, err = migrate.NewWithDatabaseInstance(sourceURL, "postgres", driver)
+		if err != nil {
+			return fmt.Errorf("create migrate instance: %w", err)
+		}
 	}
 
 	if err := m.Up(); err != nil {
-		if err == migrate.ErrNoChange {
+		if errors.Is(err, migrate.ErrNoChange) {
 			log.Info("No migrations to apply")
-		} else {
-			log.Error("failed to apply migrations", "error", err)
-			os.Exit(1)
+			return nil
 		}
-	} else {
-		log.Info("Migrations applied")
+		return fmt.Errorf("apply migrations: %w", err)
 	}
+
+	log.Info("Migrations applied")
+	return nil
 }
@@ -0,0 +1,136 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/chimort/avito_test_task/iternal/pkg/logger"
+)
+
+// Startup stages reported by ProbeServer on /readyz.
+const (
+	StageDBConnecting      = "db: connecting"
+	StageMigrationsRunning = "migrations: running"
+)
+
+// ProbeServer answers /healthz and /readyz on the HTTP address while the
+// database is connected and migrated, so that probes see a live process
+// that is not ready yet instead of a refused connection. Handover then
+// gives its listener to the full server.
+type ProbeServer struct {
+	srv       *http.Server
+	lis       *probeListener
+	stage     atomic.Value
+	served    chan struct{}
+	handedOff bool
+}
+
+// ServeProbes starts serving probes on addr with StageDBConnecting.
+func ServeProbes(addr string, log *logger.Logger) (*ProbeServer, error) {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("listen: %w", err)
+	}
+
+	p := &ProbeServer{
+		lis:    &probeListener{TCPListener: l.(*net.TCPListener)},
+		served: make(chan struct{}),
+	}
+	p.stage.Store(StageDBConnecting)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, api.HealthResponse{Status: api.HealthResponseStatusOk})
+	})
+	// Everything else, /readyz included, is not available yet.
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusServiceUnavailable, api.ReadinessResponse{
+			Status: api.NotReady,
+			Checks: []api.ReadinessCheck{failedCheck("startup", p.Stage())},
+		})
+	})
+	p.srv = &http.Server{Handler: mux, ReadHeaderTimeout: readinessCheckTimeout}
+
+	go func() {
+		defer close(p.served)
+		_ = p.srv.Serve(p.lis)
+	}()
+	log.Info("serving probes on " + addr + " until startup completes")
+	return p, nil
+}
+
+// Addr returns the address probes are served on.
+func (p *ProbeServer) Addr() net.Addr {
+	return p.lis.Addr()
+}
+
+func (p *ProbeServer) SetStage(stage string) {
+	p.stage.Store(stage)
+}
+
+func (p *ProbeServer) Stage() string {
+	stage, _ := p.stage.Load().(string)
+	return stage
+}
+
+// Handover stops serving probes, waiting within ctx for requests in
+// flight, and returns the still open listener for Server.Serve.
+func (p *ProbeServer) Handover(ctx context.Context) (net.Listener, error) {
+	if err := p.srv.Shutdown(ctx); err != nil {
+		return nil, fmt.Errorf("stop probes: %w", err)
+	}
+	<-p.served
+	if err := p.lis.TCPListener.SetDeadline(time.Time{}); err != nil {
+		return nil, err
+	}
+	p.handedOff = true
+	return p.lis.TCPListener, nil
+}
+
+// Close stops serving probes and closes the listener. After Handover the
+// listener belongs to the server and Close does nothing.
+func (p *ProbeServer) Close() error {
+	if p.handedOff {
+		return nil
+	}
+	_ = p.srv.Close()
+	<-p.served
+	return p.lis.TCPListener.Close()
+}
+
+// probeListener stops the probe server's accept loop without closing the
+// socket: Close only sets a deadline that wakes up a pending Accept.
+type probeListener struct {
+	*net.TCPListener
+	closed atomic.Bool
+}
+
+func (l *probeListener) Accept() (net.Conn, error) {
+	c, err := l.TCPListener.Accept()
+	if err != nil && l.closed.Load() {
+		return nil, net.ErrClosed
+	}
+	return c, err
+}
+
+func (l *probeListener) Close() error {
+	l.closed.Store(true)
+	err := l.TCPListener.SetDeadline(time.Now())
+	if errors.Is(err, net.ErrClosed) {
+		return nil
+	}
+	return err
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
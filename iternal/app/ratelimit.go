@@ -0,0 +1,47 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/chimort/avito_test_task/iternal/config"
+	"github.com/chimort/avito_test_task/iternal/middleware"
+)
+
+// rateLimiterIdle is how long an unused bucket is kept. It must exceed the
+// refill time of the slowest bucket, or pruning would hand out free tokens.
+const rateLimiterIdle = 30 * time.Minute
+
+func newRateLimiter(cfg config.RateLimit) *middleware.RateLimiter {
+	routes := make(map[string]middleware.RateLimit, len(cfg.Routes))
+	for _, r := range cfg.Routes {
+		routes[r.Route] = middleware.RateLimit{RequestsPerMinute: r.RequestsPerMinute, Burst: r.Burst}
+	}
+	return middleware.NewRateLimiter(
+		middleware.RateLimit{RequestsPerMinute: cfg.RequestsPerMinute, Burst: cfg.Burst},
+		routes,
+	)
+}
+
+// newAuthFailureLimiter counts failed authentications per IP; see
+// middleware.FailedAuthLimit.
+func newAuthFailureLimiter(cfg config.RateLimit) *middleware.RateLimiter {
+	return middleware.NewRateLimiter(
+		middleware.RateLimit{RequestsPerMinute: cfg.AuthFailuresPerMinute, Burst: cfg.AuthFailureBurst},
+		nil,
+	)
+}
+
+func pruneRateLimiter(ctx context.Context, limiter *middleware.RateLimiter) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			limiter.Prune(rateLimiterIdle)
+		}
+	}
+}
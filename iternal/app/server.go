@@ -1,41 +1,219 @@
 package app
 
 import (
+	"context"
 	"database/sql"
-	"os"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/chimort/avito_test_task/iternal/auth"
+	"github.com/chimort/avito_test_task/iternal/config"
 	"github.com/chimort/avito_test_task/iternal/handlers"
+	"github.com/chimort/avito_test_task/iternal/middleware"
 	"github.com/chimort/avito_test_task/iternal/pkg/logger"
+	"github.com/chimort/avito_test_task/iternal/pkg/metrics"
 	"github.com/chimort/avito_test_task/iternal/repository"
+	"github.com/chimort/avito_test_task/iternal/scim"
 	"github.com/chimort/avito_test_task/iternal/service"
 	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
 )
 
 type Server struct {
-	echo *echo.Echo
-	log  *logger.Logger
+	echo      *echo.Echo
+	grpc      *grpc.Server
+	health    *health.Server
+	grpcAddr  atomic.Value
+	db        *sql.DB
+	readiness *Readiness
+	log       *logger.Logger
+
+	drainDelay time.Duration
+
+	workersCtx  context.Context
+	stopWorkers context.CancelFunc
+	workers     sync.WaitGroup
 }
 
-func NewServer(log *logger.Logger, db *sql.DB) *Server {
+func NewServer(log *logger.Logger, db *sql.DB, cfg *config.Config) (*Server, error) {
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+
 	e := echo.New()
+	e.HideBanner = true
+	e.Server.ReadTimeout = cfg.HTTP.ReadTimeout
+	e.Server.WriteTimeout = cfg.HTTP.WriteTimeout
+	e.Server.IdleTimeout = cfg.HTTP.IdleTimeout
 
 	repo := repository.NewUserRepository(db)
-	userService := service.NewUserService(repo, log)
-	h := handlers.NewHandlers(userService, log)
+	userService := service.NewUserService(repo, cfg.Assignment, log)
+	apiKeyService := service.NewAPIKeyService(repository.NewAPIKeyRepository(db), log)
+	idempotency := repository.NewIdempotencyRepository(db)
+	readiness := NewReadiness(db)
+	h := handlers.NewHandlers(userService, apiKeyService, readiness, log)
+
+	if err := metrics.Register(collectors.NewDBStatsCollector(db, "postgres")); err != nil {
+		log.Warn("failed to register db metrics", "error", err)
+	}
+	teamMetrics := newTeamCollector(repo, log)
+	if err := metrics.Register(teamMetrics); err != nil {
+		log.Warn("failed to register team metrics", "error", err)
+	}
 
+	e.Use(otelecho.Middleware(cfg.Tracing.ServiceName, otelecho.WithSkipper(isProbe)))
+	e.Use(metrics.Middleware())
+	var authn auth.Authenticator
+	var authFailures *middleware.RateLimiter
+	if cfg.Auth.Enabled {
+		var err error
+		authn, err = newAuthenticator(workersCtx, cfg.Auth, apiKeyService)
+		if err != nil {
+			stopWorkers()
+			return nil, err
+		}
+		// Rejected tokens never reach the per-client limiter below.
+		if cfg.RateLimit.Enabled {
+			authFailures = newAuthFailureLimiter(cfg.RateLimit)
+			e.Use(middleware.FailedAuthLimit(authFailures, log))
+		}
+		e.Use(middleware.Auth(authn, log))
+	} else {
+		log.Warn("authentication is disabled")
+	}
+	var limiter *middleware.RateLimiter
+	if cfg.RateLimit.Enabled {
+		limiter = newRateLimiter(cfg.RateLimit)
+		e.Use(limiter.Middleware(log))
+	}
+	e.Use(middleware.Idempotency(idempotency, cfg.Idempotency.TTL, cfg.Idempotency.Lease, log))
+	e.GET("/metrics", echo.WrapHandler(metrics.Handler()))
 	api.RegisterHandlers(e, h)
+	scim.NewHandler(userService, log).Register(e)
+	grpcServer, healthServer := newGRPCServer(userService, apiKeyService, authn, authFailures, limiter, log)
+
+	s := &Server{
+		echo:        e,
+		grpc:        grpcServer,
+		health:      healthServer,
+		db:          db,
+		readiness:   readiness,
+		log:         log,
+		drainDelay:  cfg.HTTP.DrainDelay,
+		workersCtx:  workersCtx,
+		stopWorkers: stopWorkers,
+	}
+	s.Go(func(ctx context.Context) {
+		teamMetrics.run(ctx, teamMetricsInterval)
+	})
+	s.Go(func(ctx context.Context) {
+		cleanupIdempotencyKeys(ctx, idempotency, cfg.Idempotency.CleanupInterval, log)
+	})
+	if limiter != nil {
+		s.Go(func(ctx context.Context) {
+			pruneRateLimiter(ctx, limiter)
+		})
+	}
+	if authFailures != nil {
+		s.Go(func(ctx context.Context) {
+			pruneRateLimiter(ctx, authFailures)
+		})
+	}
+	return s, nil
+}
+
+// Start serves HTTP until Shutdown is called. It returns nil after a
+// graceful shutdown and the listener error otherwise.
+func (s *Server) Start(addr string) error {
+	s.log.Info("Server started on " + addr)
+	if err := s.echo.Start(addr); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("serve: %w", err)
+	}
+	return nil
+}
+
+// Serve is Start on an open listener, such as the one ProbeServer hands
+// over.
+func (s *Server) Serve(l net.Listener) error {
+	s.echo.Listener = l
+	return s.Start(l.Addr().String())
+}
+
+// Addr returns the address the server listens on, or nil before Start.
+func (s *Server) Addr() net.Addr {
+	return s.echo.ListenerAddr()
+}
+
+// Go runs fn in the background. The context passed to fn is cancelled by
+// Shutdown, which then waits for fn to return.
+func (s *Server) Go(fn func(ctx context.Context)) {
+	s.workers.Add(1)
+	go func() {
+		defer s.workers.Done()
+		fn(s.workersCtx)
+	}()
+}
+
+// Shutdown marks the server as not ready and keeps serving for the drain
+// delay, then waits for in-flight requests and background workers to
+// finish within ctx, and closes the database.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.log.Info("Server shutting down")
+	s.readiness.SetDraining(true)
+	s.health.Shutdown()
+
+	// Keep serving until load balancers have seen /readyz fail and stopped
+	// sending new requests.
+	if s.drainDelay > 0 {
+		s.log.Info("draining before closing listeners", "delay", s.drainDelay.String())
+		select {
+		case <-time.After(s.drainDelay):
+		case <-ctx.Done():
+		}
+	}
+
+	var errs []error
+	if err := s.echo.Shutdown(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("shutdown http: %w", err))
+	}
+	if err := s.stopGRPC(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("shutdown grpc: %w", err))
+	}
+
+	s.stopWorkers()
+	done := make(chan struct{})
+	go func() {
+		s.workers.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		errs = append(errs, fmt.Errorf("wait for workers: %w", ctx.Err()))
+	}
+
+	if err := s.db.Close(); err != nil {
+		errs = append(errs, fmt.Errorf("close db: %w", err))
+	}
 
-	return &Server{
-		echo: e,
-		log:  log,
+	if err := errors.Join(errs...); err != nil {
+		return err
 	}
+	s.log.Info("Server stopped")
+	return nil
 }
 
-func (s *Server) Start(port string) {
-	s.log.Info("Server started on " + port)
-	if err := s.echo.Start(port); err != nil {
-		s.log.Error("failed to serve", "error", err)
-		os.Exit(1)
+func isProbe(c echo.Context) bool {
+	switch c.Path() {
+	case "/healthz", "/readyz", "/metrics":
+		return true
 	}
+	return false
 }
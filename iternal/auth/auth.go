@@ -0,0 +1,98 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+)
+
+type Role string
+
+const (
+	RoleAdmin Role = "admin"
+	RoleUser  Role = "user"
+)
+
+func (r Role) Valid() bool {
+	return r == RoleAdmin || r == RoleUser
+}
+
+var ErrInvalidToken = errors.New("invalid token")
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	// Name identifies the credential in logs, never the secret itself.
+	Name string
+	// ID tells credentials apart, e.g. for rate limits. Unlike Name it is
+	// unique per static token, API key or SSO subject.
+	ID     string
+	Role   Role
+	UserID string
+	// Team, when set, limits the caller to users and pull requests of
+	// that team.
+	Team string
+}
+
+func (p *Principal) IsAdmin() bool {
+	return p.Role == RoleAdmin
+}
+
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (*Principal, error)
+}
+
+// Chain tries each authenticator in order until one accepts the token.
+type Chain []Authenticator
+
+func (c Chain) Authenticate(ctx context.Context, token string) (*Principal, error) {
+	for _, a := range c {
+		p, err := a.Authenticate(ctx, token)
+		if errors.Is(err, ErrInvalidToken) {
+			continue
+		}
+		return p, err
+	}
+	return nil, ErrInvalidToken
+}
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext returns the caller, or false when authentication is disabled.
+func FromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok
+}
+
+// StaticTokens authenticates against a fixed set of tokens from config.
+// Tokens are kept as SHA-256 digests so lookups don't compare secrets
+// byte by byte.
+type StaticTokens struct {
+	tokens map[[sha256.Size]byte]Principal
+}
+
+func NewStaticTokens() *StaticTokens {
+	return &StaticTokens{tokens: map[[sha256.Size]byte]Principal{}}
+}
+
+// Add accepts token as p. Unless p has an ID, it gets one derived from the
+// token digest.
+func (s *StaticTokens) Add(token string, p Principal) {
+	sum := sha256.Sum256([]byte(token))
+	if p.ID == "" {
+		p.ID = "token:" + hex.EncodeToString(sum[:8])
+	}
+	s.tokens[sum] = p
+}
+
+func (s *StaticTokens) Authenticate(ctx context.Context, token string) (*Principal, error) {
+	p, ok := s.tokens[sha256.Sum256([]byte(token))]
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+	return &p, nil
+}
@@ -0,0 +1,120 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/MicahParks/keyfunc/v3"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type JWTConfig struct {
+	Issuer   string
+	Audience string
+	// UserClaim and RoleClaim name the claims mapped to users.id and the
+	// role. Dots descend into nested objects.
+	UserClaim string
+	RoleClaim string
+	Leeway    time.Duration
+}
+
+// JWTVerifier authenticates JWTs signed by keys from a JWKS.
+type JWTVerifier struct {
+	keyfunc jwt.Keyfunc
+	parser  *jwt.Parser
+	cfg     JWTConfig
+}
+
+func NewJWTVerifier(kf jwt.Keyfunc, cfg JWTConfig) *JWTVerifier {
+	return &JWTVerifier{
+		keyfunc: kf,
+		parser: jwt.NewParser(
+			jwt.WithIssuer(cfg.Issuer),
+			jwt.WithAudience(cfg.Audience),
+			jwt.WithExpirationRequired(),
+			jwt.WithLeeway(cfg.Leeway),
+			jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}),
+		),
+		cfg: cfg,
+	}
+}
+
+// LoadJWKS reads a key set from file, or from url with background refresh
+// until ctx is cancelled. Exactly one of them must be set.
+func LoadJWKS(ctx context.Context, file, url string) (jwt.Keyfunc, error) {
+	if file != "" {
+		raw, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("read jwks: %w", err)
+		}
+		kf, err := keyfunc.NewJWKSetJSON(json.RawMessage(raw))
+		if err != nil {
+			return nil, fmt.Errorf("parse jwks %s: %w", file, err)
+		}
+		return kf.Keyfunc, nil
+	}
+
+	kf, err := keyfunc.NewDefaultCtx(ctx, []string{url})
+	if err != nil {
+		return nil, fmt.Errorf("load jwks %s: %w", url, err)
+	}
+	return kf.Keyfunc, nil
+}
+
+func (v *JWTVerifier) Authenticate(ctx context.Context, token string) (*Principal, error) {
+	// Not a JWT; let the next authenticator try.
+	if strings.Count(token, ".") != 2 {
+		return nil, ErrInvalidToken
+	}
+
+	claims := jwt.MapClaims{}
+	if _, err := v.parser.ParseWithClaims(token, claims, v.keyfunc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	userID, _ := lookupClaim(claims, v.cfg.UserClaim).(string)
+	role := roleFromClaim(lookupClaim(claims, v.cfg.RoleClaim))
+	if role == RoleUser && userID == "" {
+		return nil, fmt.Errorf("%w: missing %s claim", ErrInvalidToken, v.cfg.UserClaim)
+	}
+
+	name, _ := claims["sub"].(string)
+	if name == "" {
+		name = userID
+	}
+	return &Principal{Name: "jwt:" + name, ID: "jwt:" + name, Role: role, UserID: userID}, nil
+}
+
+func lookupClaim(claims map[string]any, path string) any {
+	var cur any = claims
+	for _, part := range strings.Split(path, ".") {
+		m, ok := cur.(map[string]any)
+		if !ok {
+			return nil
+		}
+		cur = m[part]
+	}
+	return cur
+}
+
+// roleFromClaim maps the role claim, a single value or a list of groups,
+// to a role. Anything but admin is a regular user.
+func roleFromClaim(v any) Role {
+	switch v := v.(type) {
+	case string:
+		if Role(v) == RoleAdmin {
+			return RoleAdmin
+		}
+	case []any:
+		for _, item := range v {
+			if s, _ := item.(string); Role(s) == RoleAdmin {
+				return RoleAdmin
+			}
+		}
+	}
+	return RoleUser
+}
@@ -0,0 +1,179 @@
+// Package authz holds the permission rules of the HTTP and gRPC APIs, so
+// that both transports check the same things. The caller is read from the
+// context; without auth there is no caller and everything is allowed.
+package authz
+
+import (
+	"context"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/chimort/avito_test_task/iternal/auth"
+)
+
+// Denied is a failed permission check. Reason is meant for the caller.
+type Denied struct {
+	Reason string
+}
+
+func (d *Denied) Error() string {
+	return d.Reason
+}
+
+var (
+	ErrOutOfScope      = &Denied{Reason: "outside of the key's team scope"}
+	ErrNotLead         = &Denied{Reason: "only admins and leads of the team are allowed"}
+	ErrOtherTeamsUsers = &Denied{Reason: "only admins can add users from other teams"}
+)
+
+// Lookup is the part of service.UserServiceInterface the checks need.
+type Lookup interface {
+	UserInTeam(ctx context.Context, userID, teamName string) (bool, error)
+	PullRequestInTeam(ctx context.Context, pullRequestId, teamName string) (bool, error)
+	IsTeamLead(ctx context.Context, userID, teamName string) (bool, error)
+	UsersOutsideTeam(ctx context.Context, teamName string, userIDs []string) ([]string, error)
+	LeadsUser(ctx context.Context, leadID, userID string) (bool, error)
+	LeadsPullRequest(ctx context.Context, leadID, pullRequestId string) (bool, error)
+}
+
+// Checker runs the checks that need to look at the data.
+type Checker struct {
+	lookup Lookup
+}
+
+func NewChecker(l Lookup) *Checker {
+	return &Checker{lookup: l}
+}
+
+// ActsAs reports whether the caller may act on behalf of userID. Admins may
+// act for anyone, users only for themselves.
+func ActsAs(ctx context.Context, userID string) bool {
+	p, ok := auth.FromContext(ctx)
+	return !ok || p.IsAdmin() || p.UserID == userID
+}
+
+// TeamScope returns the team the caller is limited to, or "" when the
+// caller is not scoped.
+func TeamScope(ctx context.Context) string {
+	p, ok := auth.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	return p.Team
+}
+
+func TeamInScope(ctx context.Context, teamName string) bool {
+	scope := TeamScope(ctx)
+	return scope == "" || scope == teamName
+}
+
+// IsAdmin reports whether the caller has the admin role.
+func IsAdmin(ctx context.Context) bool {
+	p, ok := auth.FromContext(ctx)
+	return !ok || p.IsAdmin()
+}
+
+func (c *Checker) UserInScope(ctx context.Context, userID string) (bool, error) {
+	scope := TeamScope(ctx)
+	if scope == "" {
+		return true, nil
+	}
+	return c.lookup.UserInTeam(ctx, userID, scope)
+}
+
+func (c *Checker) PullRequestInScope(ctx context.Context, prID string) (bool, error) {
+	scope := TeamScope(ctx)
+	if scope == "" {
+		return true, nil
+	}
+	return c.lookup.PullRequestInTeam(ctx, prID, scope)
+}
+
+// MembersFromTeam reports whether none of members is an existing user from
+// outside teamName. Only unscoped admins may pull users in from other
+// teams.
+func (c *Checker) MembersFromTeam(ctx context.Context, teamName string, members []api.TeamMember) (bool, error) {
+	if IsAdmin(ctx) && TeamScope(ctx) == "" {
+		return true, nil
+	}
+	ids := make([]string, len(members))
+	for i, m := range members {
+		ids[i] = m.UserId
+	}
+	outside, err := c.lookup.UsersOutsideTeam(ctx, teamName, ids)
+	if err != nil {
+		return false, err
+	}
+	return len(outside) == 0, nil
+}
+
+// LeadsTeam, LeadsUser and LeadsPullRequest report whether the caller may
+// manage the target as a team lead. Admins may manage anything.
+func (c *Checker) LeadsTeam(ctx context.Context, teamName string) (bool, error) {
+	p, ok := auth.FromContext(ctx)
+	if !ok || p.IsAdmin() {
+		return true, nil
+	}
+	return c.lookup.IsTeamLead(ctx, p.UserID, teamName)
+}
+
+func (c *Checker) LeadsUser(ctx context.Context, userID string) (bool, error) {
+	p, ok := auth.FromContext(ctx)
+	if !ok || p.IsAdmin() {
+		return true, nil
+	}
+	return c.lookup.LeadsUser(ctx, p.UserID, userID)
+}
+
+func (c *Checker) LeadsPullRequest(ctx context.Context, prID string) (bool, error) {
+	p, ok := auth.FromContext(ctx)
+	if !ok || p.IsAdmin() {
+		return true, nil
+	}
+	return c.lookup.LeadsPullRequest(ctx, p.UserID, prID)
+}
+
+// AddMembers checks that the caller may add members to teamName: the team
+// is in scope and led by the caller, only admins set roles, and only
+// unscoped admins pull in users from other teams. It returns a *Denied, or
+// the error of a failed lookup.
+func (c *Checker) AddMembers(ctx context.Context, teamName string, members []api.TeamMember) error {
+	if !TeamInScope(ctx, teamName) {
+		return ErrOutOfScope
+	}
+	if ok, err := c.LeadsTeam(ctx, teamName); err != nil {
+		return err
+	} else if !ok {
+		return ErrNotLead
+	}
+	if !IsAdmin(ctx) {
+		for _, m := range members {
+			if m.Role != nil {
+				return &Denied{Reason: "only admins can set member roles"}
+			}
+		}
+	}
+	if ok, err := c.MembersFromTeam(ctx, teamName, members); err != nil {
+		return err
+	} else if !ok {
+		return ErrOtherTeamsUsers
+	}
+	return nil
+}
+
+// WriteTeams checks that the caller may sync or import teams: every team is
+// in scope, and only unscoped admins list users from other teams, which the
+// write would pull in and update. It returns a *Denied, or the error of a
+// failed lookup.
+func (c *Checker) WriteTeams(ctx context.Context, teams []api.Team) error {
+	for _, team := range teams {
+		if !TeamInScope(ctx, team.TeamName) {
+			return ErrOutOfScope
+		}
+		if ok, err := c.MembersFromTeam(ctx, team.TeamName, team.Members); err != nil {
+			return err
+		} else if !ok {
+			return ErrOtherTeamsUsers
+		}
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+// Package backup writes and reads the archives of `app backup` and
+// `app restore`: a gzip-compressed NDJSON stream whose first line is a
+// Manifest and every following line an export.Line.
+package backup
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/chimort/avito_test_task/iternal/export"
+	"github.com/chimort/avito_test_task/iternal/repository"
+)
+
+const (
+	// Format identifies the archive in the manifest.
+	Format = "avito_test_task-backup"
+	// Version is the archive layout version. It changes only when the
+	// layout does; the schema is tracked by Manifest.SchemaVersion.
+	Version = 1
+)
+
+type Manifest struct {
+	Format        string    `json:"format"`
+	Version       int       `json:"version"`
+	SchemaVersion uint      `json:"schema_version"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// Check reports whether the archive can be restored into a database
+// migrated to schemaVersion. Archives of older schemas are accepted: the
+// columns added since then get their defaults.
+func (m Manifest) Check(schemaVersion uint) error {
+	if m.Version != Version {
+		return fmt.Errorf("unsupported archive version %d, expected %d", m.Version, Version)
+	}
+	if m.SchemaVersion > schemaVersion {
+		return fmt.Errorf("archive has schema version %d, newer than the database's %d", m.SchemaVersion, schemaVersion)
+	}
+	return nil
+}
+
+// Writer is a repository.BackupSink that writes an archive to w. Close
+// must be called after the last row to flush the compressed stream.
+type Writer struct {
+	gz    *gzip.Writer
+	lines export.Writer
+}
+
+func NewWriter(w io.Writer) *Writer {
+	gz := gzip.NewWriter(w)
+	return &Writer{gz: gz, lines: export.NewWriter(gz, export.NDJSON)}
+}
+
+func (w *Writer) Begin(schemaVersion uint) error {
+	return json.NewEncoder(w.gz).Encode(Manifest{
+		Format:        Format,
+		Version:       Version,
+		SchemaVersion: schemaVersion,
+		CreatedAt:     time.Now().UTC(),
+	})
+}
+
+func (w *Writer) Table(name string, columns []string) error {
+	return w.lines.Table(name, columns)
+}
+
+func (w *Writer) Row(values []any) error {
+	return w.lines.Row(values)
+}
+
+func (w *Writer) Close() error {
+	if err := w.lines.Close(); err != nil {
+		return err
+	}
+	return w.gz.Close()
+}
+
+// Reader reads an archive. Next checks that every row's references point
+// at rows read before it, so a broken archive is reported with its line
+// number before the database rejects it.
+type Reader struct {
+	manifest Manifest
+	dec      *json.Decoder
+	line     int
+	keys     map[string]map[string]bool
+}
+
+func NewReader(r io.Reader) (*Reader, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not a backup archive: %w", err)
+	}
+	dec := json.NewDecoder(gz)
+	dec.UseNumber()
+
+	rd := &Reader{dec: dec, line: 1, keys: map[string]map[string]bool{}}
+	if err := dec.Decode(&rd.manifest); err != nil {
+		return nil, fmt.Errorf("read manifest: %w", err)
+	}
+	if rd.manifest.Format != Format {
+		return nil, fmt.Errorf("not a backup archive (format %q)", rd.manifest.Format)
+	}
+	return rd, nil
+}
+
+func (r *Reader) Manifest() Manifest {
+	return r.manifest
+}
+
+func (r *Reader) Next() (string, map[string]any, error) {
+	var l export.Line
+	if err := r.dec.Decode(&l); err != nil {
+		if errors.Is(err, io.EOF) {
+			return "", nil, io.EOF
+		}
+		return "", nil, fmt.Errorf("line %d: %w", r.line+1, err)
+	}
+	r.line++
+
+	switch l.Table {
+	case export.EndTable:
+		// The trailer ends the archive; older archives without one hit EOF.
+		return "", nil, io.EOF
+	case export.ErrorTable:
+		return "", nil, fmt.Errorf("line %d: archive was written by a failed backup", r.line)
+	}
+
+	for _, ref := range references[l.Table] {
+		v, ok := l.Row[ref.column].(string)
+		if !ok {
+			continue
+		}
+		if !r.keys[ref.table][v] {
+			return "", nil, fmt.Errorf("line %d: %s.%s %q references a missing %s row", r.line, l.Table, ref.column, v, ref.table)
+		}
+	}
+	if key, ok := primaryKeys[l.Table]; ok {
+		v, _ := l.Row[key].(string)
+		if r.keys[l.Table] == nil {
+			r.keys[l.Table] = map[string]bool{}
+		}
+		r.keys[l.Table][v] = true
+	}
+	return l.Table, l.Row, nil
+}
+
+type reference struct {
+	column string
+	table  string
+}
+
+// primaryKeys are the keys other tables reference, and references the
+// foreign keys of the schema.
+var primaryKeys = map[string]string{"team": "name", "users": "id", "pull_requests": "id"}
+
+var references = map[string][]reference{
+	"user_teams":       {{"user_id", "users"}, {"team_name", "team"}},
+	"pull_requests":    {{"author_id", "users"}},
+	"pr_reviewers":     {{"pr_id", "pull_requests"}, {"reviewer_id", "users"}},
+	"pr_reassignments": {{"pr_id", "pull_requests"}},
+	"api_keys":         {{"user_id", "users"}, {"team_name", "team"}},
+}
+
+var _ repository.BackupSink = (*Writer)(nil)
+var _ repository.RestoreSource = (*Reader)(nil)
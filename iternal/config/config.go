@@ -0,0 +1,468 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the complete application configuration. Values are resolved in
+// order: defaults, YAML file, environment, command-line flags. Every leaf
+// field is addressable as a flag named after its YAML path (--db.host) and,
+// where the env tag is set, as an environment variable.
+type Config struct {
+	HTTP        HTTP        `yaml:"http"`
+	GRPC        GRPC        `yaml:"grpc"`
+	DB          DB          `yaml:"db"`
+	Migrations  Migrations  `yaml:"migrations"`
+	Log         Log         `yaml:"log"`
+	Tracing     Tracing     `yaml:"tracing"`
+	Assignment  Assignment  `yaml:"assignment"`
+	Auth        Auth        `yaml:"auth"`
+	Idempotency Idempotency `yaml:"idempotency"`
+	RateLimit   RateLimit   `yaml:"rate_limit"`
+
+	// PrintConfig is set by --print-config; it is not a setting.
+	PrintConfig bool `yaml:"-"`
+	// Args are the positional arguments left after the flags, used by
+	// subcommands such as import.
+	Args []string `yaml:"-"`
+}
+
+type HTTP struct {
+	Addr            string        `yaml:"addr" env:"HTTP_ADDR" usage:"HTTP listen address"`
+	ReadTimeout     time.Duration `yaml:"read_timeout" env:"HTTP_READ_TIMEOUT" usage:"maximum duration for reading a request"`
+	WriteTimeout    time.Duration `yaml:"write_timeout" env:"HTTP_WRITE_TIMEOUT" usage:"maximum duration for writing a response"`
+	IdleTimeout     time.Duration `yaml:"idle_timeout" env:"HTTP_IDLE_TIMEOUT" usage:"keep-alive idle timeout"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT" usage:"grace period for in-flight requests on shutdown"`
+	DrainDelay      time.Duration `yaml:"drain_delay" env:"DRAIN_DELAY" usage:"how long to keep serving with /readyz failing before closing listeners on shutdown"`
+}
+
+type GRPC struct {
+	Addr string `yaml:"addr" env:"GRPC_ADDR" usage:"gRPC listen address, empty disables the gRPC server"`
+}
+
+type DB struct {
+	Host                 string        `yaml:"host" env:"DB_HOST" usage:"database host"`
+	Port                 int           `yaml:"port" env:"DB_PORT" usage:"database port"`
+	User                 string        `yaml:"user" env:"DB_USER" usage:"database user"`
+	Password             string        `yaml:"password" env:"DB_PASSWORD" secret:"true" usage:"database password"`
+	Name                 string        `yaml:"name" env:"DB_NAME" usage:"database name"`
+	SSLMode              string        `yaml:"sslmode" env:"DB_SSLMODE" usage:"postgres sslmode"`
+	MaxOpenConns         int           `yaml:"max_open_conns" env:"DB_MAX_OPEN_CONNS" usage:"maximum open connections, 0 is unlimited"`
+	MaxIdleConns         int           `yaml:"max_idle_conns" env:"DB_MAX_IDLE_CONNS" usage:"maximum idle connections"`
+	ConnMaxLifetime      time.Duration `yaml:"conn_max_lifetime" env:"DB_CONN_MAX_LIFETIME" usage:"maximum connection lifetime, 0 is unlimited"`
+	ConnMaxIdleTime      time.Duration `yaml:"conn_max_idle_time" env:"DB_CONN_MAX_IDLE_TIME" usage:"maximum connection idle time, 0 is unlimited"`
+	ConnectRetries       int           `yaml:"connect_retries" env:"DB_CONNECT_RETRIES" usage:"connection attempts on startup"`
+	ConnectRetryInterval time.Duration `yaml:"connect_retry_interval" env:"DB_CONNECT_RETRY_INTERVAL" usage:"pause between connection attempts"`
+}
+
+type Migrations struct {
+	// Source is a golang-migrate source URL. Empty means the migrations
+	// embedded into the binary.
+	Source string `yaml:"source" env:"MIGRATIONS_SOURCE" usage:"migrations source URL, empty for embedded"`
+}
+
+type Log struct {
+	Level  string `yaml:"level" env:"LOG_LEVEL" usage:"debug, info, warn or error"`
+	Format string `yaml:"format" env:"LOG_FORMAT" usage:"json or text"`
+}
+
+type Tracing struct {
+	Exporter    string `yaml:"exporter" env:"OTEL_TRACES_EXPORTER" usage:"none, otlp, stdout or file"`
+	FilePath    string `yaml:"file_path" env:"OTEL_TRACES_FILE" usage:"output file for the file exporter"`
+	ServiceName string `yaml:"service_name" env:"OTEL_SERVICE_NAME" usage:"service.name resource attribute"`
+}
+
+type Assignment struct {
+	ReviewersPerPR int `yaml:"reviewers_per_pr" env:"ASSIGNMENT_REVIEWERS_PER_PR" usage:"reviewers assigned to a new PR"`
+	// MaxReassignmentsPerHour caps reassignments of one PR within a sliding
+	// hour; 0 disables the cap.
+	MaxReassignmentsPerHour int `yaml:"max_reassignments_per_hour" env:"ASSIGNMENT_MAX_REASSIGNMENTS_PER_HOUR" usage:"reassignments allowed per PR per hour, 0 is unlimited"`
+}
+
+type Auth struct {
+	Enabled bool    `yaml:"enabled" env:"AUTH_ENABLED" usage:"require a bearer token on API routes"`
+	Tokens  []Token `yaml:"tokens"`
+	JWT     JWT     `yaml:"jwt"`
+}
+
+// Token is a static bearer token. Tokens can only be set in the YAML file.
+type Token struct {
+	Name   string `yaml:"name"`
+	Token  string `yaml:"token" secret:"true"`
+	Role   string `yaml:"role"`
+	UserID string `yaml:"user_id,omitempty"`
+}
+
+// JWT configures validation of SSO-issued tokens. Claims may be dotted
+// paths into nested objects, e.g. realm_access.roles.
+type JWT struct {
+	Enabled   bool          `yaml:"enabled" env:"AUTH_JWT_ENABLED" usage:"accept JWTs signed by the JWKS keys"`
+	JWKSFile  string        `yaml:"jwks_file" env:"AUTH_JWT_JWKS_FILE" usage:"path to a JWKS JSON file"`
+	JWKSURL   string        `yaml:"jwks_url" env:"AUTH_JWT_JWKS_URL" usage:"JWKS URL, refreshed in the background"`
+	Issuer    string        `yaml:"issuer" env:"AUTH_JWT_ISSUER" usage:"expected iss claim"`
+	Audience  string        `yaml:"audience" env:"AUTH_JWT_AUDIENCE" usage:"expected aud claim"`
+	UserClaim string        `yaml:"user_claim" env:"AUTH_JWT_USER_CLAIM" usage:"claim holding users.id"`
+	RoleClaim string        `yaml:"role_claim" env:"AUTH_JWT_ROLE_CLAIM" usage:"claim holding the role, a string or a list"`
+	Leeway    time.Duration `yaml:"leeway" env:"AUTH_JWT_LEEWAY" usage:"allowed clock skew for exp and nbf"`
+}
+
+type Idempotency struct {
+	TTL             time.Duration `yaml:"ttl" env:"IDEMPOTENCY_TTL" usage:"how long responses to Idempotency-Key requests are kept"`
+	CleanupInterval time.Duration `yaml:"cleanup_interval" env:"IDEMPOTENCY_CLEANUP_INTERVAL" usage:"how often expired idempotency keys are deleted"`
+	Lease           time.Duration `yaml:"lease" env:"IDEMPOTENCY_LEASE" usage:"how long an unfinished request holds its key, should exceed http.write_timeout"`
+}
+
+// RateLimit configures per-client token buckets. Clients are told apart by
+// the authenticated principal, or by IP when there is none.
+type RateLimit struct {
+	Enabled           bool `yaml:"enabled" env:"RATE_LIMIT_ENABLED" usage:"limit request rate per client"`
+	RequestsPerMinute int  `yaml:"requests_per_minute" env:"RATE_LIMIT_REQUESTS_PER_MINUTE" usage:"default sustained rate per client and route"`
+	Burst             int  `yaml:"burst" env:"RATE_LIMIT_BURST" usage:"default bucket size per client and route"`
+	// AuthFailuresPerMinute and AuthFailureBurst limit failed
+	// authentications per IP, which the per-client limits never see.
+	AuthFailuresPerMinute int `yaml:"auth_failures_per_minute" env:"RATE_LIMIT_AUTH_FAILURES_PER_MINUTE" usage:"sustained rate of failed authentications per IP"`
+	AuthFailureBurst      int `yaml:"auth_failure_burst" env:"RATE_LIMIT_AUTH_FAILURE_BURST" usage:"failed authentications per IP allowed at once"`
+	// Routes override the default for single routes. Routes can only be set
+	// in the YAML file.
+	Routes []RouteLimit `yaml:"routes"`
+}
+
+type RouteLimit struct {
+	// Route is a method and a path template, e.g. "POST /pullRequest/reassign".
+	Route             string `yaml:"route"`
+	RequestsPerMinute int    `yaml:"requests_per_minute"`
+	Burst             int    `yaml:"burst"`
+}
+
+func Default() *Config {
+	return &Config{
+		HTTP: HTTP{
+			Addr:            ":8080",
+			ReadTimeout:     10 * time.Second,
+			WriteTimeout:    30 * time.Second,
+			IdleTimeout:     120 * time.Second,
+			ShutdownTimeout: 15 * time.Second,
+			DrainDelay:      5 * time.Second,
+		},
+		GRPC: GRPC{
+			Addr: ":9090",
+		},
+		DB: DB{
+			Host:                 "localhost",
+			Port:                 5432,
+			SSLMode:              "disable",
+			MaxOpenConns:         25,
+			MaxIdleConns:         10,
+			ConnMaxLifetime:      30 * time.Minute,
+			ConnMaxIdleTime:      5 * time.Minute,
+			ConnectRetries:       5,
+			ConnectRetryInterval: 2 * time.Second,
+		},
+		Log: Log{
+			Level:  "info",
+			Format: "json",
+		},
+		Tracing: Tracing{
+			Exporter:    "none",
+			FilePath:    "traces.json",
+			ServiceName: "pr-service",
+		},
+		Assignment: Assignment{
+			ReviewersPerPR:          2,
+			MaxReassignmentsPerHour: 10,
+		},
+		Auth: Auth{
+			JWT: JWT{
+				UserClaim: "sub",
+				RoleClaim: "role",
+				Leeway:    30 * time.Second,
+			},
+		},
+		Idempotency: Idempotency{
+			TTL:             24 * time.Hour,
+			CleanupInterval: 10 * time.Minute,
+			Lease:           time.Minute,
+		},
+		RateLimit: RateLimit{
+			Enabled:               true,
+			RequestsPerMinute:     600,
+			Burst:                 60,
+			AuthFailuresPerMinute: 10,
+			AuthFailureBurst:      10,
+			Routes: []RouteLimit{
+				{Route: "POST /pullRequest/reassign", RequestsPerMinute: 30, Burst: 5},
+			},
+		},
+	}
+}
+
+// Load builds the configuration from args (without the program name) and
+// the process environment. The YAML file is taken from --config or
+// CONFIG_FILE.
+func Load(args []string) (*Config, error) {
+	cfg := Default()
+
+	fs := flag.NewFlagSet("app", flag.ContinueOnError)
+	configFile := fs.String("config", os.Getenv("CONFIG_FILE"), "path to a YAML config file")
+	fs.BoolVar(&cfg.PrintConfig, "print-config", false, "print the resolved config with secrets redacted and exit")
+
+	overrides := map[string]string{}
+	for _, f := range fields(cfg) {
+		name := f.path
+		fs.Func(name, f.usage, func(v string) error {
+			overrides[name] = v
+			return nil
+		})
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	cfg.Args = fs.Args()
+
+	if *configFile != "" {
+		if err := loadFile(cfg, *configFile); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, f := range fields(cfg) {
+		if f.env == "" {
+			continue
+		}
+		if v, ok := os.LookupEnv(f.env); ok {
+			if err := setValue(f.value, v); err != nil {
+				return nil, fmt.Errorf("env %s: %w", f.env, err)
+			}
+		}
+	}
+
+	for _, f := range fields(cfg) {
+		if v, ok := overrides[f.path]; ok {
+			if err := setValue(f.value, v); err != nil {
+				return nil, fmt.Errorf("flag --%s: %w", f.path, err)
+			}
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func loadFile(cfg *Config, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("open config: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("parse config %s: %w", path, err)
+	}
+	return nil
+}
+
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(c.HTTP.Addr != "", "http.addr is required")
+	check(c.HTTP.ReadTimeout >= 0, "http.read_timeout must not be negative")
+	check(c.HTTP.WriteTimeout >= 0, "http.write_timeout must not be negative")
+	check(c.HTTP.IdleTimeout >= 0, "http.idle_timeout must not be negative")
+	check(c.HTTP.ShutdownTimeout > 0, "http.shutdown_timeout must be positive")
+	check(c.HTTP.DrainDelay >= 0, "http.drain_delay must not be negative")
+
+	check(c.DB.Host != "", "db.host is required")
+	check(c.DB.Port > 0 && c.DB.Port < 65536, "db.port must be between 1 and 65535")
+	check(c.DB.Name != "", "db.name is required")
+	check(c.DB.User != "", "db.user is required")
+	check(c.DB.MaxOpenConns >= 0, "db.max_open_conns must not be negative")
+	check(c.DB.MaxIdleConns >= 0, "db.max_idle_conns must not be negative")
+	check(c.DB.MaxOpenConns == 0 || c.DB.MaxIdleConns <= c.DB.MaxOpenConns,
+		"db.max_idle_conns must not exceed db.max_open_conns")
+	check(c.DB.ConnectRetries > 0, "db.connect_retries must be positive")
+	check(c.DB.ConnectRetryInterval >= 0, "db.connect_retry_interval must not be negative")
+
+	check(oneOf(c.Log.Level, "debug", "info", "warn", "error"), "log.level must be debug, info, warn or error")
+	check(oneOf(c.Log.Format, "json", "text"), "log.format must be json or text")
+
+	check(oneOf(c.Tracing.Exporter, "none", "otlp", "stdout", "file"), "tracing.exporter must be none, otlp, stdout or file")
+	check(c.Tracing.Exporter != "file" || c.Tracing.FilePath != "", "tracing.file_path is required for the file exporter")
+
+	check(c.Assignment.ReviewersPerPR >= 0 && c.Assignment.ReviewersPerPR <= 10,
+		"assignment.reviewers_per_pr must be between 0 and 10")
+
+	check(!c.Auth.Enabled || len(c.Auth.Tokens) > 0 || c.Auth.JWT.Enabled,
+		"auth.tokens must not be empty when auth is enabled without jwt")
+	seen := map[string]bool{}
+	for i, t := range c.Auth.Tokens {
+		check(t.Name != "", "auth.tokens[%d].name is required", i)
+		check(len(t.Token) >= 16, "auth.tokens[%d].token must be at least 16 characters", i)
+		check(!seen[t.Token], "auth.tokens[%d].token is a duplicate", i)
+		check(oneOf(t.Role, "admin", "user"), "auth.tokens[%d].role must be admin or user", i)
+		check(t.Role != "user" || t.UserID != "", "auth.tokens[%d].user_id is required for the user role", i)
+		seen[t.Token] = true
+	}
+
+	check(c.Assignment.MaxReassignmentsPerHour >= 0, "assignment.max_reassignments_per_hour must not be negative")
+
+	check(c.RateLimit.RequestsPerMinute > 0, "rate_limit.requests_per_minute must be positive")
+	check(c.RateLimit.Burst > 0, "rate_limit.burst must be positive")
+	check(c.RateLimit.AuthFailuresPerMinute > 0, "rate_limit.auth_failures_per_minute must be positive")
+	check(c.RateLimit.AuthFailureBurst > 0, "rate_limit.auth_failure_burst must be positive")
+	for i, r := range c.RateLimit.Routes {
+		method, path, ok := strings.Cut(r.Route, " ")
+		check(ok && method != "" && strings.HasPrefix(path, "/"), "rate_limit.routes[%d].route must look like \"POST /path\"", i)
+		check(r.RequestsPerMinute > 0, "rate_limit.routes[%d].requests_per_minute must be positive", i)
+		check(r.Burst > 0, "rate_limit.routes[%d].burst must be positive", i)
+	}
+
+	check(c.Idempotency.TTL > 0, "idempotency.ttl must be positive")
+	check(c.Idempotency.CleanupInterval > 0, "idempotency.cleanup_interval must be positive")
+	check(c.Idempotency.Lease > 0, "idempotency.lease must be positive")
+
+	if jwt := c.Auth.JWT; jwt.Enabled {
+		check((jwt.JWKSFile == "") != (jwt.JWKSURL == ""), "exactly one of auth.jwt.jwks_file and auth.jwt.jwks_url is required")
+		check(jwt.Issuer != "", "auth.jwt.issuer is required")
+		check(jwt.Audience != "", "auth.jwt.audience is required")
+		check(jwt.UserClaim != "", "auth.jwt.user_claim is required")
+		check(jwt.RoleClaim != "", "auth.jwt.role_claim is required")
+		check(jwt.Leeway >= 0, "auth.jwt.leeway must not be negative")
+	}
+
+	return errors.Join(errs...)
+}
+
+// Dump writes the configuration as YAML with secret fields redacted.
+func (c *Config) Dump(w io.Writer) error {
+	redacted := *c
+	redacted.Auth.Tokens = append([]Token(nil), c.Auth.Tokens...)
+	redact(reflect.ValueOf(&redacted).Elem())
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(&redacted); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+func oneOf(v string, allowed ...string) bool {
+	for _, a := range allowed {
+		if v == a {
+			return true
+		}
+	}
+	return false
+}
+
+func redact(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			fv := v.Field(i)
+			if v.Type().Field(i).Tag.Get("secret") == "true" && fv.Kind() == reflect.String && !fv.IsZero() {
+				fv.SetString("<redacted>")
+				continue
+			}
+			redact(fv)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			redact(v.Index(i))
+		}
+	}
+}
+
+type field struct {
+	path  string
+	env   string
+	usage string
+	value reflect.Value
+}
+
+// fields lists the scalar settings of cfg in declaration order. Lists are
+// file-only and are skipped.
+func fields(cfg *Config) []field {
+	var out []field
+	var walk func(v reflect.Value, prefix string)
+	walk = func(v reflect.Value, prefix string) {
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			name, _, _ := strings.Cut(sf.Tag.Get("yaml"), ",")
+			if name == "" || name == "-" {
+				continue
+			}
+			path := name
+			if prefix != "" {
+				path = prefix + "." + name
+			}
+			fv := v.Field(i)
+			switch sf.Type.Kind() {
+			case reflect.Struct:
+				walk(fv, path)
+				continue
+			case reflect.Slice:
+				continue
+			}
+			out = append(out, field{
+				path:  path,
+				env:   sf.Tag.Get("env"),
+				usage: sf.Tag.Get("usage"),
+				value: fv,
+			})
+		}
+	}
+	walk(reflect.ValueOf(cfg).Elem(), "")
+	return out
+}
+
+func setValue(v reflect.Value, s string) error {
+	if v.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Int:
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(n))
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported config type %s", v.Type())
+	}
+	return nil
+}
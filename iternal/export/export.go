@@ -0,0 +1,179 @@
+// Package export writes the tables streamed by repository.Export as NDJSON
+// or as a zip archive with one CSV file per table.
+package export
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+type Format string
+
+const (
+	NDJSON Format = "ndjson"
+	CSV    Format = "csv"
+)
+
+// ParseFormat accepts the value of the format query parameter; empty means
+// NDJSON.
+func ParseFormat(s string) (Format, error) {
+	switch Format(s) {
+	case "", NDJSON:
+		return NDJSON, nil
+	case CSV:
+		return CSV, nil
+	}
+	return "", fmt.Errorf("unsupported format %q, use ndjson or csv", s)
+}
+
+func (f Format) ContentType() string {
+	if f == CSV {
+		return "application/zip"
+	}
+	return "application/x-ndjson"
+}
+
+func (f Format) Filename() string {
+	if f == CSV {
+		return "export.zip"
+	}
+	return "export.ndjson"
+}
+
+// Writer receives tables as repository.ExportSink does. Close must be
+// called after the last row to finish the output, or Abort when the export
+// failed midway, so that a reader can tell a truncated output from a
+// complete one.
+type Writer interface {
+	Table(name string, columns []string) error
+	Row(values []any) error
+	Close() error
+	Abort() error
+}
+
+func NewWriter(w io.Writer, f Format) Writer {
+	if f == CSV {
+		return &csvWriter{zip: zip.NewWriter(w)}
+	}
+	return &ndjsonWriter{enc: json.NewEncoder(w)}
+}
+
+// Line is one NDJSON line: a row of table with values keyed by column.
+type Line struct {
+	Table string         `json:"table"`
+	Row   map[string]any `json:"row"`
+}
+
+// The last NDJSON line is in one of these pseudo-tables: EndTable with the
+// number of rows written when the export is complete, ErrorTable with a
+// message when it failed. Output without either was cut off.
+const (
+	EndTable   = "_end"
+	ErrorTable = "_error"
+)
+
+type ndjsonWriter struct {
+	enc     *json.Encoder
+	table   string
+	columns []string
+	rows    int
+}
+
+func (w *ndjsonWriter) Table(name string, columns []string) error {
+	w.table, w.columns = name, columns
+	return nil
+}
+
+func (w *ndjsonWriter) Row(values []any) error {
+	row := make(map[string]any, len(values))
+	for i, v := range values {
+		if b, ok := v.([]byte); ok {
+			v = string(b)
+		}
+		row[w.columns[i]] = v
+	}
+	w.rows++
+	return w.enc.Encode(Line{Table: w.table, Row: row})
+}
+
+func (w *ndjsonWriter) Close() error {
+	return w.enc.Encode(Line{Table: EndTable, Row: map[string]any{"rows": w.rows}})
+}
+
+func (w *ndjsonWriter) Abort() error {
+	return w.enc.Encode(Line{Table: ErrorTable, Row: map[string]any{"message": "export failed"}})
+}
+
+type csvWriter struct {
+	zip    *zip.Writer
+	csv    *csv.Writer
+	record []string
+}
+
+func (w *csvWriter) Table(name string, columns []string) error {
+	if err := w.flush(); err != nil {
+		return err
+	}
+	f, err := w.zip.Create(name + ".csv")
+	if err != nil {
+		return err
+	}
+	w.csv = csv.NewWriter(f)
+	w.record = make([]string, len(columns))
+	return w.csv.Write(columns)
+}
+
+func (w *csvWriter) Row(values []any) error {
+	for i, v := range values {
+		w.record[i] = formatValue(v)
+	}
+	return w.csv.Write(w.record)
+}
+
+func (w *csvWriter) Close() error {
+	if err := w.flush(); err != nil {
+		return err
+	}
+	return w.zip.Close()
+}
+
+// Abort leaves the archive without its central directory, so zip readers
+// reject it.
+func (w *csvWriter) Abort() error {
+	return w.flush()
+}
+
+func (w *csvWriter) flush() error {
+	if w.csv == nil {
+		return nil
+	}
+	w.csv.Flush()
+	return w.csv.Error()
+}
+
+// formatValue renders a CSV cell. NULL becomes an empty cell and times are
+// written in RFC 3339 with the time zone, as in the JSON output.
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,65 @@
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/chimort/avito_test_task/iternal/authz"
+	"github.com/chimort/avito_test_task/iternal/service"
+	prv1 "github.com/chimort/avito_test_task/proto/pr/v1"
+)
+
+func (s *Server) CreateAPIKey(ctx context.Context, req *prv1.CreateAPIKeyRequest) (*prv1.CreateAPIKeyResponse, error) {
+	body := api.PostApiKeysCreateJSONRequestBody{
+		Name:      req.GetName(),
+		Role:      apiKeyRoleFromProto(req.GetRole()),
+		UserId:    req.UserId,
+		TeamName:  req.TeamName,
+		ExpiresAt: timeOf(req.GetExpiresAt()),
+	}
+	if msg := service.ValidateAPIKey(body); msg != "" {
+		return nil, invalidArgument(msg)
+	}
+
+	// A scoped caller can only issue keys for its own team, otherwise it
+	// could mint itself an unscoped key.
+	if scope := authz.TeamScope(ctx); scope != "" && (body.TeamName == nil || *body.TeamName != scope) {
+		return nil, s.outOfScope(nil)
+	}
+
+	key, token, err := s.apiKeyService.CreateAPIKey(ctx, api.ApiKey{
+		Name:      body.Name,
+		Role:      api.ApiKeyRole(body.Role),
+		UserId:    body.UserId,
+		TeamName:  body.TeamName,
+		ExpiresAt: body.ExpiresAt,
+	})
+	if err != nil {
+		return nil, s.fail(err, "failed to create api key")
+	}
+	return &prv1.CreateAPIKeyResponse{ApiKey: apiKeyToProto(key), Token: token}, nil
+}
+
+func (s *Server) ListAPIKeys(ctx context.Context, req *prv1.ListAPIKeysRequest) (*prv1.ListAPIKeysResponse, error) {
+	keys, err := s.apiKeyService.ListAPIKeys(ctx, optional(authz.TeamScope(ctx)))
+	if err != nil {
+		return nil, s.fail(err, "failed to list api keys")
+	}
+	resp := &prv1.ListAPIKeysResponse{}
+	for i := range keys {
+		resp.ApiKeys = append(resp.ApiKeys, apiKeyToProto(&keys[i]))
+	}
+	return resp, nil
+}
+
+func (s *Server) RevokeAPIKey(ctx context.Context, req *prv1.RevokeAPIKeyRequest) (*prv1.RevokeAPIKeyResponse, error) {
+	if req.GetId() <= 0 {
+		return nil, invalidArgument("id is required")
+	}
+
+	key, err := s.apiKeyService.RevokeAPIKey(ctx, req.GetId(), optional(authz.TeamScope(ctx)))
+	if err != nil {
+		return nil, s.fail(err, "failed to revoke api key")
+	}
+	return &prv1.RevokeAPIKeyResponse{ApiKey: apiKeyToProto(key)}, nil
+}
@@ -0,0 +1,98 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/chimort/avito_test_task/iternal/auth"
+	"github.com/chimort/avito_test_task/iternal/pkg/logger"
+	prv1 "github.com/chimort/avito_test_task/proto/pr/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+// userMethods are the methods open to the user role, the same operations
+// as the HTTP user routes. Handlers further check that the user acts on
+// their own reviews or leads the target team.
+var userMethods = map[string]bool{
+	prv1.PRService_GetUserReviews_FullMethodName:      true,
+	prv1.PRService_ReassignPullRequest_FullMethodName: true,
+	prv1.PRService_AddTeamMembers_FullMethodName:      true,
+	prv1.PRService_RemoveTeamMembers_FullMethodName:   true,
+	prv1.PRService_SetUserIsActive_FullMethodName:     true,
+}
+
+// UnaryAuth requires a valid bearer token in the authorization metadata
+// on every PRService method and stores the caller in the context. Other
+// services, such as health and reflection, are served without a token.
+func UnaryAuth(a auth.Authenticator, log *logger.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authenticate(ctx, a, log, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamAuth is UnaryAuth for streaming methods.
+func StreamAuth(a auth.Authenticator, log *logger.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context(), a, log, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func authenticate(ctx context.Context, a auth.Authenticator, log *logger.Logger, method string) (context.Context, error) {
+	if !strings.HasPrefix(method, "/"+prv1.PRService_ServiceDesc.ServiceName+"/") {
+		return ctx, nil
+	}
+
+	token, ok := bearerToken(ctx)
+	if !ok {
+		return nil, errorStatus(codes.Unauthenticated, api.UNAUTHORIZED, "missing bearer token")
+	}
+
+	principal, err := a.Authenticate(ctx, token)
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidToken) {
+			log.Warn("authentication failed", "error", err, "method", method)
+		} else {
+			log.Error("failed to authenticate", "error", err, "method", method)
+		}
+		return nil, errorStatus(codes.Unauthenticated, api.UNAUTHORIZED, "invalid bearer token")
+	}
+
+	if !principal.IsAdmin() && !userMethods[method] {
+		log.Warn("access denied", "principal", principal.Name, "role", principal.Role, "method", method)
+		return nil, errorStatus(codes.PermissionDenied, api.FORBIDDEN, "not allowed for this role")
+	}
+	return auth.WithPrincipal(ctx, principal), nil
+}
+
+func bearerToken(ctx context.Context) (string, bool) {
+	values := metadata.ValueFromIncomingContext(ctx, "authorization")
+	if len(values) == 0 {
+		return "", false
+	}
+	scheme, token, ok := strings.Cut(values[0], " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", false
+	}
+	return strings.TrimSpace(token), true
+}
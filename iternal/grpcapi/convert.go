@@ -0,0 +1,284 @@
+package grpcapi
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+	prv1 "github.com/chimort/avito_test_task/proto/pr/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Conversions between the API types the services work with and their
+// protobuf counterparts. Unknown enum values coming from a client are
+// passed on under their protobuf name so that validation rejects them.
+
+func timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func timeOf(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+func optional[T comparable](v T) *T {
+	var zero T
+	if v == zero {
+		return nil
+	}
+	return &v
+}
+
+func teamFromProto(t *prv1.Team) (api.Team, error) {
+	if t == nil {
+		return api.Team{}, nil
+	}
+	members, err := membersFromProto(t.GetMembers())
+	if err != nil {
+		return api.Team{}, err
+	}
+	return api.Team{TeamName: t.GetTeamName(), Members: members}, nil
+}
+
+func teamsFromProto(ts []*prv1.Team) ([]api.Team, error) {
+	teams := make([]api.Team, 0, len(ts))
+	for _, t := range ts {
+		team, err := teamFromProto(t)
+		if err != nil {
+			return nil, err
+		}
+		teams = append(teams, team)
+	}
+	return teams, nil
+}
+
+func membersFromProto(ms []*prv1.TeamMember) ([]api.TeamMember, error) {
+	members := make([]api.TeamMember, 0, len(ms))
+	for _, m := range ms {
+		member := api.TeamMember{UserId: m.GetUserId(), Username: m.GetUsername(), IsActive: m.GetIsActive()}
+		switch m.GetRole() {
+		case prv1.TeamMemberRole_TEAM_MEMBER_ROLE_UNSPECIFIED:
+		case prv1.TeamMemberRole_TEAM_MEMBER_ROLE_MEMBER:
+			role := api.Member
+			member.Role = &role
+		case prv1.TeamMemberRole_TEAM_MEMBER_ROLE_LEAD:
+			role := api.Lead
+			member.Role = &role
+		default:
+			return nil, fmt.Errorf("unknown role %d for user %s", m.GetRole(), m.GetUserId())
+		}
+		members = append(members, member)
+	}
+	return members, nil
+}
+
+func teamToProto(t *api.Team) *prv1.Team {
+	if t == nil {
+		return nil
+	}
+	members := make([]*prv1.TeamMember, 0, len(t.Members))
+	for _, m := range t.Members {
+		role := prv1.TeamMemberRole_TEAM_MEMBER_ROLE_MEMBER
+		if m.Role != nil && *m.Role == api.Lead {
+			role = prv1.TeamMemberRole_TEAM_MEMBER_ROLE_LEAD
+		}
+		members = append(members, &prv1.TeamMember{
+			UserId:   m.UserId,
+			Username: m.Username,
+			IsActive: m.IsActive,
+			Role:     role,
+		})
+	}
+	return &prv1.Team{TeamName: t.TeamName, Members: members}
+}
+
+func userToProto(u *api.User) *prv1.User {
+	if u == nil {
+		return nil
+	}
+	return &prv1.User{
+		UserId:    u.UserId,
+		Username:  u.Username,
+		TeamName:  u.TeamName,
+		IsActive:  u.IsActive,
+		DeletedAt: timestamp(u.DeletedAt),
+	}
+}
+
+func statusToProto(s string) prv1.PullRequestStatus {
+	switch s {
+	case string(api.PullRequestStatusOPEN):
+		return prv1.PullRequestStatus_PULL_REQUEST_STATUS_OPEN
+	case string(api.PullRequestStatusMERGED):
+		return prv1.PullRequestStatus_PULL_REQUEST_STATUS_MERGED
+	}
+	return prv1.PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED
+}
+
+// statusFilter returns the status filter of a list request, nil for any.
+func statusFilter(s prv1.PullRequestStatus) *string {
+	var v string
+	switch s {
+	case prv1.PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED:
+		return nil
+	case prv1.PullRequestStatus_PULL_REQUEST_STATUS_OPEN:
+		v = string(api.PullRequestStatusOPEN)
+	case prv1.PullRequestStatus_PULL_REQUEST_STATUS_MERGED:
+		v = string(api.PullRequestStatusMERGED)
+	default:
+		v = s.String()
+	}
+	return &v
+}
+
+func sortOrder(o prv1.SortOrder) *api.SortOrder {
+	var v api.SortOrder
+	switch o {
+	case prv1.SortOrder_SORT_ORDER_UNSPECIFIED:
+		return nil
+	case prv1.SortOrder_SORT_ORDER_ASC:
+		v = api.Asc
+	case prv1.SortOrder_SORT_ORDER_DESC:
+		v = api.Desc
+	default:
+		v = api.SortOrder(o.String())
+	}
+	return &v
+}
+
+func pullRequestToProto(pr *api.PullRequest) *prv1.PullRequest {
+	if pr == nil {
+		return nil
+	}
+	return &prv1.PullRequest{
+		PullRequestId:     pr.PullRequestId,
+		PullRequestName:   pr.PullRequestName,
+		AuthorId:          pr.AuthorId,
+		Status:            statusToProto(string(pr.Status)),
+		AssignedReviewers: pr.AssignedReviewers,
+		CreatedAt:         timestamp(pr.CreatedAt),
+		MergedAt:          timestamp(pr.MergedAt),
+	}
+}
+
+func reviewToProto(r api.ReviewAssignment) *prv1.ReviewAssignment {
+	return &prv1.ReviewAssignment{
+		PullRequestId:   r.PullRequestId,
+		PullRequestName: r.PullRequestName,
+		AuthorId:        r.AuthorId,
+		Status:          statusToProto(string(r.Status)),
+		CreatedAt:       timestamppb.New(r.CreatedAt),
+		AssignedAt:      timestamppb.New(r.AssignedAt),
+	}
+}
+
+func deleteModeFromProto(m prv1.UserDeleteMode) api.UserDeleteMode {
+	switch m {
+	case prv1.UserDeleteMode_USER_DELETE_MODE_UNSPECIFIED, prv1.UserDeleteMode_USER_DELETE_MODE_BLOCK:
+		return api.Block
+	case prv1.UserDeleteMode_USER_DELETE_MODE_REASSIGN:
+		return api.Reassign
+	case prv1.UserDeleteMode_USER_DELETE_MODE_ANONYMIZE:
+		return api.Anonymize
+	}
+	return api.UserDeleteMode(m.String())
+}
+
+func deleteModeToProto(m api.UserDeleteMode) prv1.UserDeleteMode {
+	switch m {
+	case api.Block:
+		return prv1.UserDeleteMode_USER_DELETE_MODE_BLOCK
+	case api.Reassign:
+		return prv1.UserDeleteMode_USER_DELETE_MODE_REASSIGN
+	case api.Anonymize:
+		return prv1.UserDeleteMode_USER_DELETE_MODE_ANONYMIZE
+	}
+	return prv1.UserDeleteMode_USER_DELETE_MODE_UNSPECIFIED
+}
+
+func syncDiffToProto(d api.TeamSyncDiff) *prv1.TeamSyncDiff {
+	return &prv1.TeamSyncDiff{
+		TeamName:          d.TeamName,
+		Created:           d.Created,
+		Added:             d.Added,
+		Removed:           d.Removed,
+		Activated:         d.Activated,
+		Deactivated:       d.Deactivated,
+		ReassignedReviews: int32(d.ReassignedReviews),
+		RemovedReviews:    int32(d.RemovedReviews),
+	}
+}
+
+func cycleTimeStatsToProto(stats []api.CycleTimeStats) []*prv1.CycleTimeStats {
+	out := make([]*prv1.CycleTimeStats, 0, len(stats))
+	for _, s := range stats {
+		out = append(out, &prv1.CycleTimeStats{
+			Key:        s.Key,
+			Count:      int32(s.Count),
+			P50Seconds: s.P50Seconds,
+			P90Seconds: s.P90Seconds,
+			P99Seconds: s.P99Seconds,
+		})
+	}
+	return out
+}
+
+func cycleTimeReportToProto(r *api.CycleTimeReport) *prv1.CycleTimeReport {
+	weekly := make([]*prv1.CycleTimeBucket, 0, len(r.Weekly))
+	for _, b := range r.Weekly {
+		weekly = append(weekly, &prv1.CycleTimeBucket{
+			WeekStart:  timestamppb.New(b.WeekStart),
+			Count:      int32(b.Count),
+			P50Seconds: b.P50Seconds,
+			P90Seconds: b.P90Seconds,
+			P99Seconds: b.P99Seconds,
+		})
+	}
+	return &prv1.CycleTimeReport{
+		ByTeam:     cycleTimeStatsToProto(r.ByTeam),
+		ByAuthor:   cycleTimeStatsToProto(r.ByAuthor),
+		ByReviewer: cycleTimeStatsToProto(r.ByReviewer),
+		Weekly:     weekly,
+	}
+}
+
+func apiKeyRoleFromProto(r prv1.APIKeyRole) api.PostApiKeysCreateJSONBodyRole {
+	switch r {
+	case prv1.APIKeyRole_API_KEY_ROLE_ADMIN:
+		return api.PostApiKeysCreateJSONBodyRoleAdmin
+	case prv1.APIKeyRole_API_KEY_ROLE_USER:
+		return api.PostApiKeysCreateJSONBodyRoleUser
+	}
+	return ""
+}
+
+func apiKeyToProto(k *api.ApiKey) *prv1.APIKey {
+	if k == nil {
+		return nil
+	}
+	role := prv1.APIKeyRole_API_KEY_ROLE_UNSPECIFIED
+	switch k.Role {
+	case api.ApiKeyRoleAdmin:
+		role = prv1.APIKeyRole_API_KEY_ROLE_ADMIN
+	case api.ApiKeyRoleUser:
+		role = prv1.APIKeyRole_API_KEY_ROLE_USER
+	}
+	return &prv1.APIKey{
+		Id:         k.Id,
+		Name:       k.Name,
+		Role:       role,
+		UserId:     k.UserId,
+		TeamName:   k.TeamName,
+		CreatedAt:  timestamppb.New(k.CreatedAt),
+		ExpiresAt:  timestamp(k.ExpiresAt),
+		LastUsedAt: timestamp(k.LastUsedAt),
+		RevokedAt:  timestamp(k.RevokedAt),
+	}
+}
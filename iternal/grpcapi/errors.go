@@ -0,0 +1,117 @@
+package grpcapi
+
+import (
+	"errors"
+	"time"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/chimort/avito_test_task/iternal/authz"
+	"github.com/chimort/avito_test_task/iternal/repository"
+	"github.com/chimort/avito_test_task/iternal/service"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// ErrorDomain is the ErrorInfo domain of every error status. ErrorInfo's
+// reason is the ErrorResponse code the HTTP API would answer with.
+const ErrorDomain = "pr-service"
+
+// errorStatuses maps the errors of the service and repository layers.
+// Anything not listed is an internal error.
+var errorStatuses = []struct {
+	err    error
+	code   codes.Code
+	reason api.ErrorResponseErrorCode
+	msg    string
+}{
+	{repository.ErrTeamNotFound, codes.NotFound, api.NOTFOUND, "team not found"},
+	{repository.ErrUserNotFound, codes.NotFound, api.NOTFOUND, "user not found"},
+	{repository.ErrPRNotFound, codes.NotFound, api.NOTFOUND, "PR not found"},
+	{repository.ErrAPIKeyNotFound, codes.NotFound, api.NOTFOUND, "api key not found"},
+	{repository.ErrTeamExists, codes.AlreadyExists, api.TEAMEXISTS, "team_name already exists"},
+	{repository.ErrPRExists, codes.AlreadyExists, api.PREXISTS, "PR id already exists"},
+	{repository.ErrUserExists, codes.AlreadyExists, api.BADREQUEST, "user already exists"},
+	{repository.ErrPRMerged, codes.FailedPrecondition, api.PRMERGED, "cannot reassign on merged PR"},
+	{repository.ErrReviewerNotAssign, codes.FailedPrecondition, api.NOTASSIGNED, "reviewer is not assigned to this PR"},
+	{repository.ErrNoCandidates, codes.FailedPrecondition, api.NOCANDIDATE, "no active replacement candidate in team"},
+	{repository.ErrUserHasActivity, codes.FailedPrecondition, api.USERHASACTIVITY, "user authored or reviewed pull requests, use mode reassign or anonymize"},
+	{repository.ErrIdempotencyConflict, codes.Aborted, api.IDEMPOTENCYKEYINUSE, "idempotency key is in use"},
+	{service.ErrInvalidCursor, codes.InvalidArgument, api.BADREQUEST, "invalid cursor"},
+}
+
+// fail converts err into a status. Unknown errors are logged and answered
+// with Internal and msg, without ErrorInfo, so that internals don't leak to
+// the caller.
+func (s *Server) fail(err error, msg string) error {
+	for _, e := range errorStatuses {
+		if errors.Is(err, e.err) {
+			return errorStatus(e.code, e.reason, e.msg)
+		}
+	}
+
+	var limitErr *repository.ReassignLimitError
+	if errors.As(err, &limitErr) {
+		return rateLimited("too many reassignments of this PR in the last hour", limitErr.RetryAfter)
+	}
+
+	s.log.Error(msg, "error", err)
+	return status.Error(codes.Internal, msg)
+}
+
+// errorStatus builds a status carrying reason in ErrorInfo.
+func errorStatus(code codes.Code, reason api.ErrorResponseErrorCode, msg string) error {
+	st, err := status.New(code, msg).WithDetails(&errdetails.ErrorInfo{Reason: string(reason), Domain: ErrorDomain})
+	if err != nil {
+		return status.Error(code, msg)
+	}
+	return st.Err()
+}
+
+// rateLimited builds a ResourceExhausted status telling the caller to retry
+// after retryAfter.
+func rateLimited(msg string, retryAfter time.Duration) error {
+	st, err := status.New(codes.ResourceExhausted, msg).WithDetails(
+		&errdetails.ErrorInfo{Reason: string(api.RATELIMITED), Domain: ErrorDomain},
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)},
+	)
+	if err != nil {
+		return status.Error(codes.ResourceExhausted, msg)
+	}
+	return st.Err()
+}
+
+func invalidArgument(msg string) error {
+	return errorStatus(codes.InvalidArgument, api.BADREQUEST, msg)
+}
+
+// outOfScope answers a request that failed a team scope check.
+func (s *Server) outOfScope(err error) error {
+	return s.forbidden(err, authz.ErrOutOfScope.Reason)
+}
+
+// notLead answers a request that failed a team lead check.
+func (s *Server) notLead(err error) error {
+	return s.forbidden(err, authz.ErrNotLead.Reason)
+}
+
+// denied answers a request that failed an authz rule with its reason, or
+// Internal when the rule could not be checked.
+func (s *Server) denied(err error) error {
+	var d *authz.Denied
+	if errors.As(err, &d) {
+		return s.forbidden(nil, d.Reason)
+	}
+	return s.forbidden(err, "")
+}
+
+// forbidden answers PermissionDenied with msg, or Internal when the
+// permission check itself failed with err.
+func (s *Server) forbidden(err error, msg string) error {
+	if err != nil {
+		s.log.Error("failed to check permissions", "error", err)
+		return status.Error(codes.Internal, "failed to check permissions")
+	}
+	return errorStatus(codes.PermissionDenied, api.FORBIDDEN, msg)
+}
@@ -0,0 +1,115 @@
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/chimort/avito_test_task/iternal/authz"
+	"github.com/chimort/avito_test_task/iternal/service"
+	prv1 "github.com/chimort/avito_test_task/proto/pr/v1"
+)
+
+func (s *Server) CreatePullRequest(ctx context.Context, req *prv1.CreatePullRequestRequest) (*prv1.CreatePullRequestResponse, error) {
+	if req.GetPullRequestId() == "" || req.GetPullRequestName() == "" || req.GetAuthorId() == "" {
+		return nil, invalidArgument("pull_request_id, pull_request_name and author_id are required")
+	}
+	if ok, err := s.checker.UserInScope(ctx, req.GetAuthorId()); !ok {
+		return nil, s.outOfScope(err)
+	}
+
+	pr, err := s.userService.PullRequestCreate(ctx, req.GetPullRequestId(), req.GetPullRequestName(), req.GetAuthorId())
+	if err != nil {
+		return nil, s.fail(err, "failed to create PR")
+	}
+	return &prv1.CreatePullRequestResponse{Pr: pullRequestToProto(pr)}, nil
+}
+
+func (s *Server) GetPullRequest(ctx context.Context, req *prv1.GetPullRequestRequest) (*prv1.GetPullRequestResponse, error) {
+	if req.GetPullRequestId() == "" {
+		return nil, invalidArgument("pull_request_id is required")
+	}
+	if ok, err := s.checker.PullRequestInScope(ctx, req.GetPullRequestId()); !ok {
+		return nil, s.outOfScope(err)
+	}
+
+	pr, err := s.userService.GetPullRequest(ctx, req.GetPullRequestId())
+	if err != nil {
+		return nil, s.fail(err, "failed to get PR")
+	}
+	return &prv1.GetPullRequestResponse{Pr: pullRequestToProto(pr)}, nil
+}
+
+func (s *Server) ListPullRequests(ctx context.Context, req *prv1.ListPullRequestsRequest) (*prv1.ListPullRequestsResponse, error) {
+	params := api.GetPullRequestListParams{
+		AuthorId:      req.AuthorId,
+		TeamName:      req.TeamName,
+		Status:        (*api.GetPullRequestListParamsStatus)(statusFilter(req.GetStatus())),
+		ReviewerId:    req.ReviewerId,
+		Title:         req.Title,
+		CreatedAfter:  timeOf(req.GetCreatedAfter()),
+		CreatedBefore: timeOf(req.GetCreatedBefore()),
+		Order:         sortOrder(req.GetOrder()),
+		Limit:         optional(int(req.GetLimit())),
+		Cursor:        optional(req.GetCursor()),
+	}
+	if msg := service.ValidatePage((*string)(params.Status), params.Order, params.Limit, params.CreatedAfter, params.CreatedBefore); msg != "" {
+		return nil, invalidArgument(msg)
+	}
+
+	// A team-scoped caller only sees its own team's pull requests.
+	if scope := authz.TeamScope(ctx); scope != "" {
+		if params.TeamName != nil && *params.TeamName != scope {
+			return nil, s.outOfScope(nil)
+		}
+		params.TeamName = &scope
+	}
+
+	prs, next, err := s.userService.ListPullRequests(ctx, params)
+	if err != nil {
+		return nil, s.fail(err, "failed to list PRs")
+	}
+	resp := &prv1.ListPullRequestsResponse{NextCursor: next}
+	for i := range prs {
+		resp.PullRequests = append(resp.PullRequests, pullRequestToProto(&prs[i]))
+	}
+	return resp, nil
+}
+
+func (s *Server) MergePullRequest(ctx context.Context, req *prv1.MergePullRequestRequest) (*prv1.MergePullRequestResponse, error) {
+	if req.GetPullRequestId() == "" {
+		return nil, invalidArgument("pull_request_id is required")
+	}
+	if ok, err := s.checker.PullRequestInScope(ctx, req.GetPullRequestId()); !ok {
+		return nil, s.outOfScope(err)
+	}
+
+	pr, err := s.userService.PullRequestMerge(ctx, req.GetPullRequestId())
+	if err != nil {
+		return nil, s.fail(err, "failed to merge PR")
+	}
+	return &prv1.MergePullRequestResponse{Pr: pullRequestToProto(pr)}, nil
+}
+
+func (s *Server) ReassignPullRequest(ctx context.Context, req *prv1.ReassignPullRequestRequest) (*prv1.ReassignPullRequestResponse, error) {
+	if req.GetPullRequestId() == "" || req.GetOldUserId() == "" {
+		return nil, invalidArgument("pull_request_id and old_user_id are required")
+	}
+
+	// Reviewers may hand off their own reviews; leads may force a
+	// reassignment on any PR of their team.
+	if !authz.ActsAs(ctx, req.GetOldUserId()) {
+		ok, err := s.checker.LeadsPullRequest(ctx, req.GetPullRequestId())
+		if err != nil || !ok {
+			return nil, s.forbidden(err, "users can only reassign their own reviews")
+		}
+	}
+	if ok, err := s.checker.PullRequestInScope(ctx, req.GetPullRequestId()); !ok {
+		return nil, s.outOfScope(err)
+	}
+
+	pr, replacedBy, err := s.userService.PullRequestReassign(ctx, req.GetPullRequestId(), req.GetOldUserId())
+	if err != nil {
+		return nil, s.fail(err, "failed to reassign reviewer")
+	}
+	return &prv1.ReassignPullRequestResponse{Pr: pullRequestToProto(pr), ReplacedBy: replacedBy}, nil
+}
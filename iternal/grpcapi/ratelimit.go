@@ -0,0 +1,157 @@
+package grpcapi
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/chimort/avito_test_task/iternal/middleware"
+	"github.com/chimort/avito_test_task/iternal/pkg/logger"
+	"github.com/chimort/avito_test_task/iternal/pkg/metrics"
+	prv1 "github.com/chimort/avito_test_task/proto/pr/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// RateLimiter hands out tokens per client and HTTP route; it is the limiter
+// the HTTP API uses.
+type RateLimiter interface {
+	Reserve(client, route string) time.Duration
+	Peek(client, route string) time.Duration
+}
+
+// methodRoutes maps every PRService method to the HTTP route it mirrors,
+// so a caller spends the same bucket whichever API it uses. Route limits
+// from the config apply to gRPC as well.
+var methodRoutes = map[string]string{
+	prv1.PRService_AddTeam_FullMethodName:             "POST /team/add",
+	prv1.PRService_GetTeam_FullMethodName:             "GET /team/get",
+	prv1.PRService_AddTeamMembers_FullMethodName:      "POST /team/addMembers",
+	prv1.PRService_RemoveTeamMembers_FullMethodName:   "POST /team/removeMembers",
+	prv1.PRService_RenameTeam_FullMethodName:          "POST /team/rename",
+	prv1.PRService_DeleteTeam_FullMethodName:          "POST /team/delete",
+	prv1.PRService_SyncTeams_FullMethodName:           "POST /team/sync",
+	prv1.PRService_ImportTeams_FullMethodName:         "POST /team/import",
+	prv1.PRService_SetUserIsActive_FullMethodName:     "POST /users/setIsActive",
+	prv1.PRService_GetUser_FullMethodName:             "GET /users/get",
+	prv1.PRService_UpdateUser_FullMethodName:          "POST /users/update",
+	prv1.PRService_DeleteUser_FullMethodName:          "POST /users/delete",
+	prv1.PRService_ListUsers_FullMethodName:           "GET /users/list",
+	prv1.PRService_GetUserReviews_FullMethodName:      "GET /users/getReview",
+	prv1.PRService_CreatePullRequest_FullMethodName:   "POST /pullRequest/create",
+	prv1.PRService_GetPullRequest_FullMethodName:      "GET /pullRequest/get",
+	prv1.PRService_ListPullRequests_FullMethodName:    "GET /pullRequest/list",
+	prv1.PRService_MergePullRequest_FullMethodName:    "POST /pullRequest/merge",
+	prv1.PRService_ReassignPullRequest_FullMethodName: "POST /pullRequest/reassign",
+	prv1.PRService_GetReviewerStats_FullMethodName:    "GET /stats/reviewers",
+	prv1.PRService_GetTeamStats_FullMethodName:        "GET /stats/teams",
+	prv1.PRService_GetCycleTime_FullMethodName:        "GET /analytics/cycleTime",
+	prv1.PRService_Export_FullMethodName:              "GET /export",
+	prv1.PRService_CreateAPIKey_FullMethodName:        "POST /apiKeys/create",
+	prv1.PRService_ListAPIKeys_FullMethodName:         "GET /apiKeys/list",
+	prv1.PRService_RevokeAPIKey_FullMethodName:        "POST /apiKeys/revoke",
+}
+
+// UnaryRateLimit answers ResourceExhausted with RetryInfo once the caller's
+// bucket for the method is empty. It must run after UnaryAuth so it sees
+// the principal. Health and reflection are not limited.
+func UnaryRateLimit(l RateLimiter, log *logger.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := limit(ctx, l, log, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamRateLimit is UnaryRateLimit for streaming methods.
+func StreamRateLimit(l RateLimiter, log *logger.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := limit(ss.Context(), l, log, info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func limit(ctx context.Context, l RateLimiter, log *logger.Logger, method string) error {
+	route, ok := methodRoutes[method]
+	if !ok {
+		return nil
+	}
+	wait := l.Reserve(middleware.ClientKey(ctx, peerIP(ctx)), route)
+	if wait == 0 {
+		return nil
+	}
+
+	metrics.RateLimited.WithLabelValues(route).Inc()
+	log.Warn("rate limit exceeded", "method", method, "route", route, "retry_after", wait.String())
+	return rateLimited("rate limit exceeded", wait)
+}
+
+// UnaryFailedAuthLimit answers ResourceExhausted to an IP whose bucket of
+// failed authentications is empty, and charges every Unauthenticated
+// answer to it, like middleware.FailedAuthLimit. It must run before
+// UnaryAuth. l should be the limiter the HTTP API uses for failures, so
+// both APIs share the buckets.
+func UnaryFailedAuthLimit(l RateLimiter, log *logger.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if _, ok := methodRoutes[info.FullMethod]; !ok {
+			return handler(ctx, req)
+		}
+		if err := limitFailedAuth(ctx, l, log); err != nil {
+			return nil, err
+		}
+		resp, err := handler(ctx, req)
+		chargeFailedAuth(ctx, l, err)
+		return resp, err
+	}
+}
+
+// StreamFailedAuthLimit is UnaryFailedAuthLimit for streaming methods.
+func StreamFailedAuthLimit(l RateLimiter, log *logger.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if _, ok := methodRoutes[info.FullMethod]; !ok {
+			return handler(srv, ss)
+		}
+		if err := limitFailedAuth(ss.Context(), l, log); err != nil {
+			return err
+		}
+		err := handler(srv, ss)
+		chargeFailedAuth(ss.Context(), l, err)
+		return err
+	}
+}
+
+func limitFailedAuth(ctx context.Context, l RateLimiter, log *logger.Logger) error {
+	ip := peerIP(ctx)
+	wait := l.Peek("ip:"+ip, middleware.AuthFailuresRoute)
+	if wait == 0 {
+		return nil
+	}
+	metrics.RateLimited.WithLabelValues(middleware.AuthFailuresRoute).Inc()
+	log.Warn("too many failed authentications", "ip", ip, "retry_after", wait.String())
+	return rateLimited("too many failed authentications", wait)
+}
+
+func chargeFailedAuth(ctx context.Context, l RateLimiter, err error) {
+	if status.Code(err) == codes.Unauthenticated {
+		l.Reserve("ip:"+peerIP(ctx), middleware.AuthFailuresRoute)
+	}
+}
+
+// peerIP returns the caller's IP without the port, the form the HTTP
+// limiter keys on.
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
@@ -0,0 +1,34 @@
+package grpcapi
+
+import (
+	"github.com/chimort/avito_test_task/iternal/authz"
+	"github.com/chimort/avito_test_task/iternal/pkg/logger"
+	"github.com/chimort/avito_test_task/iternal/service"
+	prv1 "github.com/chimort/avito_test_task/proto/pr/v1"
+	"google.golang.org/grpc"
+)
+
+// Server implements prv1.PRServiceServer on top of the services behind the
+// HTTP handlers, with the same validation of package service and the same
+// permission checks of package authz.
+type Server struct {
+	prv1.UnimplementedPRServiceServer
+
+	userService   service.UserServiceInterface
+	checker       *authz.Checker
+	apiKeyService service.APIKeyServiceInterface
+	log           *logger.Logger
+}
+
+func NewServer(us service.UserServiceInterface, ks service.APIKeyServiceInterface, log *logger.Logger) *Server {
+	return &Server{
+		userService:   us,
+		checker:       authz.NewChecker(us),
+		apiKeyService: ks,
+		log:           log,
+	}
+}
+
+func (s *Server) Register(r grpc.ServiceRegistrar) {
+	prv1.RegisterPRServiceServer(r, s)
+}
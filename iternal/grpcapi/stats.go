@@ -0,0 +1,141 @@
+package grpcapi
+
+import (
+	"context"
+	"time"
+
+	"github.com/chimort/avito_test_task/iternal/authz"
+	"github.com/chimort/avito_test_task/iternal/repository"
+	prv1 "github.com/chimort/avito_test_task/proto/pr/v1"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// window validates a from/to pair. Stats span all teams, so team-scoped
+// keys can't read them.
+func (s *Server) window(ctx context.Context, from, to *timestamppb.Timestamp) (*time.Time, *time.Time, error) {
+	f, t := timeOf(from), timeOf(to)
+	if f != nil && t != nil && !f.Before(*t) {
+		return nil, nil, invalidArgument("from must be before to")
+	}
+	if authz.TeamScope(ctx) != "" {
+		return nil, nil, s.outOfScope(nil)
+	}
+	return f, t, nil
+}
+
+func (s *Server) GetReviewerStats(ctx context.Context, req *prv1.GetReviewerStatsRequest) (*prv1.GetReviewerStatsResponse, error) {
+	from, to, err := s.window(ctx, req.GetFrom(), req.GetTo())
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.userService.GetReviewerStats(ctx, from, to)
+	if err != nil {
+		return nil, s.fail(err, "failed to get reviewer stats")
+	}
+	resp := &prv1.GetReviewerStatsResponse{}
+	for _, st := range stats {
+		resp.Reviewers = append(resp.Reviewers, &prv1.ReviewerStats{
+			UserId:        st.UserId,
+			Username:      st.Username,
+			Assigned:      int32(st.Assigned),
+			Open:          int32(st.Open),
+			Merged:        int32(st.Merged),
+			ReassignedIn:  int32(st.ReassignedIn),
+			ReassignedOut: int32(st.ReassignedOut),
+		})
+	}
+	return resp, nil
+}
+
+func (s *Server) GetTeamStats(ctx context.Context, req *prv1.GetTeamStatsRequest) (*prv1.GetTeamStatsResponse, error) {
+	from, to, err := s.window(ctx, req.GetFrom(), req.GetTo())
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.userService.GetTeamStats(ctx, from, to)
+	if err != nil {
+		return nil, s.fail(err, "failed to get team stats")
+	}
+	resp := &prv1.GetTeamStatsResponse{}
+	for _, st := range stats {
+		resp.Teams = append(resp.Teams, &prv1.TeamStats{
+			TeamName:      st.TeamName,
+			Assigned:      int32(st.Assigned),
+			Open:          int32(st.Open),
+			Merged:        int32(st.Merged),
+			ReassignedIn:  int32(st.ReassignedIn),
+			ReassignedOut: int32(st.ReassignedOut),
+		})
+	}
+	return resp, nil
+}
+
+func (s *Server) GetCycleTime(ctx context.Context, req *prv1.GetCycleTimeRequest) (*prv1.GetCycleTimeResponse, error) {
+	from, to, err := s.window(ctx, req.GetFrom(), req.GetTo())
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := s.userService.GetCycleTimeReport(ctx, from, to)
+	if err != nil {
+		return nil, s.fail(err, "failed to get cycle time report")
+	}
+	return &prv1.GetCycleTimeResponse{Report: cycleTimeReportToProto(report)}, nil
+}
+
+// Export streams one record per row. As with /export, a failure after the
+// first record leaves the client with a truncated stream, but here it also
+// gets the error status at the end.
+func (s *Server) Export(req *prv1.ExportRequest, stream prv1.PRService_ExportServer) error {
+	ctx := stream.Context()
+	from, to, err := s.window(ctx, req.GetFrom(), req.GetTo())
+	if err != nil {
+		return err
+	}
+
+	if err := s.userService.Export(ctx, from, to, &exportStream{stream: stream}); err != nil {
+		return s.fail(err, "failed to export")
+	}
+	return nil
+}
+
+// exportStream is a repository.ExportSink sending rows as ExportRecords.
+type exportStream struct {
+	stream  prv1.PRService_ExportServer
+	table   string
+	columns []string
+}
+
+var _ repository.ExportSink = (*exportStream)(nil)
+
+func (e *exportStream) Table(name string, columns []string) error {
+	e.table, e.columns = name, columns
+	return nil
+}
+
+func (e *exportStream) Row(values []any) error {
+	fields := make(map[string]*structpb.Value, len(values))
+	for i, v := range values {
+		value, err := exportValue(v)
+		if err != nil {
+			return err
+		}
+		fields[e.columns[i]] = value
+	}
+	return e.stream.Send(&prv1.ExportRecord{Table: e.table, Row: &structpb.Struct{Fields: fields}})
+}
+
+// exportValue encodes a column value the way the NDJSON export does: text
+// as strings and timestamps in RFC 3339.
+func exportValue(v any) (*structpb.Value, error) {
+	switch v := v.(type) {
+	case []byte:
+		return structpb.NewStringValue(string(v)), nil
+	case time.Time:
+		return structpb.NewStringValue(v.Format(time.RFC3339Nano)), nil
+	}
+	return structpb.NewValue(v)
+}
@@ -0,0 +1,184 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/chimort/avito_test_task/iternal/authz"
+	"github.com/chimort/avito_test_task/iternal/repository"
+	"github.com/chimort/avito_test_task/iternal/service"
+	prv1 "github.com/chimort/avito_test_task/proto/pr/v1"
+	"google.golang.org/grpc/codes"
+)
+
+func (s *Server) AddTeam(ctx context.Context, req *prv1.AddTeamRequest) (*prv1.AddTeamResponse, error) {
+	team, err := teamFromProto(req.GetTeam())
+	if err != nil {
+		return nil, invalidArgument(err.Error())
+	}
+	if msg := service.ValidateMembers(team.TeamName, team.Members); msg != "" {
+		return nil, invalidArgument(msg)
+	}
+
+	if !authz.TeamInScope(ctx, team.TeamName) {
+		return nil, s.outOfScope(nil)
+	}
+
+	created, err := s.userService.TeamAdd(ctx, team.TeamName, team.Members)
+	if err != nil {
+		if errors.Is(err, repository.ErrTeamExists) {
+			s.log.Warn("team already exists", "team_name", team.TeamName)
+		}
+		return nil, s.fail(err, "failed to create team")
+	}
+	return &prv1.AddTeamResponse{Team: teamToProto(created)}, nil
+}
+
+func (s *Server) GetTeam(ctx context.Context, req *prv1.GetTeamRequest) (*prv1.GetTeamResponse, error) {
+	if req.GetTeamName() == "" {
+		return nil, invalidArgument("team_name is required")
+	}
+	if !authz.TeamInScope(ctx, req.GetTeamName()) {
+		return nil, s.outOfScope(nil)
+	}
+
+	team, err := s.userService.GetTeam(ctx, req.GetTeamName())
+	if err != nil {
+		return nil, s.fail(err, "failed to get team")
+	}
+	return &prv1.GetTeamResponse{Team: teamToProto(team)}, nil
+}
+
+func (s *Server) AddTeamMembers(ctx context.Context, req *prv1.AddTeamMembersRequest) (*prv1.AddTeamMembersResponse, error) {
+	members, err := membersFromProto(req.GetMembers())
+	if err != nil {
+		return nil, invalidArgument(err.Error())
+	}
+	if msg := service.ValidateMembers(req.GetTeamName(), members); msg != "" {
+		return nil, invalidArgument(msg)
+	}
+
+	if err := s.checker.AddMembers(ctx, req.GetTeamName(), members); err != nil {
+		return nil, s.denied(err)
+	}
+
+	team, err := s.userService.AddMembers(ctx, req.GetTeamName(), members)
+	if err != nil {
+		return nil, s.fail(err, "failed to add team members")
+	}
+	return &prv1.AddTeamMembersResponse{Team: teamToProto(team)}, nil
+}
+
+func (s *Server) RemoveTeamMembers(ctx context.Context, req *prv1.RemoveTeamMembersRequest) (*prv1.RemoveTeamMembersResponse, error) {
+	if req.GetTeamName() == "" || len(req.GetUserIds()) == 0 {
+		return nil, invalidArgument("team_name and user_ids are required")
+	}
+
+	if !authz.TeamInScope(ctx, req.GetTeamName()) {
+		return nil, s.outOfScope(nil)
+	}
+	if ok, err := s.checker.LeadsTeam(ctx, req.GetTeamName()); !ok {
+		return nil, s.notLead(err)
+	}
+
+	res, err := s.userService.RemoveMembers(ctx, req.GetTeamName(), req.GetUserIds())
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return nil, errorStatus(codes.NotFound, api.NOTFOUND, "user is not a member of the team")
+	}
+	if err != nil {
+		return nil, s.fail(err, "failed to remove team members")
+	}
+	return &prv1.RemoveTeamMembersResponse{
+		Team:              teamToProto(res.Team),
+		ReassignedReviews: int32(res.ReassignedReviews),
+		RemovedReviews:    int32(res.RemovedReviews),
+	}, nil
+}
+
+func (s *Server) RenameTeam(ctx context.Context, req *prv1.RenameTeamRequest) (*prv1.RenameTeamResponse, error) {
+	if req.GetTeamName() == "" || req.GetNewTeamName() == "" {
+		return nil, invalidArgument("team_name and new_team_name are required")
+	}
+	if !authz.TeamInScope(ctx, req.GetTeamName()) {
+		return nil, s.outOfScope(nil)
+	}
+
+	team, err := s.userService.RenameTeam(ctx, req.GetTeamName(), req.GetNewTeamName())
+	if errors.Is(err, repository.ErrTeamExists) {
+		return nil, errorStatus(codes.AlreadyExists, api.TEAMEXISTS, "new_team_name already exists")
+	}
+	if err != nil {
+		return nil, s.fail(err, "failed to rename team")
+	}
+	return &prv1.RenameTeamResponse{Team: teamToProto(team)}, nil
+}
+
+func (s *Server) DeleteTeam(ctx context.Context, req *prv1.DeleteTeamRequest) (*prv1.DeleteTeamResponse, error) {
+	if req.GetTeamName() == "" {
+		return nil, invalidArgument("team_name is required")
+	}
+	if !authz.TeamInScope(ctx, req.GetTeamName()) {
+		return nil, s.outOfScope(nil)
+	}
+
+	if err := s.userService.DeleteTeam(ctx, req.GetTeamName()); err != nil {
+		return nil, s.fail(err, "failed to delete team")
+	}
+	return &prv1.DeleteTeamResponse{TeamName: req.GetTeamName()}, nil
+}
+
+func (s *Server) SyncTeams(ctx context.Context, req *prv1.SyncTeamsRequest) (*prv1.SyncTeamsResponse, error) {
+	teams, err := teamsFromProto(req.GetTeams())
+	if err != nil {
+		return nil, invalidArgument(err.Error())
+	}
+	if msg := service.ValidateSync(teams); msg != "" {
+		return nil, invalidArgument(msg)
+	}
+	if err := s.checker.WriteTeams(ctx, teams); err != nil {
+		return nil, s.denied(err)
+	}
+
+	diffs, err := s.userService.SyncTeams(ctx, teams, req.GetDryRun())
+	if err != nil {
+		return nil, s.fail(err, "failed to sync teams")
+	}
+	resp := &prv1.SyncTeamsResponse{DryRun: req.GetDryRun()}
+	for _, d := range diffs {
+		resp.Teams = append(resp.Teams, syncDiffToProto(d))
+	}
+	return resp, nil
+}
+
+// ImportTeams takes the teams already parsed, unlike /team/import, which
+// reads a CSV or YAML file. The file rules still apply: every team needs a
+// name and every member an id and a name.
+func (s *Server) ImportTeams(ctx context.Context, req *prv1.ImportTeamsRequest) (*prv1.ImportTeamsResponse, error) {
+	teams, err := teamsFromProto(req.GetTeams())
+	if err != nil {
+		return nil, invalidArgument(err.Error())
+	}
+	if len(teams) == 0 {
+		return nil, invalidArgument("teams is required")
+	}
+	for _, team := range teams {
+		if msg := service.ValidateMembers(team.TeamName, team.Members); msg != "" {
+			return nil, invalidArgument(msg)
+		}
+	}
+	if err := s.checker.WriteTeams(ctx, teams); err != nil {
+		return nil, s.denied(err)
+	}
+
+	res, err := s.userService.ImportTeams(ctx, teams)
+	if err != nil {
+		return nil, s.fail(err, "failed to import teams")
+	}
+	return &prv1.ImportTeamsResponse{Result: &prv1.ImportResult{
+		Teams:        int32(res.Teams),
+		TeamsCreated: int32(res.TeamsCreated),
+		Users:        int32(res.Users),
+		MembersAdded: int32(res.MembersAdded),
+	}}, nil
+}
@@ -0,0 +1,159 @@
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/chimort/avito_test_task/iternal/authz"
+	"github.com/chimort/avito_test_task/iternal/service"
+	prv1 "github.com/chimort/avito_test_task/proto/pr/v1"
+)
+
+func (s *Server) SetUserIsActive(ctx context.Context, req *prv1.SetUserIsActiveRequest) (*prv1.SetUserIsActiveResponse, error) {
+	if req.GetUserId() == "" {
+		return nil, invalidArgument("user_id is required")
+	}
+
+	if ok, err := s.checker.UserInScope(ctx, req.GetUserId()); !ok {
+		return nil, s.outOfScope(err)
+	}
+	if ok, err := s.checker.LeadsUser(ctx, req.GetUserId()); !ok {
+		return nil, s.notLead(err)
+	}
+
+	user, err := s.userService.SetIsActive(ctx, req.GetUserId(), req.GetIsActive())
+	if err != nil {
+		return nil, s.fail(err, "failed to set user active status")
+	}
+	return &prv1.SetUserIsActiveResponse{User: userToProto(user)}, nil
+}
+
+func (s *Server) GetUser(ctx context.Context, req *prv1.GetUserRequest) (*prv1.GetUserResponse, error) {
+	if req.GetUserId() == "" {
+		return nil, invalidArgument("user_id is required")
+	}
+	if ok, err := s.checker.UserInScope(ctx, req.GetUserId()); !ok {
+		return nil, s.outOfScope(err)
+	}
+
+	user, err := s.userService.GetUser(ctx, req.GetUserId())
+	if err != nil {
+		return nil, s.fail(err, "failed to get user")
+	}
+	return &prv1.GetUserResponse{User: userToProto(user)}, nil
+}
+
+func (s *Server) UpdateUser(ctx context.Context, req *prv1.UpdateUserRequest) (*prv1.UpdateUserResponse, error) {
+	switch {
+	case req.GetUserId() == "":
+		return nil, invalidArgument("user_id is required")
+	case req.Username == nil && req.IsActive == nil:
+		return nil, invalidArgument("username or is_active is required")
+	case req.Username != nil && *req.Username == "":
+		return nil, invalidArgument("username must not be empty")
+	}
+
+	if ok, err := s.checker.UserInScope(ctx, req.GetUserId()); !ok {
+		return nil, s.outOfScope(err)
+	}
+
+	user, err := s.userService.UpdateUser(ctx, req.GetUserId(), req.Username, req.IsActive)
+	if err != nil {
+		return nil, s.fail(err, "failed to update user")
+	}
+	return &prv1.UpdateUserResponse{User: userToProto(user)}, nil
+}
+
+func (s *Server) DeleteUser(ctx context.Context, req *prv1.DeleteUserRequest) (*prv1.DeleteUserResponse, error) {
+	mode := deleteModeFromProto(req.GetMode())
+	switch {
+	case req.GetUserId() == "":
+		return nil, invalidArgument("user_id is required")
+	case mode != api.Block && mode != api.Reassign && mode != api.Anonymize:
+		return nil, invalidArgument("mode must be block, reassign or anonymize")
+	}
+
+	if ok, err := s.checker.UserInScope(ctx, req.GetUserId()); !ok {
+		return nil, s.outOfScope(err)
+	}
+
+	res, err := s.userService.DeleteUser(ctx, req.GetUserId(), mode)
+	if err != nil {
+		return nil, s.fail(err, "failed to delete user")
+	}
+	return &prv1.DeleteUserResponse{Result: &prv1.UserDeletion{
+		UserId:            res.UserId,
+		Mode:              deleteModeToProto(res.Mode),
+		Anonymized:        res.Anonymized,
+		ReassignedReviews: int32(res.ReassignedReviews),
+		RemovedReviews:    int32(res.RemovedReviews),
+	}}, nil
+}
+
+func (s *Server) ListUsers(ctx context.Context, req *prv1.ListUsersRequest) (*prv1.ListUsersResponse, error) {
+	params := api.GetUsersListParams{
+		TeamName:       req.TeamName,
+		IsActive:       req.IsActive,
+		Username:       req.Username,
+		IncludeDeleted: optional(req.GetIncludeDeleted()),
+		Limit:          optional(int(req.GetLimit())),
+		Cursor:         optional(req.GetCursor()),
+	}
+	if msg := service.ValidatePage(nil, nil, params.Limit, nil, nil); msg != "" {
+		return nil, invalidArgument(msg)
+	}
+
+	if scope := authz.TeamScope(ctx); scope != "" {
+		if params.TeamName != nil && *params.TeamName != scope {
+			return nil, s.outOfScope(nil)
+		}
+		params.TeamName = &scope
+	}
+
+	users, next, err := s.userService.ListUsers(ctx, params)
+	if err != nil {
+		return nil, s.fail(err, "failed to list users")
+	}
+	resp := &prv1.ListUsersResponse{NextCursor: next}
+	for i := range users {
+		resp.Users = append(resp.Users, userToProto(&users[i]))
+	}
+	return resp, nil
+}
+
+func (s *Server) GetUserReviews(ctx context.Context, req *prv1.GetUserReviewsRequest) (*prv1.GetUserReviewsResponse, error) {
+	userID := req.GetUserId()
+	if userID == "" {
+		return nil, invalidArgument("user_id is required")
+	}
+
+	if !authz.ActsAs(ctx, userID) {
+		return nil, s.forbidden(nil, "users can only list their own reviews")
+	}
+	if ok, err := s.checker.UserInScope(ctx, userID); !ok {
+		return nil, s.outOfScope(err)
+	}
+
+	params := api.GetUsersGetReviewParams{
+		UserId:        userID,
+		Status:        (*api.GetUsersGetReviewParamsStatus)(statusFilter(req.GetStatus())),
+		CreatedAfter:  timeOf(req.GetCreatedAfter()),
+		CreatedBefore: timeOf(req.GetCreatedBefore()),
+		Order:         sortOrder(req.GetOrder()),
+		Limit:         optional(int(req.GetLimit())),
+		Cursor:        optional(req.GetCursor()),
+	}
+	if msg := service.ValidatePage((*string)(params.Status), params.Order, params.Limit, params.CreatedAfter, params.CreatedBefore); msg != "" {
+		return nil, invalidArgument(msg)
+	}
+
+	prs, next, err := s.userService.GetPRsByReviewer(ctx, userID, params)
+	if err != nil {
+		return nil, s.fail(err, "failed to fetch PRs")
+	}
+	resp := &prv1.GetUserReviewsResponse{UserId: userID, NextCursor: next}
+	for _, pr := range prs {
+		resp.PullRequests = append(resp.PullRequests, reviewToProto(pr))
+	}
+	return resp, nil
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/chimort/avito_test_task/iternal/authz"
+	"github.com/labstack/echo/v4"
+)
+
+func (h *Handlers) GetAnalyticsCycleTime(ctx echo.Context, params api.GetAnalyticsCycleTimeParams) error {
+	if params.From != nil && params.To != nil && !params.From.Before(*params.To) {
+		return ctx.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: struct {
+				Code    api.ErrorResponseErrorCode `json:"code"`
+				Message string                     `json:"message"`
+			}{
+				Code:    api.BADREQUEST,
+				Message: "from must be before to",
+			},
+		})
+	}
+
+	if authz.TeamScope(ctx.Request().Context()) != "" {
+		return h.outOfScope(ctx, nil)
+	}
+
+	report, err := h.userService.GetCycleTimeReport(ctx.Request().Context(), params.From, params.To)
+	if err != nil {
+		h.log.Error("failed to get cycle time report", "error", err)
+		return ctx.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Error: struct {
+				Code    api.ErrorResponseErrorCode `json:"code"`
+				Message string                     `json:"message"`
+			}{
+				Code:    api.NOTFOUND,
+				Message: "failed to get cycle time report",
+			},
+		})
+	}
+
+	if params.Format != nil && *params.Format == api.Csv {
+		return writeCycleTimeCSV(ctx, report)
+	}
+	return ctx.JSON(http.StatusOK, report)
+}
+
+func writeCycleTimeCSV(ctx echo.Context, report *api.CycleTimeReport) error {
+	resp := ctx.Response()
+	resp.Header().Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+	resp.Header().Set(echo.HeaderContentDisposition, `attachment; filename="cycle_time.csv"`)
+	resp.WriteHeader(http.StatusOK)
+
+	w := csv.NewWriter(resp)
+	if err := w.Write([]string{"group", "key", "count", "p50_seconds", "p90_seconds", "p99_seconds"}); err != nil {
+		return err
+	}
+
+	groups := []struct {
+		name  string
+		stats []api.CycleTimeStats
+	}{
+		{"team", report.ByTeam},
+		{"author", report.ByAuthor},
+		{"reviewer", report.ByReviewer},
+	}
+	for _, g := range groups {
+		for _, s := range g.stats {
+			if err := w.Write(cycleTimeRecord(g.name, s.Key, s.Count, s.P50Seconds, s.P90Seconds, s.P99Seconds)); err != nil {
+				return err
+			}
+		}
+	}
+	for _, b := range report.Weekly {
+		key := b.WeekStart.UTC().Format(time.RFC3339)
+		if err := w.Write(cycleTimeRecord("week", key, b.Count, b.P50Seconds, b.P90Seconds, b.P99Seconds)); err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+func cycleTimeRecord(group, key string, count int, p50, p90, p99 float64) []string {
+	return []string{
+		group,
+		key,
+		strconv.Itoa(count),
+		strconv.FormatFloat(p50, 'f', 0, 64),
+		strconv.FormatFloat(p90, 'f', 0, 64),
+		strconv.FormatFloat(p99, 'f', 0, 64),
+	}
+}
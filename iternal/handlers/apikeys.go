@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/chimort/avito_test_task/iternal/authz"
+	"github.com/chimort/avito_test_task/iternal/repository"
+	"github.com/chimort/avito_test_task/iternal/service"
+	"github.com/labstack/echo/v4"
+)
+
+func (h *Handlers) PostApiKeysCreate(ctx echo.Context) error {
+	var body api.PostApiKeysCreateJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		h.log.Error("failed to bind request body", "error", err)
+		return ctx.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: struct {
+				Code    api.ErrorResponseErrorCode `json:"code"`
+				Message string                     `json:"message"`
+			}{
+				Code:    api.BADREQUEST,
+				Message: "invalid body",
+			},
+		})
+	}
+
+	if msg := service.ValidateAPIKey(body); msg != "" {
+		return ctx.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: struct {
+				Code    api.ErrorResponseErrorCode `json:"code"`
+				Message string                     `json:"message"`
+			}{
+				Code:    api.BADREQUEST,
+				Message: msg,
+			},
+		})
+	}
+
+	// A scoped caller can only issue keys for its own team, otherwise it
+	// could mint itself an unscoped key.
+	if scope := authz.TeamScope(ctx.Request().Context()); scope != "" && (body.TeamName == nil || *body.TeamName != scope) {
+		return h.outOfScope(ctx, nil)
+	}
+
+	key, token, err := h.apiKeyService.CreateAPIKey(ctx.Request().Context(), api.ApiKey{
+		Name:      body.Name,
+		Role:      api.ApiKeyRole(body.Role),
+		UserId:    body.UserId,
+		TeamName:  body.TeamName,
+		ExpiresAt: body.ExpiresAt,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrTeamNotFound) || errors.Is(err, repository.ErrUserNotFound) {
+			return ctx.JSON(http.StatusNotFound, api.ErrorResponse{
+				Error: struct {
+					Code    api.ErrorResponseErrorCode `json:"code"`
+					Message string                     `json:"message"`
+				}{
+					Code:    api.NOTFOUND,
+					Message: "team or user not found",
+				},
+			})
+		}
+		h.log.Error("failed to create api key", "error", err)
+		return ctx.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Error: struct {
+				Code    api.ErrorResponseErrorCode `json:"code"`
+				Message string                     `json:"message"`
+			}{
+				Code:    api.NOTFOUND,
+				Message: "failed to create api key",
+			},
+		})
+	}
+
+	return ctx.JSON(http.StatusCreated, map[string]interface{}{"api_key": key, "token": token})
+}
+
+func (h *Handlers) GetApiKeysList(ctx echo.Context) error {
+	keys, err := h.apiKeyService.ListAPIKeys(ctx.Request().Context(), scopeFilter(ctx))
+	if err != nil {
+		h.log.Error("failed to list api keys", "error", err)
+		return ctx.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Error: struct {
+				Code    api.ErrorResponseErrorCode `json:"code"`
+				Message string                     `json:"message"`
+			}{
+				Code:    api.NOTFOUND,
+				Message: "failed to list api keys",
+			},
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]interface{}{"api_keys": keys})
+}
+
+func (h *Handlers) PostApiKeysRevoke(ctx echo.Context) error {
+	var body api.PostApiKeysRevokeJSONRequestBody
+	if err := ctx.Bind(&body); err != nil || body.Id <= 0 {
+		return ctx.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: struct {
+				Code    api.ErrorResponseErrorCode `json:"code"`
+				Message string                     `json:"message"`
+			}{
+				Code:    api.BADREQUEST,
+				Message: "id is required",
+			},
+		})
+	}
+
+	key, err := h.apiKeyService.RevokeAPIKey(ctx.Request().Context(), body.Id, scopeFilter(ctx))
+	if err != nil {
+		if errors.Is(err, repository.ErrAPIKeyNotFound) {
+			return ctx.JSON(http.StatusNotFound, api.ErrorResponse{
+				Error: struct {
+					Code    api.ErrorResponseErrorCode `json:"code"`
+					Message string                     `json:"message"`
+				}{
+					Code:    api.NOTFOUND,
+					Message: "api key not found",
+				},
+			})
+		}
+		h.log.Error("failed to revoke api key", "error", err)
+		return ctx.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Error: struct {
+				Code    api.ErrorResponseErrorCode `json:"code"`
+				Message string                     `json:"message"`
+			}{
+				Code:    api.NOTFOUND,
+				Message: "failed to revoke api key",
+			},
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]interface{}{"api_key": key})
+}
+
+// scopeFilter limits key management to the caller's team, if any.
+func scopeFilter(ctx echo.Context) *string {
+	if scope := authz.TeamScope(ctx.Request().Context()); scope != "" {
+		return &scope
+	}
+	return nil
+}
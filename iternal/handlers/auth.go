@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/chimort/avito_test_task/iternal/authz"
+	"github.com/labstack/echo/v4"
+)
+
+// outOfScope answers a request that failed a team scope check.
+func (h *Handlers) outOfScope(ctx echo.Context, err error) error {
+	return h.forbidden(ctx, err, authz.ErrOutOfScope.Reason)
+}
+
+// notLead answers a request that failed a team lead check.
+func (h *Handlers) notLead(ctx echo.Context, err error) error {
+	return h.forbidden(ctx, err, authz.ErrNotLead.Reason)
+}
+
+// denied answers a request that failed an authz rule with its reason, or
+// 500 when the rule could not be checked.
+func (h *Handlers) denied(ctx echo.Context, err error) error {
+	var d *authz.Denied
+	if errors.As(err, &d) {
+		return h.forbidden(ctx, nil, d.Reason)
+	}
+	return h.forbidden(ctx, err, "")
+}
+
+// forbidden answers 403 with msg, or 500 when the permission check itself
+// failed with err.
+func (h *Handlers) forbidden(ctx echo.Context, err error, msg string) error {
+	if err != nil {
+		h.log.Error("failed to check permissions", "error", err)
+		return ctx.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Error: struct {
+				Code    api.ErrorResponseErrorCode `json:"code"`
+				Message string                     `json:"message"`
+			}{
+				Code:    api.NOTFOUND,
+				Message: "failed to check permissions",
+			},
+		})
+	}
+	return ctx.JSON(http.StatusForbidden, api.ErrorResponse{
+		Error: struct {
+			Code    api.ErrorResponseErrorCode `json:"code"`
+			Message string                     `json:"message"`
+		}{
+			Code:    api.FORBIDDEN,
+			Message: msg,
+		},
+	})
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/chimort/avito_test_task/iternal/authz"
+	"github.com/chimort/avito_test_task/iternal/export"
+	"github.com/labstack/echo/v4"
+)
+
+// exportWriteTimeout bounds a single write of an export. The deadline moves
+// forward with every write, so http.write_timeout does not cut off a large
+// export that keeps making progress.
+const exportWriteTimeout = 30 * time.Second
+
+// GetExport streams the export in the requested format. Errors before the
+// first byte is written get a 500; once streaming has started the status
+// can no longer change, so the output is ended with an error marker
+// instead of the trailer of a complete export.
+func (h *Handlers) GetExport(ctx echo.Context, params api.GetExportParams) error {
+	var raw string
+	if params.Format != nil {
+		raw = *params.Format
+	}
+	format, err := export.ParseFormat(raw)
+	if err != nil {
+		return h.badRequest(ctx, err.Error())
+	}
+	if params.From != nil && params.To != nil && !params.From.Before(*params.To) {
+		return h.badRequest(ctx, "from must be before to")
+	}
+
+	if authz.TeamScope(ctx.Request().Context()) != "" {
+		return h.outOfScope(ctx, nil)
+	}
+
+	resp := ctx.Response()
+	resp.Header().Set(echo.HeaderContentType, format.ContentType())
+	resp.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, format.Filename()))
+
+	w := export.NewWriter(&deadlineWriter{w: resp, rc: http.NewResponseController(resp)}, format)
+	if err := h.userService.Export(ctx.Request().Context(), params.From, params.To, w); err != nil {
+		if !resp.Committed {
+			resp.Header().Del(echo.HeaderContentType)
+			resp.Header().Del(echo.HeaderContentDisposition)
+			return ctx.JSON(http.StatusInternalServerError, api.ErrorResponse{
+				Error: struct {
+					Code    api.ErrorResponseErrorCode `json:"code"`
+					Message string                     `json:"message"`
+				}{
+					Code:    api.NOTFOUND,
+					Message: "failed to export data",
+				},
+			})
+		}
+		h.log.Error("export aborted", "error", err)
+		if err := w.Abort(); err != nil {
+			h.log.Error("failed to mark export as aborted", "error", err)
+		}
+		return nil
+	}
+	if err := w.Close(); err != nil {
+		h.log.Error("failed to finish export", "error", err)
+	}
+	return nil
+}
+
+// deadlineWriter extends the connection's write deadline before each write.
+type deadlineWriter struct {
+	w  io.Writer
+	rc *http.ResponseController
+}
+
+func (d *deadlineWriter) Write(p []byte) (int, error) {
+	err := d.rc.SetWriteDeadline(time.Now().Add(exportWriteTimeout))
+	if err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return 0, err
+	}
+	return d.w.Write(p)
+}
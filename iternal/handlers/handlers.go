@@ -2,9 +2,12 @@ package handlers
 
 import (
 	"errors"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/chimort/avito_test_task/iternal/authz"
 	"github.com/chimort/avito_test_task/iternal/pkg/logger"
 	"github.com/chimort/avito_test_task/iternal/repository"
 	"github.com/chimort/avito_test_task/iternal/service"
@@ -12,14 +15,20 @@ import (
 )
 
 type Handlers struct {
-	userService service.UserServiceInterface
-	log         *logger.Logger
+	userService   service.UserServiceInterface
+	checker       *authz.Checker
+	apiKeyService service.APIKeyServiceInterface
+	readiness     ReadinessChecker
+	log           *logger.Logger
 }
 
-func NewHandlers(us service.UserServiceInterface, log *logger.Logger) *Handlers {
+func NewHandlers(us service.UserServiceInterface, ks service.APIKeyServiceInterface, rc ReadinessChecker, log *logger.Logger) *Handlers {
 	return &Handlers{
-		userService: us,
-		log:         log,
+		userService:   us,
+		checker:       authz.NewChecker(us),
+		apiKeyService: ks,
+		readiness:     rc,
+		log:           log,
 	}
 }
 
@@ -62,6 +71,13 @@ func (h *Handlers) PostTeamAdd(ctx echo.Context) error {
 				},
 			})
 		}
+		if msg := service.ValidateMember(m); msg != "" {
+			return h.badRequest(ctx, msg)
+		}
+	}
+
+	if !authz.TeamInScope(ctx.Request().Context(), body.TeamName) {
+		return h.outOfScope(ctx, nil)
 	}
 
 	team, err := h.userService.TeamAdd(ctx.Request().Context(), body.TeamName, body.Members)
@@ -78,16 +94,7 @@ func (h *Handlers) PostTeamAdd(ctx echo.Context) error {
 				},
 			})
 		}
-		h.log.Error("failed to create team", "error", err, "team_name", body.TeamName)
-		return ctx.JSON(http.StatusInternalServerError, api.ErrorResponse{
-			Error: struct {
-				Code    api.ErrorResponseErrorCode `json:"code"`
-				Message string                     `json:"message"`
-			}{
-				Code:    api.NOTFOUND,
-				Message: "failed to create team",
-			},
-		})
+		return h.teamError(ctx, err, "failed to create team")
 	}
 
 	h.log.Info("team created", "team", team)
@@ -96,6 +103,10 @@ func (h *Handlers) PostTeamAdd(ctx echo.Context) error {
 
 func (h *Handlers) GetTeamGet(ctx echo.Context, params api.GetTeamGetParams) error {
 	teamName := params.TeamName
+	if !authz.TeamInScope(ctx.Request().Context(), teamName) {
+		return h.outOfScope(ctx, nil)
+	}
+
 	h.log.Info("getting team", "team_name", teamName)
 	team, err := h.userService.GetTeam(ctx.Request().Context(), teamName)
 
@@ -155,6 +166,14 @@ func (h *Handlers) PostUsersSetIsActive(ctx echo.Context) error {
 		})
 	}
 
+	if ok, err := h.checker.UserInScope(ctx.Request().Context(), body.UserId); !ok {
+		return h.outOfScope(ctx, err)
+	}
+
+	if ok, err := h.checker.LeadsUser(ctx.Request().Context(), body.UserId); !ok {
+		return h.notLead(ctx, err)
+	}
+
 	user, err := h.userService.SetIsActive(ctx.Request().Context(), body.UserId, body.IsActive)
 	if err != nil {
 		h.log.Error("failed to set user active status", "error", err)
@@ -200,6 +219,10 @@ func (h *Handlers) PostPullRequestCreate(ctx echo.Context) error {
 		})
 	}
 
+	if ok, err := h.checker.UserInScope(ctx.Request().Context(), body.AuthorId); !ok {
+		return h.outOfScope(ctx, err)
+	}
+
 	pr, err := h.userService.PullRequestCreate(ctx.Request().Context(), body.PullRequestId, body.PullRequestName, body.AuthorId)
 	if err != nil {
 		if errors.Is(err, repository.ErrPRExists) {
@@ -253,6 +276,10 @@ func (h *Handlers) PostPullRequestMerge(ctx echo.Context) error {
 		})
 	}
 
+	if ok, err := h.checker.PullRequestInScope(ctx.Request().Context(), body.PullRequestId); !ok {
+		return h.outOfScope(ctx, err)
+	}
+
 	pr, err := h.userService.PullRequestMerge(ctx.Request().Context(), body.PullRequestId)
 	if err != nil {
 		if errors.Is(err, repository.ErrPRNotFound) {
@@ -307,12 +334,26 @@ func (h *Handlers) PostPullRequestReassign(ctx echo.Context) error {
 		})
 	}
 
+	// Reviewers may hand off their own reviews; leads may force a
+	// reassignment on any PR of their team.
+	if !authz.ActsAs(ctx.Request().Context(), body.OldUserId) {
+		ok, err := h.checker.LeadsPullRequest(ctx.Request().Context(), body.PullRequestId)
+		if err != nil || !ok {
+			return h.forbidden(ctx, err, "users can only reassign their own reviews")
+		}
+	}
+
+	if ok, err := h.checker.PullRequestInScope(ctx.Request().Context(), body.PullRequestId); !ok {
+		return h.outOfScope(ctx, err)
+	}
+
 	pr, replacedBy, err := h.userService.PullRequestReassign(
 		ctx.Request().Context(),
 		body.PullRequestId,
 		body.OldUserId,
 	)
 
+	var limitErr *repository.ReassignLimitError
 	if err != nil {
 		switch {
 		case errors.Is(err, repository.ErrPRNotFound):
@@ -326,6 +367,18 @@ func (h *Handlers) PostPullRequestReassign(ctx echo.Context) error {
 				},
 			})
 
+		case errors.As(err, &limitErr):
+			ctx.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(limitErr.RetryAfter.Seconds()))))
+			return ctx.JSON(http.StatusTooManyRequests, api.ErrorResponse{
+				Error: struct {
+					Code    api.ErrorResponseErrorCode `json:"code"`
+					Message string                     `json:"message"`
+				}{
+					Code:    api.RATELIMITED,
+					Message: "too many reassignments of this PR in the last hour",
+				},
+			})
+
 		case errors.Is(err, repository.ErrReviewerNotAssign):
 			return ctx.JSON(http.StatusConflict, api.ErrorResponse{
 				Error: struct {
@@ -393,7 +446,46 @@ func (h *Handlers) GetUsersGetReview(ctx echo.Context, params api.GetUsersGetRev
 		})
 	}
 
-	prs, err := h.userService.GetPRsByReviewer(ctx.Request().Context(), userId)
+	if !authz.ActsAs(ctx.Request().Context(), userId) {
+		return ctx.JSON(http.StatusForbidden, api.ErrorResponse{
+			Error: struct {
+				Code    api.ErrorResponseErrorCode `json:"code"`
+				Message string                     `json:"message"`
+			}{
+				Code:    api.FORBIDDEN,
+				Message: "users can only list their own reviews",
+			},
+		})
+	}
+
+	if ok, err := h.checker.UserInScope(ctx.Request().Context(), userId); !ok {
+		return h.outOfScope(ctx, err)
+	}
+
+	if msg := service.ValidatePage((*string)(params.Status), params.Order, params.Limit, params.CreatedAfter, params.CreatedBefore); msg != "" {
+		return ctx.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: struct {
+				Code    api.ErrorResponseErrorCode `json:"code"`
+				Message string                     `json:"message"`
+			}{
+				Code:    api.BADREQUEST,
+				Message: msg,
+			},
+		})
+	}
+
+	prs, next, err := h.userService.GetPRsByReviewer(ctx.Request().Context(), userId, params)
+	if errors.Is(err, service.ErrInvalidCursor) {
+		return ctx.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: struct {
+				Code    api.ErrorResponseErrorCode `json:"code"`
+				Message string                     `json:"message"`
+			}{
+				Code:    api.BADREQUEST,
+				Message: "invalid cursor",
+			},
+		})
+	}
 	if err != nil {
 		h.log.Error("failed to get PRs for reviewer", "error", err)
 		return ctx.JSON(http.StatusInternalServerError, api.ErrorResponse{
@@ -407,18 +499,12 @@ func (h *Handlers) GetUsersGetReview(ctx echo.Context, params api.GetUsersGetRev
 		})
 	}
 
-	var prsShort []api.PullRequestShort
-	for _, pr := range prs {
-		prsShort = append(prsShort, api.PullRequestShort{
-			PullRequestId:   pr.PullRequestId,
-			PullRequestName: pr.PullRequestName,
-			AuthorId:        pr.AuthorId,
-			Status:          pr.Status,
-		})
-	}
-
-	return ctx.JSON(http.StatusOK, map[string]interface{}{
+	resp := map[string]interface{}{
 		"user_id":       userId,
-		"pull_requests": prsShort,
-	})
+		"pull_requests": prs,
+	}
+	if next != "" {
+		resp["next_cursor"] = next
+	}
+	return ctx.JSON(http.StatusOK, resp)
 }
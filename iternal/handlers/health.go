@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/labstack/echo/v4"
+)
+
+type ReadinessChecker interface {
+	CheckReadiness(ctx context.Context) api.ReadinessResponse
+}
+
+func (h *Handlers) GetHealthz(ctx echo.Context) error {
+	return ctx.JSON(http.StatusOK, api.HealthResponse{Status: api.HealthResponseStatusOk})
+}
+
+func (h *Handlers) GetReadyz(ctx echo.Context) error {
+	resp := h.readiness.CheckReadiness(ctx.Request().Context())
+	if resp.Status != api.Ready {
+		h.log.Warn("service is not ready", "checks", resp.Checks)
+		return ctx.JSON(http.StatusServiceUnavailable, resp)
+	}
+	return ctx.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/chimort/avito_test_task/iternal/importer"
+	"github.com/labstack/echo/v4"
+)
+
+// maxImportSize caps the body of /team/import.
+const maxImportSize = 10 << 20
+
+func (h *Handlers) PostTeamImport(ctx echo.Context) error {
+	format, err := importer.FormatFromContentType(ctx.Request().Header.Get(echo.HeaderContentType))
+	if err != nil {
+		return ctx.JSON(http.StatusUnsupportedMediaType, api.ErrorResponse{
+			Error: struct {
+				Code    api.ErrorResponseErrorCode `json:"code"`
+				Message string                     `json:"message"`
+			}{
+				Code:    api.BADREQUEST,
+				Message: err.Error(),
+			},
+		})
+	}
+
+	body := http.MaxBytesReader(ctx.Response(), ctx.Request().Body, maxImportSize)
+	teams, err := importer.Parse(body, format)
+	var lineErrs importer.Errors
+	if errors.As(err, &lineErrs) {
+		resp := api.ImportErrorResponse{}
+		resp.Error.Code = string(api.BADREQUEST)
+		resp.Error.Message = "invalid import file"
+		for _, le := range lineErrs {
+			resp.Errors = append(resp.Errors, struct {
+				Line    int    `json:"line"`
+				Message string `json:"message"`
+			}{Line: le.Line, Message: le.Message})
+		}
+		return ctx.JSON(http.StatusBadRequest, resp)
+	}
+	if err != nil {
+		h.log.Error("failed to read import file", "error", err)
+		return h.badRequest(ctx, "failed to read body")
+	}
+
+	if err := h.checker.WriteTeams(ctx.Request().Context(), teams); err != nil {
+		return h.denied(ctx, err)
+	}
+
+	res, err := h.userService.ImportTeams(ctx.Request().Context(), teams)
+	if err != nil {
+		return h.teamError(ctx, err, "failed to import teams")
+	}
+	return ctx.JSON(http.StatusOK, map[string]interface{}{"result": res})
+}
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/chimort/avito_test_task/iternal/authz"
+	"github.com/chimort/avito_test_task/iternal/repository"
+	"github.com/chimort/avito_test_task/iternal/service"
+	"github.com/labstack/echo/v4"
+)
+
+func (h *Handlers) GetPullRequestGet(ctx echo.Context, params api.GetPullRequestGetParams) error {
+	if params.PullRequestId == "" {
+		return ctx.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: struct {
+				Code    api.ErrorResponseErrorCode `json:"code"`
+				Message string                     `json:"message"`
+			}{
+				Code:    api.BADREQUEST,
+				Message: "pull_request_id is required",
+			},
+		})
+	}
+
+	if ok, err := h.checker.PullRequestInScope(ctx.Request().Context(), params.PullRequestId); !ok {
+		return h.outOfScope(ctx, err)
+	}
+
+	pr, err := h.userService.GetPullRequest(ctx.Request().Context(), params.PullRequestId)
+	if err != nil {
+		if errors.Is(err, repository.ErrPRNotFound) {
+			return ctx.JSON(http.StatusNotFound, api.ErrorResponse{
+				Error: struct {
+					Code    api.ErrorResponseErrorCode `json:"code"`
+					Message string                     `json:"message"`
+				}{
+					Code:    api.NOTFOUND,
+					Message: "PR not found",
+				},
+			})
+		}
+		h.log.Error("failed to get PR", "error", err)
+		return ctx.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Error: struct {
+				Code    api.ErrorResponseErrorCode `json:"code"`
+				Message string                     `json:"message"`
+			}{
+				Code:    api.NOTFOUND,
+				Message: "failed to get PR",
+			},
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]interface{}{"pr": pr})
+}
+
+func (h *Handlers) GetPullRequestList(ctx echo.Context, params api.GetPullRequestListParams) error {
+	if msg := service.ValidatePage((*string)(params.Status), params.Order, params.Limit, params.CreatedAfter, params.CreatedBefore); msg != "" {
+		return ctx.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: struct {
+				Code    api.ErrorResponseErrorCode `json:"code"`
+				Message string                     `json:"message"`
+			}{
+				Code:    api.BADREQUEST,
+				Message: msg,
+			},
+		})
+	}
+
+	// A team-scoped caller only sees its own team's pull requests.
+	if scope := authz.TeamScope(ctx.Request().Context()); scope != "" {
+		if params.TeamName != nil && *params.TeamName != scope {
+			return h.outOfScope(ctx, nil)
+		}
+		params.TeamName = &scope
+	}
+
+	prs, next, err := h.userService.ListPullRequests(ctx.Request().Context(), params)
+	if errors.Is(err, service.ErrInvalidCursor) {
+		return ctx.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: struct {
+				Code    api.ErrorResponseErrorCode `json:"code"`
+				Message string                     `json:"message"`
+			}{
+				Code:    api.BADREQUEST,
+				Message: "invalid cursor",
+			},
+		})
+	}
+	if err != nil {
+		h.log.Error("failed to list PRs", "error", err)
+		return ctx.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Error: struct {
+				Code    api.ErrorResponseErrorCode `json:"code"`
+				Message string                     `json:"message"`
+			}{
+				Code:    api.NOTFOUND,
+				Message: "failed to list PRs",
+			},
+		})
+	}
+
+	resp := map[string]interface{}{"pull_requests": prs}
+	if next != "" {
+		resp["next_cursor"] = next
+	}
+	return ctx.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/chimort/avito_test_task/iternal/authz"
+	"github.com/labstack/echo/v4"
+)
+
+func (h *Handlers) GetStatsReviewers(ctx echo.Context, params api.GetStatsReviewersParams) error {
+	if params.From != nil && params.To != nil && !params.From.Before(*params.To) {
+		return ctx.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: struct {
+				Code    api.ErrorResponseErrorCode `json:"code"`
+				Message string                     `json:"message"`
+			}{
+				Code:    api.BADREQUEST,
+				Message: "from must be before to",
+			},
+		})
+	}
+
+	// Stats span all teams, so team-scoped keys can't read them.
+	if authz.TeamScope(ctx.Request().Context()) != "" {
+		return h.outOfScope(ctx, nil)
+	}
+
+	stats, err := h.userService.GetReviewerStats(ctx.Request().Context(), params.From, params.To)
+	if err != nil {
+		h.log.Error("failed to get reviewer stats", "error", err)
+		return ctx.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Error: struct {
+				Code    api.ErrorResponseErrorCode `json:"code"`
+				Message string                     `json:"message"`
+			}{
+				Code:    api.NOTFOUND,
+				Message: "failed to get reviewer stats",
+			},
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]interface{}{"reviewers": stats})
+}
+
+func (h *Handlers) GetStatsTeams(ctx echo.Context, params api.GetStatsTeamsParams) error {
+	if params.From != nil && params.To != nil && !params.From.Before(*params.To) {
+		return ctx.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: struct {
+				Code    api.ErrorResponseErrorCode `json:"code"`
+				Message string                     `json:"message"`
+			}{
+				Code:    api.BADREQUEST,
+				Message: "from must be before to",
+			},
+		})
+	}
+
+	if authz.TeamScope(ctx.Request().Context()) != "" {
+		return h.outOfScope(ctx, nil)
+	}
+
+	stats, err := h.userService.GetTeamStats(ctx.Request().Context(), params.From, params.To)
+	if err != nil {
+		h.log.Error("failed to get team stats", "error", err)
+		return ctx.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Error: struct {
+				Code    api.ErrorResponseErrorCode `json:"code"`
+				Message string                     `json:"message"`
+			}{
+				Code:    api.NOTFOUND,
+				Message: "failed to get team stats",
+			},
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]interface{}{"teams": stats})
+}
@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/chimort/avito_test_task/iternal/authz"
+	"github.com/chimort/avito_test_task/iternal/repository"
+	"github.com/chimort/avito_test_task/iternal/service"
+	"github.com/labstack/echo/v4"
+)
+
+func (h *Handlers) PostTeamAddMembers(ctx echo.Context) error {
+	var body api.PostTeamAddMembersJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		h.log.Error("failed to bind request body", "error", err)
+		return h.badRequest(ctx, "invalid body")
+	}
+
+	if body.TeamName == "" || len(body.Members) == 0 {
+		return h.badRequest(ctx, "team_name and members are required")
+	}
+	for _, m := range body.Members {
+		if msg := service.ValidateMember(m); msg != "" {
+			return h.badRequest(ctx, msg)
+		}
+	}
+
+	if err := h.checker.AddMembers(ctx.Request().Context(), body.TeamName, body.Members); err != nil {
+		return h.denied(ctx, err)
+	}
+
+	team, err := h.userService.AddMembers(ctx.Request().Context(), body.TeamName, body.Members)
+	if err != nil {
+		return h.teamError(ctx, err, "failed to add team members")
+	}
+	return ctx.JSON(http.StatusOK, map[string]interface{}{"team": team})
+}
+
+func (h *Handlers) PostTeamRemoveMembers(ctx echo.Context) error {
+	var body api.PostTeamRemoveMembersJSONBody
+	if err := ctx.Bind(&body); err != nil {
+		h.log.Error("failed to bind request body", "error", err)
+		return h.badRequest(ctx, "invalid body")
+	}
+
+	if body.TeamName == "" || len(body.UserIds) == 0 {
+		return h.badRequest(ctx, "team_name and user_ids are required")
+	}
+
+	if !authz.TeamInScope(ctx.Request().Context(), body.TeamName) {
+		return h.outOfScope(ctx, nil)
+	}
+	if ok, err := h.checker.LeadsTeam(ctx.Request().Context(), body.TeamName); !ok {
+		return h.notLead(ctx, err)
+	}
+
+	res, err := h.userService.RemoveMembers(ctx.Request().Context(), body.TeamName, body.UserIds)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return ctx.JSON(http.StatusNotFound, api.ErrorResponse{
+			Error: struct {
+				Code    api.ErrorResponseErrorCode `json:"code"`
+				Message string                     `json:"message"`
+			}{
+				Code:    api.NOTFOUND,
+				Message: "user is not a member of the team",
+			},
+		})
+	}
+	if err != nil {
+		return h.teamError(ctx, err, "failed to remove team members")
+	}
+	return ctx.JSON(http.StatusOK, map[string]interface{}{
+		"team":               res.Team,
+		"reassigned_reviews": res.ReassignedReviews,
+		"removed_reviews":    res.RemovedReviews,
+	})
+}
+
+func (h *Handlers) PostTeamRename(ctx echo.Context) error {
+	var body api.PostTeamRenameJSONBody
+	if err := ctx.Bind(&body); err != nil {
+		h.log.Error("failed to bind request body", "error", err)
+		return h.badRequest(ctx, "invalid body")
+	}
+
+	if body.TeamName == "" || body.NewTeamName == "" {
+		return h.badRequest(ctx, "team_name and new_team_name are required")
+	}
+
+	if !authz.TeamInScope(ctx.Request().Context(), body.TeamName) {
+		return h.outOfScope(ctx, nil)
+	}
+
+	team, err := h.userService.RenameTeam(ctx.Request().Context(), body.TeamName, body.NewTeamName)
+	if errors.Is(err, repository.ErrTeamExists) {
+		return ctx.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: struct {
+				Code    api.ErrorResponseErrorCode `json:"code"`
+				Message string                     `json:"message"`
+			}{
+				Code:    api.TEAMEXISTS,
+				Message: "new_team_name already exists",
+			},
+		})
+	}
+	if err != nil {
+		return h.teamError(ctx, err, "failed to rename team")
+	}
+	return ctx.JSON(http.StatusOK, map[string]interface{}{"team": team})
+}
+
+func (h *Handlers) PostTeamDelete(ctx echo.Context) error {
+	var body api.PostTeamDeleteJSONBody
+	if err := ctx.Bind(&body); err != nil {
+		h.log.Error("failed to bind request body", "error", err)
+		return h.badRequest(ctx, "invalid body")
+	}
+
+	if body.TeamName == "" {
+		return h.badRequest(ctx, "team_name is required")
+	}
+
+	if !authz.TeamInScope(ctx.Request().Context(), body.TeamName) {
+		return h.outOfScope(ctx, nil)
+	}
+
+	if err := h.userService.DeleteTeam(ctx.Request().Context(), body.TeamName); err != nil {
+		return h.teamError(ctx, err, "failed to delete team")
+	}
+	return ctx.JSON(http.StatusOK, map[string]interface{}{"team_name": body.TeamName})
+}
+
+func (h *Handlers) PostTeamSync(ctx echo.Context) error {
+	var body api.PostTeamSyncJSONBody
+	if err := ctx.Bind(&body); err != nil {
+		h.log.Error("failed to bind request body", "error", err)
+		return h.badRequest(ctx, "invalid body")
+	}
+
+	if msg := service.ValidateSync(body.Teams); msg != "" {
+		return h.badRequest(ctx, msg)
+	}
+	if err := h.checker.WriteTeams(ctx.Request().Context(), body.Teams); err != nil {
+		return h.denied(ctx, err)
+	}
+
+	dryRun := body.DryRun != nil && *body.DryRun
+	diffs, err := h.userService.SyncTeams(ctx.Request().Context(), body.Teams, dryRun)
+	if err != nil {
+		return h.teamError(ctx, err, "failed to sync teams")
+	}
+	return ctx.JSON(http.StatusOK, map[string]interface{}{"dry_run": dryRun, "teams": diffs})
+}
+
+func (h *Handlers) badRequest(ctx echo.Context, msg string) error {
+	return ctx.JSON(http.StatusBadRequest, api.ErrorResponse{
+		Error: struct {
+			Code    api.ErrorResponseErrorCode `json:"code"`
+			Message string                     `json:"message"`
+		}{
+			Code:    api.BADREQUEST,
+			Message: msg,
+		},
+	})
+}
+
+// teamError maps ErrTeamNotFound and ErrUserNotFound to 404 and anything
+// else to 500 with msg.
+func (h *Handlers) teamError(ctx echo.Context, err error, msg string) error {
+	if errors.Is(err, repository.ErrTeamNotFound) {
+		return ctx.JSON(http.StatusNotFound, api.ErrorResponse{
+			Error: struct {
+				Code    api.ErrorResponseErrorCode `json:"code"`
+				Message string                     `json:"message"`
+			}{
+				Code:    api.NOTFOUND,
+				Message: "team not found",
+			},
+		})
+	}
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return ctx.JSON(http.StatusNotFound, api.ErrorResponse{
+			Error: struct {
+				Code    api.ErrorResponseErrorCode `json:"code"`
+				Message string                     `json:"message"`
+			}{
+				Code:    api.NOTFOUND,
+				Message: "user not found",
+			},
+		})
+	}
+	h.log.Error(msg, "error", err)
+	return ctx.JSON(http.StatusInternalServerError, api.ErrorResponse{
+		Error: struct {
+			Code    api.ErrorResponseErrorCode `json:"code"`
+			Message string                     `json:"message"`
+		}{
+			Code:    api.NOTFOUND,
+			Message: msg,
+		},
+	})
+}
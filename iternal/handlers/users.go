@@ -0,0 +1,220 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/chimort/avito_test_task/iternal/authz"
+	"github.com/chimort/avito_test_task/iternal/repository"
+	"github.com/chimort/avito_test_task/iternal/service"
+	"github.com/labstack/echo/v4"
+)
+
+func (h *Handlers) GetUsersGet(ctx echo.Context, params api.GetUsersGetParams) error {
+	if params.UserId == "" {
+		return ctx.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: struct {
+				Code    api.ErrorResponseErrorCode `json:"code"`
+				Message string                     `json:"message"`
+			}{
+				Code:    api.BADREQUEST,
+				Message: "user_id is required",
+			},
+		})
+	}
+
+	if ok, err := h.checker.UserInScope(ctx.Request().Context(), params.UserId); !ok {
+		return h.outOfScope(ctx, err)
+	}
+
+	user, err := h.userService.GetUser(ctx.Request().Context(), params.UserId)
+	if err != nil {
+		return h.userError(ctx, err, "failed to get user")
+	}
+	return ctx.JSON(http.StatusOK, map[string]interface{}{"user": user})
+}
+
+func (h *Handlers) PostUsersUpdate(ctx echo.Context) error {
+	var body api.PostUsersUpdateJSONBody
+	if err := ctx.Bind(&body); err != nil {
+		h.log.Error("failed to bind request body", "error", err)
+		return ctx.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: struct {
+				Code    api.ErrorResponseErrorCode `json:"code"`
+				Message string                     `json:"message"`
+			}{
+				Code:    api.BADREQUEST,
+				Message: "invalid body",
+			},
+		})
+	}
+
+	var msg string
+	switch {
+	case body.UserId == "":
+		msg = "user_id is required"
+	case body.Username == nil && body.IsActive == nil:
+		msg = "username or is_active is required"
+	case body.Username != nil && *body.Username == "":
+		msg = "username must not be empty"
+	}
+	if msg != "" {
+		return ctx.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: struct {
+				Code    api.ErrorResponseErrorCode `json:"code"`
+				Message string                     `json:"message"`
+			}{
+				Code:    api.BADREQUEST,
+				Message: msg,
+			},
+		})
+	}
+
+	if ok, err := h.checker.UserInScope(ctx.Request().Context(), body.UserId); !ok {
+		return h.outOfScope(ctx, err)
+	}
+
+	user, err := h.userService.UpdateUser(ctx.Request().Context(), body.UserId, body.Username, body.IsActive)
+	if err != nil {
+		return h.userError(ctx, err, "failed to update user")
+	}
+	return ctx.JSON(http.StatusOK, map[string]interface{}{"user": user})
+}
+
+func (h *Handlers) PostUsersDelete(ctx echo.Context) error {
+	var body api.PostUsersDeleteJSONBody
+	if err := ctx.Bind(&body); err != nil {
+		h.log.Error("failed to bind request body", "error", err)
+		return ctx.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: struct {
+				Code    api.ErrorResponseErrorCode `json:"code"`
+				Message string                     `json:"message"`
+			}{
+				Code:    api.BADREQUEST,
+				Message: "invalid body",
+			},
+		})
+	}
+
+	mode := api.Block
+	if body.Mode != nil {
+		mode = *body.Mode
+	}
+	var msg string
+	switch {
+	case body.UserId == "":
+		msg = "user_id is required"
+	case mode != api.Block && mode != api.Reassign && mode != api.Anonymize:
+		msg = "mode must be block, reassign or anonymize"
+	}
+	if msg != "" {
+		return ctx.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: struct {
+				Code    api.ErrorResponseErrorCode `json:"code"`
+				Message string                     `json:"message"`
+			}{
+				Code:    api.BADREQUEST,
+				Message: msg,
+			},
+		})
+	}
+
+	if ok, err := h.checker.UserInScope(ctx.Request().Context(), body.UserId); !ok {
+		return h.outOfScope(ctx, err)
+	}
+
+	result, err := h.userService.DeleteUser(ctx.Request().Context(), body.UserId, mode)
+	if errors.Is(err, repository.ErrUserHasActivity) {
+		return ctx.JSON(http.StatusConflict, api.ErrorResponse{
+			Error: struct {
+				Code    api.ErrorResponseErrorCode `json:"code"`
+				Message string                     `json:"message"`
+			}{
+				Code:    api.USERHASACTIVITY,
+				Message: "user authored or reviewed pull requests, use mode reassign or anonymize",
+			},
+		})
+	}
+	if err != nil {
+		return h.userError(ctx, err, "failed to delete user")
+	}
+	return ctx.JSON(http.StatusOK, map[string]interface{}{"result": result})
+}
+
+func (h *Handlers) GetUsersList(ctx echo.Context, params api.GetUsersListParams) error {
+	if msg := service.ValidatePage(nil, nil, params.Limit, nil, nil); msg != "" {
+		return ctx.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: struct {
+				Code    api.ErrorResponseErrorCode `json:"code"`
+				Message string                     `json:"message"`
+			}{
+				Code:    api.BADREQUEST,
+				Message: msg,
+			},
+		})
+	}
+
+	if scope := authz.TeamScope(ctx.Request().Context()); scope != "" {
+		if params.TeamName != nil && *params.TeamName != scope {
+			return h.outOfScope(ctx, nil)
+		}
+		params.TeamName = &scope
+	}
+
+	users, next, err := h.userService.ListUsers(ctx.Request().Context(), params)
+	if errors.Is(err, service.ErrInvalidCursor) {
+		return ctx.JSON(http.StatusBadRequest, api.ErrorResponse{
+			Error: struct {
+				Code    api.ErrorResponseErrorCode `json:"code"`
+				Message string                     `json:"message"`
+			}{
+				Code:    api.BADREQUEST,
+				Message: "invalid cursor",
+			},
+		})
+	}
+	if err != nil {
+		h.log.Error("failed to list users", "error", err)
+		return ctx.JSON(http.StatusInternalServerError, api.ErrorResponse{
+			Error: struct {
+				Code    api.ErrorResponseErrorCode `json:"code"`
+				Message string                     `json:"message"`
+			}{
+				Code:    api.NOTFOUND,
+				Message: "failed to list users",
+			},
+		})
+	}
+
+	resp := map[string]interface{}{"users": users}
+	if next != "" {
+		resp["next_cursor"] = next
+	}
+	return ctx.JSON(http.StatusOK, resp)
+}
+
+// userError maps ErrUserNotFound to 404 and anything else to 500 with msg.
+func (h *Handlers) userError(ctx echo.Context, err error, msg string) error {
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return ctx.JSON(http.StatusNotFound, api.ErrorResponse{
+			Error: struct {
+				Code    api.ErrorResponseErrorCode `json:"code"`
+				Message string                     `json:"message"`
+			}{
+				Code:    api.NOTFOUND,
+				Message: "user not found",
+			},
+		})
+	}
+	h.log.Error(msg, "error", err)
+	return ctx.JSON(http.StatusInternalServerError, api.ErrorResponse{
+		Error: struct {
+			Code    api.ErrorResponseErrorCode `json:"code"`
+			Message string                     `json:"message"`
+		}{
+			Code:    api.NOTFOUND,
+			Message: msg,
+		},
+	})
+}
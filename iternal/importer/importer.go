@@ -0,0 +1,305 @@
+// Package importer parses bulk team rosters from CSV or YAML files.
+//
+// CSV has one member per row with the columns team,user_id,username,is_active
+// and an optional header. YAML holds the same data grouped by team:
+//
+//	teams:
+//	  - team_name: payments
+//	    members:
+//	      - {user_id: u1, username: Alice, is_active: true, role: lead}
+//
+// Every row is validated before anything is returned; problems are reported
+// together with the line they were found on.
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+	"gopkg.in/yaml.v3"
+)
+
+type Format string
+
+const (
+	CSV  Format = "csv"
+	YAML Format = "yaml"
+)
+
+var csvHeader = []string{"team", "user_id", "username", "is_active"}
+
+// FormatFromPath picks the format by file extension.
+func FormatFromPath(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return CSV, nil
+	case ".yml", ".yaml":
+		return YAML, nil
+	}
+	return "", fmt.Errorf("unsupported file %q, expected .csv, .yml or .yaml", path)
+}
+
+// FormatFromContentType picks the format by a request's Content-Type.
+func FormatFromContentType(contentType string) (Format, error) {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	switch strings.TrimSpace(strings.ToLower(mediaType)) {
+	case "text/csv":
+		return CSV, nil
+	case "application/yaml", "application/x-yaml", "text/yaml":
+		return YAML, nil
+	}
+	return "", fmt.Errorf("unsupported content type %q, expected text/csv or application/yaml", contentType)
+}
+
+// LineError is a problem with one line of the input.
+type LineError struct {
+	Line    int
+	Message string
+}
+
+func (e LineError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+}
+
+// Errors collects every problem found in the input, ordered by line.
+type Errors []LineError
+
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, le := range e {
+		msgs[i] = le.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+type row struct {
+	line   int
+	team   string
+	member api.TeamMember
+}
+
+// Parse reads a roster in the given format and returns it grouped by team
+// in input order. Invalid input yields Errors.
+func Parse(r io.Reader, format Format) ([]api.Team, error) {
+	var rows []row
+	var errs Errors
+	switch format {
+	case CSV:
+		rows, errs = parseCSV(r)
+	case YAML:
+		rows, errs = parseYAML(r)
+	default:
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+	errs = append(errs, validate(rows)...)
+	if len(errs) > 0 {
+		sort.SliceStable(errs, func(i, j int) bool { return errs[i].Line < errs[j].Line })
+		return nil, errs
+	}
+	if len(rows) == 0 {
+		return nil, Errors{{Line: 1, Message: "no members found"}}
+	}
+	return group(rows), nil
+}
+
+func parseCSV(r io.Reader) ([]row, Errors) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	var rows []row
+	var errs Errors
+	for first := true; ; first = false {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			// The reader cannot continue past a malformed quote.
+			return rows, append(errs, LineError{Line: parseErr.Line, Message: parseErr.Err.Error()})
+		}
+		if err != nil {
+			return rows, append(errs, LineError{Line: 1, Message: err.Error()})
+		}
+		line, _ := cr.FieldPos(0)
+
+		if first && isHeader(record) {
+			continue
+		}
+		if len(record) != len(csvHeader) {
+			errs = append(errs, LineError{Line: line, Message: fmt.Sprintf(
+				"expected %d columns (%s), got %d", len(csvHeader), strings.Join(csvHeader, ","), len(record))})
+			continue
+		}
+
+		isActive := true
+		if v := strings.TrimSpace(record[3]); v != "" {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				errs = append(errs, LineError{Line: line, Message: fmt.Sprintf("is_active must be true or false, got %q", v)})
+				continue
+			}
+			isActive = b
+		}
+		rows = append(rows, row{
+			line: line,
+			team: strings.TrimSpace(record[0]),
+			member: api.TeamMember{
+				UserId:   strings.TrimSpace(record[1]),
+				Username: strings.TrimSpace(record[2]),
+				IsActive: isActive,
+			},
+		})
+	}
+	return rows, errs
+}
+
+func isHeader(record []string) bool {
+	if len(record) != len(csvHeader) {
+		return false
+	}
+	for i, name := range csvHeader {
+		if strings.ToLower(strings.TrimSpace(record[i])) != name {
+			return false
+		}
+	}
+	return true
+}
+
+type yamlTeam struct {
+	TeamName string      `yaml:"team_name"`
+	Members  []yaml.Node `yaml:"members"`
+}
+
+type yamlMember struct {
+	UserID   string  `yaml:"user_id"`
+	Username string  `yaml:"username"`
+	IsActive *bool   `yaml:"is_active"`
+	Role     *string `yaml:"role"`
+}
+
+func parseYAML(r io.Reader) ([]row, Errors) {
+	var doc struct {
+		Teams []yaml.Node `yaml:"teams"`
+	}
+	if err := yaml.NewDecoder(r).Decode(&doc); err != nil && !errors.Is(err, io.EOF) {
+		return nil, Errors{yamlError(err)}
+	}
+
+	var rows []row
+	var errs Errors
+	for _, teamNode := range doc.Teams {
+		var team yamlTeam
+		if err := teamNode.Decode(&team); err != nil {
+			errs = append(errs, yamlError(err))
+			continue
+		}
+		if len(team.Members) == 0 {
+			errs = append(errs, LineError{Line: teamNode.Line, Message: "members is required"})
+			continue
+		}
+		for _, memberNode := range team.Members {
+			var m yamlMember
+			if err := memberNode.Decode(&m); err != nil {
+				errs = append(errs, yamlError(err))
+				continue
+			}
+			member := api.TeamMember{UserId: m.UserID, Username: m.Username, IsActive: true}
+			if m.IsActive != nil {
+				member.IsActive = *m.IsActive
+			}
+			if m.Role != nil {
+				role := api.TeamMemberRole(*m.Role)
+				if role != api.Lead && role != api.Member {
+					errs = append(errs, LineError{Line: memberNode.Line, Message: fmt.Sprintf("role must be lead or member, got %q", *m.Role)})
+					continue
+				}
+				member.Role = &role
+			}
+			rows = append(rows, row{line: memberNode.Line, team: team.TeamName, member: member})
+		}
+	}
+	return rows, errs
+}
+
+// yamlError keeps the line yaml.v3 puts into its messages.
+func yamlError(err error) LineError {
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) && len(typeErr.Errors) > 0 {
+		err = errors.New(typeErr.Errors[0])
+	}
+	msg := strings.TrimPrefix(err.Error(), "yaml: ")
+	var line int
+	if _, scanErr := fmt.Sscanf(msg, "line %d:", &line); scanErr == nil {
+		_, msg, _ = strings.Cut(msg, ": ")
+	} else {
+		line = 1
+	}
+	return LineError{Line: line, Message: msg}
+}
+
+// validate checks required fields and that a user is listed once per team
+// with the same name and is_active everywhere.
+func validate(rows []row) Errors {
+	type seen struct {
+		line   int
+		member api.TeamMember
+	}
+	var errs Errors
+	users := make(map[string]seen)
+	memberships := make(map[[2]string]int)
+	for _, r := range rows {
+		switch {
+		case r.team == "":
+			errs = append(errs, LineError{Line: r.line, Message: "team is required"})
+			continue
+		case r.member.UserId == "":
+			errs = append(errs, LineError{Line: r.line, Message: "user_id is required"})
+			continue
+		case r.member.Username == "":
+			errs = append(errs, LineError{Line: r.line, Message: "username is required"})
+			continue
+		}
+
+		key := [2]string{r.team, r.member.UserId}
+		if prev, ok := memberships[key]; ok {
+			errs = append(errs, LineError{Line: r.line, Message: fmt.Sprintf(
+				"user %s is already in team %s on line %d", r.member.UserId, r.team, prev)})
+			continue
+		}
+		memberships[key] = r.line
+
+		if prev, ok := users[r.member.UserId]; ok {
+			if prev.member.Username != r.member.Username || prev.member.IsActive != r.member.IsActive {
+				errs = append(errs, LineError{Line: r.line, Message: fmt.Sprintf(
+					"user %s differs from line %d in username or is_active", r.member.UserId, prev.line)})
+			}
+			continue
+		}
+		users[r.member.UserId] = seen{line: r.line, member: r.member}
+	}
+	return errs
+}
+
+func group(rows []row) []api.Team {
+	var teams []api.Team
+	index := make(map[string]int)
+	for _, r := range rows {
+		i, ok := index[r.team]
+		if !ok {
+			i = len(teams)
+			index[r.team] = i
+			teams = append(teams, api.Team{TeamName: r.team})
+		}
+		teams[i].Members = append(teams[i].Members, r.member)
+	}
+	return teams
+}
@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/chimort/avito_test_task/iternal/auth"
+	"github.com/chimort/avito_test_task/iternal/pkg/logger"
+	"github.com/labstack/echo/v4"
+)
+
+// publicRoutes are served without a token.
+var publicRoutes = map[string]bool{
+	"/healthz": true,
+	"/readyz":  true,
+	"/metrics": true,
+}
+
+// userRoutes are the routes open to the user role. Handlers further check
+// that the user acts on their own reviews or leads the target team.
+var userRoutes = map[string]bool{
+	http.MethodGet + " /users/getReview":       true,
+	http.MethodPost + " /pullRequest/reassign": true,
+	http.MethodPost + " /team/addMembers":      true,
+	http.MethodPost + " /team/removeMembers":   true,
+	http.MethodPost + " /users/setIsActive":    true,
+}
+
+// Auth requires a valid bearer token on every non-public route and stores
+// the caller in the request context.
+func Auth(a auth.Authenticator, log *logger.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if publicRoutes[c.Path()] {
+				return next(c)
+			}
+
+			token, ok := bearerToken(c.Request())
+			if !ok {
+				return unauthorized(c, "missing bearer token")
+			}
+
+			req := c.Request()
+			principal, err := a.Authenticate(req.Context(), token)
+			if err != nil {
+				if errors.Is(err, auth.ErrInvalidToken) {
+					log.Warn("authentication failed", "error", err, "path", req.URL.Path)
+				} else {
+					log.Error("failed to authenticate", "error", err, "path", req.URL.Path)
+				}
+				return unauthorized(c, "invalid bearer token")
+			}
+
+			if !principal.IsAdmin() && !userRoutes[req.Method+" "+c.Path()] {
+				log.Warn("access denied", "principal", principal.Name, "role", principal.Role, "path", c.Path())
+				return errorJSON(c, http.StatusForbidden, api.FORBIDDEN, "not allowed for this role")
+			}
+
+			c.SetRequest(req.WithContext(auth.WithPrincipal(req.Context(), principal)))
+			return next(c)
+		}
+	}
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get(echo.HeaderAuthorization), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", false
+	}
+	return strings.TrimSpace(token), true
+}
+
+func unauthorized(c echo.Context, msg string) error {
+	c.Response().Header().Set(echo.HeaderWWWAuthenticate, `Bearer realm="pr-service"`)
+	return errorJSON(c, http.StatusUnauthorized, api.UNAUTHORIZED, msg)
+}
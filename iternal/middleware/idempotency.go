@@ -0,0 +1,141 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/chimort/avito_test_task/iternal/auth"
+	"github.com/chimort/avito_test_task/iternal/pkg/logger"
+	"github.com/chimort/avito_test_task/iternal/repository"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	HeaderIdempotencyKey = "Idempotency-Key"
+	HeaderReplayed       = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLen = 255
+)
+
+// idempotencySkip lists POST routes whose responses must not be stored:
+// /apiKeys/create returns the only copy of a secret.
+var idempotencySkip = map[string]bool{
+	"/apiKeys/create": true,
+}
+
+type IdempotencyStore interface {
+	Reserve(ctx context.Context, rec repository.IdempotencyRecord) (*repository.IdempotencyRecord, bool, error)
+	Complete(ctx context.Context, scope, key string, status int, contentType string, body []byte) error
+	Release(ctx context.Context, scope, key string) error
+}
+
+// Idempotency replays the stored response for a POST retried with the same
+// Idempotency-Key and body. Keys are per caller. 5xx responses are not
+// stored so the request can be retried. A request holds its key for at most
+// lease; after that a retry may take it over.
+func Idempotency(store IdempotencyStore, ttl, lease time.Duration, log *logger.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			key := req.Header.Get(HeaderIdempotencyKey)
+			if req.Method != http.MethodPost || key == "" || idempotencySkip[c.Path()] {
+				return next(c)
+			}
+			if len(key) > maxIdempotencyKeyLen {
+				return errorJSON(c, http.StatusBadRequest, api.BADREQUEST, "idempotency key is too long")
+			}
+
+			body, err := io.ReadAll(req.Body)
+			if err != nil {
+				return errorJSON(c, http.StatusBadRequest, api.BADREQUEST, "failed to read body")
+			}
+			req.Body = io.NopCloser(bytes.NewReader(body))
+
+			hash := sha256.New()
+			hash.Write([]byte(c.Path()))
+			hash.Write([]byte{0})
+			hash.Write(body)
+
+			// Names of keys and tokens need not be unique, IDs are.
+			var scope string
+			if p, ok := auth.FromContext(req.Context()); ok {
+				scope = p.ID
+			}
+
+			rec := repository.IdempotencyRecord{
+				Scope:       scope,
+				Key:         key,
+				RequestHash: hex.EncodeToString(hash.Sum(nil)),
+				ExpiresAt:   time.Now().Add(ttl),
+				LockedUntil: time.Now().Add(lease),
+			}
+			stored, reserved, err := store.Reserve(req.Context(), rec)
+			if err != nil {
+				if errors.Is(err, repository.ErrIdempotencyConflict) {
+					return errorJSON(c, http.StatusConflict, api.IDEMPOTENCYKEYINUSE, "request with this idempotency key is in progress")
+				}
+				log.Error("failed to reserve idempotency key", "error", err)
+				return errorJSON(c, http.StatusInternalServerError, api.NOTFOUND, "failed to check idempotency key")
+			}
+
+			if !reserved {
+				switch {
+				case stored.RequestHash != rec.RequestHash:
+					return errorJSON(c, http.StatusUnprocessableEntity, api.IDEMPOTENCYKEYREUSED, "idempotency key was used with a different request")
+				case stored.StatusCode == 0:
+					return errorJSON(c, http.StatusConflict, api.IDEMPOTENCYKEYINUSE, "request with this idempotency key is in progress")
+				}
+				c.Response().Header().Set(HeaderReplayed, "true")
+				return c.Blob(stored.StatusCode, stored.ContentType, stored.Body)
+			}
+
+			recorder := &bodyRecorder{ResponseWriter: c.Response().Writer}
+			c.Response().Writer = recorder
+
+			// Store the outcome even if the client has gone away, since that
+			// is exactly when it will retry.
+			ctx := context.WithoutCancel(req.Context())
+			release := func() {
+				if rerr := store.Release(ctx, scope, key); rerr != nil {
+					log.Error("failed to release idempotency key", "error", rerr)
+				}
+			}
+			defer func() {
+				if r := recover(); r != nil {
+					release()
+					panic(r)
+				}
+			}()
+
+			err = next(c)
+			status := c.Response().Status
+			if err != nil || status >= http.StatusInternalServerError {
+				release()
+				return err
+			}
+
+			contentType := c.Response().Header().Get(echo.HeaderContentType)
+			if err := store.Complete(ctx, scope, key, status, contentType, recorder.body.Bytes()); err != nil {
+				log.Error("failed to store idempotent response", "error", err)
+			}
+			return nil
+		}
+	}
+}
+
+type bodyRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *bodyRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/labstack/echo/v4"
+)
+
+func errorJSON(c echo.Context, status int, code api.ErrorResponseErrorCode, msg string) error {
+	return c.JSON(status, api.ErrorResponse{
+		Error: struct {
+			Code    api.ErrorResponseErrorCode `json:"code"`
+			Message string                     `json:"message"`
+		}{
+			Code:    code,
+			Message: msg,
+		},
+	})
+}
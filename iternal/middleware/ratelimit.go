@@ -0,0 +1,173 @@
+package middleware
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/chimort/avito_test_task/iternal/auth"
+	"github.com/chimort/avito_test_task/iternal/pkg/logger"
+	"github.com/chimort/avito_test_task/iternal/pkg/metrics"
+	"github.com/labstack/echo/v4"
+	"golang.org/x/time/rate"
+)
+
+type RateLimit struct {
+	RequestsPerMinute int
+	Burst             int
+}
+
+// RateLimiter keeps a token bucket per client and route.
+type RateLimiter struct {
+	def    RateLimit
+	routes map[string]RateLimit
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// NewRateLimiter applies def to every route not listed in routes. Routes
+// are keyed by method and path template, e.g. "POST /pullRequest/reassign".
+func NewRateLimiter(def RateLimit, routes map[string]RateLimit) *RateLimiter {
+	return &RateLimiter{
+		def:     def,
+		routes:  routes,
+		buckets: map[string]*bucket{},
+	}
+}
+
+// Middleware answers 429 with Retry-After once the client's bucket for the
+// route is empty. It must run after Auth so it sees the principal.
+func (l *RateLimiter) Middleware(log *logger.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if publicRoutes[c.Path()] {
+				return next(c)
+			}
+
+			route := c.Request().Method + " " + c.Path()
+			client := ClientKey(c.Request().Context(), c.RealIP())
+			wait := l.Reserve(client, route)
+			if wait == 0 {
+				return next(c)
+			}
+
+			metrics.RateLimited.WithLabelValues(route).Inc()
+			log.Warn("rate limit exceeded", "route", route, "retry_after", wait.String())
+			c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			return errorJSON(c, http.StatusTooManyRequests, api.RATELIMITED, "rate limit exceeded")
+		}
+	}
+}
+
+// Reserve takes a token from the client's bucket for route and returns 0,
+// or returns how long the client has to wait for one without taking it.
+// The gRPC API calls it with the HTTP route it mirrors, so both APIs share
+// the buckets.
+func (l *RateLimiter) Reserve(client, route string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	r := l.bucket(client, route, now).limiter.ReserveN(now, 1)
+	if d := r.DelayFrom(now); d > 0 {
+		r.CancelAt(now)
+		return d
+	}
+	return 0
+}
+
+// Peek is Reserve without taking the token.
+func (l *RateLimiter) Peek(client, route string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	r := l.bucket(client, route, now).limiter.ReserveN(now, 1)
+	defer r.CancelAt(now)
+	return r.DelayFrom(now)
+}
+
+// bucket returns the client's bucket for route, creating a full one on
+// first use. l.mu must be held.
+func (l *RateLimiter) bucket(client, route string, now time.Time) *bucket {
+	key := client + "|" + route
+	b, ok := l.buckets[key]
+	if !ok {
+		limit, ok := l.routes[route]
+		if !ok {
+			limit = l.def
+		}
+		b = &bucket{limiter: rate.NewLimiter(rate.Limit(float64(limit.RequestsPerMinute)/60), limit.Burst)}
+		l.buckets[key] = b
+	}
+	b.lastSeen = now
+	return b
+}
+
+// Prune forgets buckets not used for idle. A forgotten bucket starts full
+// again, so idle must exceed the time to refill one.
+func (l *RateLimiter) Prune(idle time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	cutoff := time.Now().Add(-idle)
+	for key, b := range l.buckets {
+		if b.lastSeen.Before(cutoff) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// AuthFailuresRoute is the route failed authentications are counted on by
+// FailedAuthLimit.
+const AuthFailuresRoute = "auth failures"
+
+// FailedAuthLimit answers 429 with Retry-After to an IP whose bucket of
+// failed authentications is empty, so that tokens cannot be guessed faster
+// than l allows. It must run before Auth, which rejects bad tokens before
+// Middleware sees them. Only 401 responses take a token, so callers with a
+// valid token are limited by Middleware alone. l should be a limiter of its
+// own, sized for failures rather than requests.
+func FailedAuthLimit(l *RateLimiter, log *logger.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if publicRoutes[c.Path()] {
+				return next(c)
+			}
+
+			client := "ip:" + c.RealIP()
+			if wait := l.Peek(client, AuthFailuresRoute); wait > 0 {
+				metrics.RateLimited.WithLabelValues(AuthFailuresRoute).Inc()
+				log.Warn("too many failed authentications", "ip", c.RealIP(), "retry_after", wait.String())
+				c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+				return errorJSON(c, http.StatusTooManyRequests, api.RATELIMITED, "too many failed authentications")
+			}
+
+			err := next(c)
+			if c.Response().Status == http.StatusUnauthorized {
+				l.Reserve(client, AuthFailuresRoute)
+			}
+			return err
+		}
+	}
+}
+
+// ClientKey identifies the caller by the principal authentication stored in
+// ctx, or by ip when authentication is disabled. An unverified bearer token
+// is never used, or every made-up token would get a fresh bucket.
+func ClientKey(ctx context.Context, ip string) string {
+	if p, ok := auth.FromContext(ctx); ok {
+		return "principal:" + p.ID
+	}
+	return "ip:" + ip
+}
@@ -1,32 +1,65 @@
 package logger
 
 import (
+	"fmt"
 	"log/slog"
 	"os"
+	"strings"
 )
 
 type Level slog.Level
 
 const (
+	LevelDebug Level = Level(slog.LevelDebug)
 	LevelInfo  Level = Level(slog.LevelInfo)
 	LevelWarn  Level = Level(slog.LevelWarn)
 	LevelError Level = Level(slog.LevelError)
 )
 
+func ParseLevel(s string) (Level, error) {
+	switch strings.ToLower(s) {
+	case "debug":
+		return LevelDebug, nil
+	case "info":
+		return LevelInfo, nil
+	case "warn":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	}
+	return LevelInfo, fmt.Errorf("unknown log level %q", s)
+}
+
 type Logger struct {
 	log *slog.Logger
 }
 
 func NewLogger(serviceName string, level Level) *Logger {
-	handler := slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+	return NewLoggerWithFormat(serviceName, level, "json")
+}
+
+// NewLoggerWithFormat is NewLogger with a choice of "json" or "text" output.
+func NewLoggerWithFormat(serviceName string, level Level, format string) *Logger {
+	opts := &slog.HandlerOptions{
 		Level: slog.Level(level),
-	})
+	}
+
+	var handler slog.Handler
+	if format == "text" {
+		handler = slog.NewTextHandler(os.Stdout, opts)
+	} else {
+		handler = slog.NewJSONHandler(os.Stdout, opts)
+	}
 
 	return &Logger{
 		log: slog.New(handler).With("service", serviceName),
 	}
 }
 
+func (l *Logger) Debug(msg string, args ...any) {
+	l.log.Debug(msg, args...)
+}
+
 func (l *Logger) Info(msg string, args ...any) {
 	l.log.Info(msg, args...)
 }
@@ -0,0 +1,302 @@
+package prctl
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/chimort/avito_test_task/client"
+)
+
+type runFunc func(ctx context.Context, c *cli, args []string) error
+
+// command is a leaf of the command tree. setup registers the command's
+// flags and returns the function that runs it.
+type command struct {
+	args    string
+	summary string
+	setup   func(fs *flag.FlagSet) runFunc
+}
+
+// groups is the command tree: prctl GROUP NAME. It is filled in init
+// because the completion command reads it.
+var groups map[string]map[string]command
+
+func init() {
+	groups = map[string]map[string]command{
+		"team": {
+			"add": {"NAME USER_ID:USERNAME[:inactive]...", "create a team with its members", teamAdd},
+			"get": {"NAME", "show a team and its members", teamGet},
+		},
+		"users": {
+			"set-active": {"USER_ID true|false", "activate or deactivate a user", usersSetActive},
+		},
+		"pr": {
+			"create":   {"ID AUTHOR_ID TITLE", "create a pull request and assign reviewers", prCreate},
+			"merge":    {"ID", "merge a pull request", prMerge},
+			"reassign": {"ID OLD_REVIEWER_ID", "replace a reviewer of a pull request", prReassign},
+		},
+		"reviews": {
+			"list": {"USER_ID", "list pull requests the user reviews", reviewsList},
+		},
+		"profile": {
+			"list":   {"", "list configured profiles", profileList},
+			"use":    {"NAME", "make NAME the current profile", profileUse},
+			"set":    {"NAME", "create or update a profile", profileSet},
+			"delete": {"NAME", "delete a profile", profileDelete},
+		},
+		"completion": {
+			"bash": {"", "print the bash completion script", completion("bash")},
+			"zsh":  {"", "print the zsh completion script", completion("zsh")},
+			"fish": {"", "print the fish completion script", completion("fish")},
+		},
+	}
+}
+
+func noFlags(run runFunc) func(*flag.FlagSet) runFunc {
+	return func(*flag.FlagSet) runFunc { return run }
+}
+
+var teamAdd = noFlags(func(ctx context.Context, c *cli, args []string) error {
+	if len(args) < 1 {
+		return errUsage
+	}
+	team := client.Team{TeamName: args[0], Members: []client.TeamMember{}}
+	for _, spec := range args[1:] {
+		m, err := parseMember(spec)
+		if err != nil {
+			return err
+		}
+		team.Members = append(team.Members, m)
+	}
+
+	cl, err := c.client()
+	if err != nil {
+		return err
+	}
+	resp, err := cl.PostTeamAddWithResponse(ctx, team)
+	if err != nil {
+		return err
+	}
+	return c.printTeam(resp.JSON201.Team)
+})
+
+// parseMember reads USER_ID:USERNAME with an optional :inactive suffix.
+func parseMember(spec string) (client.TeamMember, error) {
+	parts := strings.Split(spec, ":")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+		return client.TeamMember{}, fmt.Errorf("member %q: expected USER_ID:USERNAME[:inactive]", spec)
+	}
+	m := client.TeamMember{UserId: parts[0], Username: parts[1], IsActive: true}
+	if len(parts) == 3 {
+		switch parts[2] {
+		case "active":
+		case "inactive":
+			m.IsActive = false
+		default:
+			return client.TeamMember{}, fmt.Errorf("member %q: expected active or inactive after the username", spec)
+		}
+	}
+	return m, nil
+}
+
+var teamGet = noFlags(func(ctx context.Context, c *cli, args []string) error {
+	if len(args) != 1 {
+		return errUsage
+	}
+	cl, err := c.client()
+	if err != nil {
+		return err
+	}
+	resp, err := cl.GetTeamGetWithResponse(ctx, &client.GetTeamGetParams{TeamName: args[0]})
+	if err != nil {
+		return err
+	}
+	return c.printTeam(resp.JSON200)
+})
+
+var usersSetActive = noFlags(func(ctx context.Context, c *cli, args []string) error {
+	if len(args) != 2 {
+		return errUsage
+	}
+	active, err := strconv.ParseBool(args[1])
+	if err != nil {
+		return fmt.Errorf("expected true or false, got %q", args[1])
+	}
+	cl, err := c.client()
+	if err != nil {
+		return err
+	}
+	resp, err := cl.PostUsersSetIsActiveWithResponse(ctx, client.PostUsersSetIsActiveJSONRequestBody{UserId: args[0], IsActive: active})
+	if err != nil {
+		return err
+	}
+	return c.printUser(resp.JSON200.User)
+})
+
+var prCreate = noFlags(func(ctx context.Context, c *cli, args []string) error {
+	if len(args) < 3 {
+		return errUsage
+	}
+	cl, err := c.client()
+	if err != nil {
+		return err
+	}
+	resp, err := cl.PostPullRequestCreateWithResponse(ctx, client.PostPullRequestCreateJSONRequestBody{
+		PullRequestId:   args[0],
+		AuthorId:        args[1],
+		PullRequestName: strings.Join(args[2:], " "),
+	})
+	if err != nil {
+		return err
+	}
+	return c.printPullRequest(resp.JSON201.Pr)
+})
+
+var prMerge = noFlags(func(ctx context.Context, c *cli, args []string) error {
+	if len(args) != 1 {
+		return errUsage
+	}
+	cl, err := c.client()
+	if err != nil {
+		return err
+	}
+	resp, err := cl.PostPullRequestMergeWithResponse(ctx, client.PostPullRequestMergeJSONRequestBody{PullRequestId: args[0]})
+	if err != nil {
+		return err
+	}
+	return c.printPullRequest(resp.JSON200.Pr)
+})
+
+var prReassign = noFlags(func(ctx context.Context, c *cli, args []string) error {
+	if len(args) != 2 {
+		return errUsage
+	}
+	cl, err := c.client()
+	if err != nil {
+		return err
+	}
+	resp, err := cl.PostPullRequestReassignWithResponse(ctx, client.PostPullRequestReassignJSONRequestBody{
+		PullRequestId: args[0],
+		OldUserId:     args[1],
+	})
+	if err != nil {
+		return err
+	}
+	if c.output == "json" {
+		return c.printJSON(resp.JSON200)
+	}
+	fmt.Fprintf(c.stdout, "%s replaced by %s\n", args[1], resp.JSON200.ReplacedBy)
+	return c.printPullRequest(&resp.JSON200.Pr)
+})
+
+func reviewsList(fs *flag.FlagSet) runFunc {
+	status := fs.String("status", "", "only OPEN or MERGED pull requests")
+	limit := fs.Int("limit", 0, "page size, the server default when 0")
+	all := fs.Bool("all", false, "follow next_cursor and print every page")
+
+	return func(ctx context.Context, c *cli, args []string) error {
+		if len(args) != 1 {
+			return errUsage
+		}
+		params := client.GetUsersGetReviewParams{UserId: args[0]}
+		if *status != "" {
+			s := client.GetUsersGetReviewParamsStatus(strings.ToUpper(*status))
+			if s != client.GetUsersGetReviewParamsStatusOPEN && s != client.GetUsersGetReviewParamsStatusMERGED {
+				return fmt.Errorf("status must be OPEN or MERGED")
+			}
+			params.Status = &s
+		}
+		if *limit > 0 {
+			params.Limit = limit
+		}
+
+		cl, err := c.client()
+		if err != nil {
+			return err
+		}
+		reviews := []client.ReviewAssignment{}
+		for {
+			resp, err := cl.GetUsersGetReviewWithResponse(ctx, &params)
+			if err != nil {
+				return err
+			}
+			reviews = append(reviews, resp.JSON200.PullRequests...)
+			if !*all || resp.JSON200.NextCursor == nil {
+				break
+			}
+			params.Cursor = resp.JSON200.NextCursor
+		}
+		return c.printReviews(reviews)
+	}
+}
+
+var profileList = noFlags(func(ctx context.Context, c *cli, args []string) error {
+	if len(args) != 0 {
+		return errUsage
+	}
+	if c.output == "json" {
+		return c.printJSON(c.cfg)
+	}
+	return c.table([]string{"CURRENT", "NAME", "SERVER"}, func(row func(...any)) {
+		for _, name := range sortedKeys(c.cfg.Profiles) {
+			current := ""
+			if name == c.cfg.Current {
+				current = "*"
+			}
+			row(current, name, c.cfg.Profiles[name].Server)
+		}
+	})
+})
+
+var profileUse = noFlags(func(ctx context.Context, c *cli, args []string) error {
+	if len(args) != 1 {
+		return errUsage
+	}
+	if _, ok := c.cfg.Profiles[args[0]]; !ok {
+		return fmt.Errorf("profile %q not found", args[0])
+	}
+	c.cfg.Current = args[0]
+	return c.cfg.Save(c.cfgPath)
+})
+
+func profileSet(fs *flag.FlagSet) runFunc {
+	server := fs.String("server", "", "API base URL")
+	token := fs.String("token", "", "API token")
+
+	return func(ctx context.Context, c *cli, args []string) error {
+		if len(args) != 1 {
+			return errUsage
+		}
+		p := c.cfg.Profiles[args[0]]
+		fs.Visit(func(f *flag.Flag) {
+			switch f.Name {
+			case "server":
+				p.Server = *server
+			case "token":
+				p.Token = *token
+			}
+		})
+		c.cfg.Profiles[args[0]] = p
+		if c.cfg.Current == "" {
+			c.cfg.Current = args[0]
+		}
+		return c.cfg.Save(c.cfgPath)
+	}
+}
+
+var profileDelete = noFlags(func(ctx context.Context, c *cli, args []string) error {
+	if len(args) != 1 {
+		return errUsage
+	}
+	if _, ok := c.cfg.Profiles[args[0]]; !ok {
+		return fmt.Errorf("profile %q not found", args[0])
+	}
+	delete(c.cfg.Profiles, args[0])
+	if c.cfg.Current == args[0] {
+		c.cfg.Current = ""
+	}
+	return c.cfg.Save(c.cfgPath)
+})
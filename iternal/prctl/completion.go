@@ -0,0 +1,102 @@
+package prctl
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// valueFlags are the global flags that take a value, so completion can
+// skip the value when looking for the command.
+const valueFlags = "-config|--config|-profile|--profile|-server|--server|-token|--token|-o|--o|-timeout|--timeout"
+
+func completion(shell string) func(*flag.FlagSet) runFunc {
+	return noFlags(func(ctx context.Context, c *cli, args []string) error {
+		if len(args) != 0 {
+			return errUsage
+		}
+		var script string
+		switch shell {
+		case "bash":
+			script = bashCompletion()
+		case "zsh":
+			script = "#compdef prctl\nautoload -U +X bashcompinit && bashcompinit\n" + bashCompletion()
+		case "fish":
+			script = fishCompletion()
+		}
+		_, err := fmt.Fprint(c.stdout, script)
+		return err
+	})
+}
+
+// bashCompletion completes commands, subcommands and, for the profile
+// commands, the names of the configured profiles.
+func bashCompletion() string {
+	var b strings.Builder
+	b.WriteString(`# prctl bash completion: source <(prctl completion bash)
+_prctl() {
+    local cur=${COMP_WORDS[COMP_CWORD]} prev=${COMP_WORDS[COMP_CWORD-1]}
+    local i cmd="" sub=""
+    case $prev in
+        ` + valueFlags + `) return ;;
+    esac
+    for ((i = 1; i < COMP_CWORD; i++)); do
+        case ${COMP_WORDS[i]} in
+            ` + valueFlags + `) ((i++)) ;;
+            -*) ;;
+            *)
+                if [[ -z $cmd ]]; then cmd=${COMP_WORDS[i]}
+                elif [[ -z $sub ]]; then sub=${COMP_WORDS[i]}
+                fi ;;
+        esac
+    done
+    if [[ -z $cmd ]]; then
+        COMPREPLY=($(compgen -W "`)
+	b.WriteString(strings.Join(sortedKeys(groups), " "))
+	b.WriteString(`" -- "$cur"))
+        return
+    fi
+    if [[ -z $sub ]]; then
+        case $cmd in
+`)
+	for _, name := range sortedKeys(groups) {
+		fmt.Fprintf(&b, "            %s) COMPREPLY=($(compgen -W \"%s\" -- \"$cur\")) ;;\n", name, strings.Join(sortedKeys(groups[name]), " "))
+	}
+	b.WriteString(`        esac
+        return
+    fi
+    if [[ $cmd == profile && $sub =~ ^(use|set|delete)$ ]]; then
+        COMPREPLY=($(compgen -W "$(prctl profile list 2>/dev/null | awk 'NR > 1 { print $2 }')" -- "$cur"))
+    fi
+}
+complete -F _prctl prctl
+`)
+	return b.String()
+}
+
+func fishCompletion() string {
+	var b strings.Builder
+	b.WriteString("# prctl fish completion: prctl completion fish | source\n")
+	b.WriteString("complete -c prctl -f\n")
+	fmt.Fprintf(&b, "complete -c prctl -n __fish_use_subcommand -a %q\n", strings.Join(sortedKeys(groups), " "))
+	for _, name := range sortedKeys(groups) {
+		for _, sub := range sortedKeys(groups[name]) {
+			fmt.Fprintf(&b, "complete -c prctl -n '__fish_seen_subcommand_from %s; and not __fish_seen_subcommand_from %s' -a %s -d %q\n",
+				name, strings.Join(sortedKeys(groups[name]), " "), sub, groups[name][sub].summary)
+		}
+	}
+	b.WriteString("complete -c prctl -n '__fish_seen_subcommand_from use set delete; and __fish_seen_subcommand_from profile' " +
+		"-a \"(prctl profile list 2>/dev/null | awk 'NR > 1 { print \\$2 }')\"\n")
+	for _, f := range []struct{ name, desc string }{
+		{"config", "path to the profiles file"},
+		{"profile", "profile to use"},
+		{"server", "API base URL"},
+		{"token", "API token"},
+		{"o", "output format"},
+		{"timeout", "request timeout"},
+	} {
+		fmt.Fprintf(&b, "complete -c prctl -o %s -r -d %q\n", f.name, f.desc)
+	}
+	return b.String()
+}
@@ -0,0 +1,99 @@
+package prctl
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/chimort/avito_test_task/client"
+)
+
+func (c *cli) printJSON(v any) error {
+	enc := json.NewEncoder(c.stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// table writes aligned columns; fill calls row once per line.
+func (c *cli) table(header []string, fill func(row func(...any))) error {
+	w := tabwriter.NewWriter(c.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+	fill(func(values ...any) {
+		cells := make([]string, len(values))
+		for i, v := range values {
+			cells[i] = cell(v)
+		}
+		fmt.Fprintln(w, strings.Join(cells, "\t"))
+	})
+	return w.Flush()
+}
+
+func cell(v any) string {
+	switch v := v.(type) {
+	case *time.Time:
+		if v == nil {
+			return "-"
+		}
+		return v.Local().Format(time.DateTime)
+	case time.Time:
+		return v.Local().Format(time.DateTime)
+	case []string:
+		if len(v) == 0 {
+			return "-"
+		}
+		return strings.Join(v, ",")
+	case string:
+		if v == "" {
+			return "-"
+		}
+		return v
+	}
+	return fmt.Sprint(v)
+}
+
+func (c *cli) printTeam(team *client.Team) error {
+	if c.output == "json" {
+		return c.printJSON(team)
+	}
+	fmt.Fprintf(c.stdout, "team %s\n", team.TeamName)
+	return c.table([]string{"USER_ID", "USERNAME", "ACTIVE", "ROLE"}, func(row func(...any)) {
+		for _, m := range team.Members {
+			role := string(client.Member)
+			if m.Role != nil {
+				role = string(*m.Role)
+			}
+			row(m.UserId, m.Username, m.IsActive, role)
+		}
+	})
+}
+
+func (c *cli) printUser(user *client.User) error {
+	if c.output == "json" {
+		return c.printJSON(user)
+	}
+	return c.table([]string{"USER_ID", "USERNAME", "TEAM", "ACTIVE"}, func(row func(...any)) {
+		row(user.UserId, user.Username, user.TeamName, user.IsActive)
+	})
+}
+
+func (c *cli) printPullRequest(pr *client.PullRequest) error {
+	if c.output == "json" {
+		return c.printJSON(pr)
+	}
+	return c.table([]string{"ID", "TITLE", "AUTHOR", "STATUS", "REVIEWERS", "CREATED", "MERGED"}, func(row func(...any)) {
+		row(pr.PullRequestId, pr.PullRequestName, pr.AuthorId, string(pr.Status), pr.AssignedReviewers, pr.CreatedAt, pr.MergedAt)
+	})
+}
+
+func (c *cli) printReviews(reviews []client.ReviewAssignment) error {
+	if c.output == "json" {
+		return c.printJSON(reviews)
+	}
+	return c.table([]string{"ID", "TITLE", "AUTHOR", "STATUS", "CREATED", "ASSIGNED"}, func(row func(...any)) {
+		for _, r := range reviews {
+			row(r.PullRequestId, r.PullRequestName, r.AuthorId, string(r.Status), r.CreatedAt, r.AssignedAt)
+		}
+	})
+}
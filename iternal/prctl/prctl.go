@@ -0,0 +1,195 @@
+// Package prctl implements the prctl command-line client of the PR
+// reviewer service. It talks to the HTTP API through the client generated
+// from openapi.yml.
+package prctl
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/chimort/avito_test_task/client"
+)
+
+// Environment variables that override the selected profile.
+const (
+	EnvProfile = "PRCTL_PROFILE"
+	EnvServer  = "PRCTL_SERVER"
+	EnvToken   = "PRCTL_TOKEN"
+)
+
+// errUsage makes Run print the usage of the command and exit with 2.
+var errUsage = errors.New("usage")
+
+type cli struct {
+	stdout  io.Writer
+	stderr  io.Writer
+	output  string
+	cfgPath string
+	cfg     *Config
+	profile string
+	server  string
+	token   string
+	timeout time.Duration
+}
+
+// Run executes prctl with args (without the program name) and returns the
+// exit code.
+func Run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	c := &cli{stdout: stdout, stderr: stderr}
+
+	fs := flag.NewFlagSet("prctl", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&c.cfgPath, "config", DefaultConfigPath(), "path to the profiles file")
+	fs.StringVar(&c.profile, "profile", os.Getenv(EnvProfile), "profile to use instead of the current one")
+	fs.StringVar(&c.server, "server", os.Getenv(EnvServer), "API base URL, overrides the profile")
+	fs.StringVar(&c.token, "token", os.Getenv(EnvToken), "API token, overrides the profile")
+	fs.StringVar(&c.output, "o", "table", "output format: table or json")
+	fs.DurationVar(&c.timeout, "timeout", 30*time.Second, "request timeout")
+	fs.Usage = func() { c.usage(fs) }
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if c.output != "table" && c.output != "json" {
+		fmt.Fprintf(stderr, "unknown output format %q, use table or json\n", c.output)
+		return 2
+	}
+
+	rest := fs.Args()
+	if len(rest) == 0 {
+		c.usage(fs)
+		return 2
+	}
+	group, ok := groups[rest[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown command %q\n", rest[0])
+		c.usage(fs)
+		return 2
+	}
+	if len(rest) < 2 {
+		c.groupUsage(rest[0], group)
+		return 2
+	}
+	cmd, ok := group[rest[1]]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown command %q\n", strings.Join(rest[:2], " "))
+		c.groupUsage(rest[0], group)
+		return 2
+	}
+
+	cfs := flag.NewFlagSet("prctl "+rest[0]+" "+rest[1], flag.ContinueOnError)
+	cfs.SetOutput(stderr)
+	run := cmd.setup(cfs)
+	cfs.Usage = func() { c.commandUsage(rest[0], rest[1], cmd, cfs) }
+	if err := cfs.Parse(rest[2:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	cfg, err := LoadConfig(c.cfgPath)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	c.cfg = cfg
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	err = run(ctx, c, cfs.Args())
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, errUsage):
+		c.commandUsage(rest[0], rest[1], cmd, cfs)
+		return 2
+	}
+	fmt.Fprintln(stderr, "error:", err)
+	return 1
+}
+
+// client builds an API client for the selected profile; flags and
+// environment variables take precedence over it. Errors of the API come
+// back as the typed errors of package client.
+func (c *cli) client() (*client.ClientWithResponses, error) {
+	p, err := c.cfg.Profile(c.profile)
+	if err != nil {
+		return nil, err
+	}
+	server, token := p.Server, p.Token
+	if c.server != "" {
+		server = c.server
+	}
+	if c.token != "" {
+		token = c.token
+	}
+	if server == "" {
+		return nil, fmt.Errorf("no server configured: run `prctl profile set NAME -server URL` or pass -server")
+	}
+
+	cfg := client.Config{
+		Server:     server,
+		HTTPClient: &http.Client{Timeout: c.timeout},
+		UserAgent:  "prctl",
+	}
+	if token != "" {
+		cfg.Auth = client.BearerToken(token)
+	}
+	return client.New(cfg)
+}
+
+func (c *cli) usage(fs *flag.FlagSet) {
+	fmt.Fprintln(c.stderr, "usage: prctl [flags] COMMAND SUBCOMMAND [flags] [args]")
+	fmt.Fprintln(c.stderr, "\ncommands:")
+	w := tabwriter.NewWriter(c.stderr, 0, 4, 2, ' ', 0)
+	for _, name := range sortedKeys(groups) {
+		for _, sub := range sortedKeys(groups[name]) {
+			cmd := groups[name][sub]
+			fmt.Fprintf(w, "  %s\t%s\n", strings.TrimSpace(name+" "+sub+" "+cmd.args), cmd.summary)
+		}
+	}
+	_ = w.Flush()
+	fmt.Fprintln(c.stderr, "\nflags:")
+	fs.PrintDefaults()
+}
+
+func (c *cli) groupUsage(name string, group map[string]command) {
+	fmt.Fprintf(c.stderr, "usage: prctl %s SUBCOMMAND\n\nsubcommands:\n", name)
+	w := tabwriter.NewWriter(c.stderr, 0, 4, 2, ' ', 0)
+	for _, sub := range sortedKeys(group) {
+		cmd := group[sub]
+		fmt.Fprintf(w, "  %s\t%s\n", strings.TrimSpace(sub+" "+cmd.args), cmd.summary)
+	}
+	_ = w.Flush()
+}
+
+func (c *cli) commandUsage(group, sub string, cmd command, fs *flag.FlagSet) {
+	fmt.Fprintf(c.stderr, "usage: prctl %s %s [flags] %s\n\n%s\n", group, sub, cmd.args, cmd.summary)
+	hasFlags := false
+	fs.VisitAll(func(*flag.Flag) { hasFlags = true })
+	if hasFlags {
+		fmt.Fprintln(c.stderr, "\nflags:")
+		fs.PrintDefaults()
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
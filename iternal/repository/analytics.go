@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+)
+
+const mergedInWindow = `
+	pr.status = 'MERGED'
+	and ($1::timestamptz is null or pr.merged_at >= $1)
+	and ($2::timestamptz is null or pr.merged_at < $2)
+`
+
+const cycleTimeByTeamQuery = `
+	select ut.team_name, count(*),
+		percentile_cont(0.5) within group (order by extract(epoch from pr.merged_at - pr.created_at)),
+		percentile_cont(0.9) within group (order by extract(epoch from pr.merged_at - pr.created_at)),
+		percentile_cont(0.99) within group (order by extract(epoch from pr.merged_at - pr.created_at))
+	from pull_requests pr
+	join user_teams ut on ut.user_id = pr.author_id
+	where ` + mergedInWindow + `
+	group by ut.team_name
+	order by ut.team_name`
+
+const cycleTimeByAuthorQuery = `
+	select pr.author_id, count(*),
+		percentile_cont(0.5) within group (order by extract(epoch from pr.merged_at - pr.created_at)),
+		percentile_cont(0.9) within group (order by extract(epoch from pr.merged_at - pr.created_at)),
+		percentile_cont(0.99) within group (order by extract(epoch from pr.merged_at - pr.created_at))
+	from pull_requests pr
+	where ` + mergedInWindow + `
+	group by pr.author_id
+	order by pr.author_id`
+
+const cycleTimeByReviewerQuery = `
+	select prr.reviewer_id, count(*),
+		percentile_cont(0.5) within group (order by extract(epoch from pr.merged_at - prr.assigned_at)),
+		percentile_cont(0.9) within group (order by extract(epoch from pr.merged_at - prr.assigned_at)),
+		percentile_cont(0.99) within group (order by extract(epoch from pr.merged_at - prr.assigned_at))
+	from pr_reviewers prr
+	join pull_requests pr on pr.id = prr.pr_id
+	where ` + mergedInWindow + `
+	group by prr.reviewer_id
+	order by prr.reviewer_id`
+
+const cycleTimeWeeklyQuery = `
+	select date_trunc('week', pr.merged_at) as week_start, count(*),
+		percentile_cont(0.5) within group (order by extract(epoch from pr.merged_at - pr.created_at)),
+		percentile_cont(0.9) within group (order by extract(epoch from pr.merged_at - pr.created_at)),
+		percentile_cont(0.99) within group (order by extract(epoch from pr.merged_at - pr.created_at))
+	from pull_requests pr
+	where ` + mergedInWindow + `
+	group by week_start
+	order by week_start`
+
+func (r *UserRepository) GetCycleTimeReport(ctx context.Context, from, to *time.Time) (*api.CycleTimeReport, error) {
+	var report api.CycleTimeReport
+	var err error
+
+	if report.ByTeam, err = r.cycleTimeStats(ctx, cycleTimeByTeamQuery, from, to); err != nil {
+		return nil, err
+	}
+	if report.ByAuthor, err = r.cycleTimeStats(ctx, cycleTimeByAuthorQuery, from, to); err != nil {
+		return nil, err
+	}
+	if report.ByReviewer, err = r.cycleTimeStats(ctx, cycleTimeByReviewerQuery, from, to); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, cycleTimeWeeklyQuery, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	report.Weekly = []api.CycleTimeBucket{}
+	for rows.Next() {
+		var b api.CycleTimeBucket
+		if err := rows.Scan(&b.WeekStart, &b.Count, &b.P50Seconds, &b.P90Seconds, &b.P99Seconds); err != nil {
+			return nil, err
+		}
+		report.Weekly = append(report.Weekly, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &report, nil
+}
+
+func (r *UserRepository) cycleTimeStats(ctx context.Context, query string, from, to *time.Time) ([]api.CycleTimeStats, error) {
+	rows, err := r.db.QueryContext(ctx, query, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	stats := []api.CycleTimeStats{}
+	for rows.Next() {
+		var s api.CycleTimeStats
+		if err := rows.Scan(&s.Key, &s.Count, &s.P50Seconds, &s.P90Seconds, &s.P99Seconds); err != nil {
+			return nil, err
+		}
+		stats = append(stats, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/lib/pq"
+)
+
+type APIKeyRepo interface {
+	CreateAPIKey(ctx context.Context, key api.ApiKey, keyHash string) (*api.ApiKey, error)
+	ListAPIKeys(ctx context.Context, teamName *string) ([]api.ApiKey, error)
+	RevokeAPIKey(ctx context.Context, id int64, teamName *string) (*api.ApiKey, error)
+	UseAPIKey(ctx context.Context, keyHash string) (*api.ApiKey, error)
+}
+
+type APIKeyRepository struct {
+	db *sql.DB
+}
+
+func NewAPIKeyRepository(db *sql.DB) *APIKeyRepository {
+	return &APIKeyRepository{db: db}
+}
+
+const apiKeyColumns = `id, name, role, user_id, team_name, expires_at, created_at, last_used_at, revoked_at`
+
+func (r *APIKeyRepository) CreateAPIKey(ctx context.Context, key api.ApiKey, keyHash string) (*api.ApiKey, error) {
+	row := r.db.QueryRowContext(ctx,
+		`insert into api_keys (name, key_hash, role, user_id, team_name, expires_at)
+		values ($1, $2, $3, $4, $5, $6)
+		returning `+apiKeyColumns,
+		key.Name, keyHash, string(key.Role), key.UserId, key.TeamName, key.ExpiresAt)
+
+	created, err := scanAPIKey(row)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" {
+			if pqErr.Constraint == "api_keys_team_name_fkey" {
+				return nil, ErrTeamNotFound
+			}
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return created, nil
+}
+
+// ListAPIKeys returns all keys, or only the keys scoped to teamName when it
+// is set.
+func (r *APIKeyRepository) ListAPIKeys(ctx context.Context, teamName *string) ([]api.ApiKey, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`select `+apiKeyColumns+`
+		from api_keys
+		where $1::text is null or team_name = $1
+		order by id`, teamName)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	keys := []api.ApiKey{}
+	for rows.Next() {
+		k, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, *k)
+	}
+	return keys, rows.Err()
+}
+
+// RevokeAPIKey marks the key as revoked. Revoking a revoked key keeps the
+// original revocation time.
+func (r *APIKeyRepository) RevokeAPIKey(ctx context.Context, id int64, teamName *string) (*api.ApiKey, error) {
+	row := r.db.QueryRowContext(ctx,
+		`update api_keys set revoked_at = coalesce(revoked_at, now())
+		where id = $1 and ($2::text is null or team_name = $2)
+		returning `+apiKeyColumns, id, teamName)
+
+	key, err := scanAPIKey(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrAPIKeyNotFound
+	}
+	return key, err
+}
+
+// UseAPIKey looks up an active key by hash and records the time of use.
+func (r *APIKeyRepository) UseAPIKey(ctx context.Context, keyHash string) (*api.ApiKey, error) {
+	row := r.db.QueryRowContext(ctx,
+		`update api_keys set last_used_at = now()
+		where key_hash = $1
+			and revoked_at is null
+			and (expires_at is null or expires_at > now())
+		returning `+apiKeyColumns, keyHash)
+
+	key, err := scanAPIKey(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrAPIKeyNotFound
+	}
+	return key, err
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanAPIKey(row rowScanner) (*api.ApiKey, error) {
+	var (
+		k                           api.ApiKey
+		role                        string
+		userID, teamName            sql.NullString
+		expiresAt, lastUsed, revoke sql.NullTime
+	)
+	if err := row.Scan(&k.Id, &k.Name, &role, &userID, &teamName, &expiresAt, &k.CreatedAt, &lastUsed, &revoke); err != nil {
+		return nil, err
+	}
+	k.Role = api.ApiKeyRole(role)
+	k.UserId = nullString(userID)
+	k.TeamName = nullString(teamName)
+	k.ExpiresAt = nullTime(expiresAt)
+	k.LastUsedAt = nullTime(lastUsed)
+	k.RevokedAt = nullTime(revoke)
+	return &k, nil
+}
+
+func nullString(s sql.NullString) *string {
+	if !s.Valid {
+		return nil
+	}
+	return &s.String
+}
+
+func nullTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// BackupTable is a table in a backup: its columns and the order rows are
+// read in.
+type BackupTable struct {
+	Name    string
+	Columns []string
+	OrderBy string
+}
+
+// BackupTables lists every table a backup holds, in restore order: each
+// table comes after the tables it references. Idempotency keys are
+// short-lived and are not backed up.
+var BackupTables = []BackupTable{
+	{"team", []string{"name"}, "name"},
+	{"users", []string{"id", "name", "is_active", "deleted_at"}, "id"},
+	{"user_teams", []string{"user_id", "team_name", "role"}, "team_name, user_id"},
+	{"pull_requests", []string{"id", "title", "author_id", "status", "created_at", "merged_at"}, "created_at, id"},
+	{"pr_reviewers", []string{"pr_id", "reviewer_id", "assigned_at"}, "pr_id, reviewer_id"},
+	{"pr_reassignments", []string{"id", "pr_id", "old_reviewer_id", "new_reviewer_id", "reassigned_at"}, "id"},
+	{"api_keys", []string{"id", "name", "key_hash", "role", "user_id", "team_name", "expires_at", "created_at", "last_used_at", "revoked_at"}, "id"},
+}
+
+var ErrDatabaseNotEmpty = errors.New("database is not empty")
+var ErrSchemaDirty = errors.New("schema migration is dirty")
+
+// BackupSink is an ExportSink that is told the schema version before the
+// first table.
+type BackupSink interface {
+	ExportSink
+	Begin(schemaVersion uint) error
+}
+
+// RestoreSource yields the rows of a backup, table by table in the order
+// of BackupTables. Next returns io.EOF after the last row.
+type RestoreSource interface {
+	Next() (table string, row map[string]any, err error)
+}
+
+// Backup streams every table of BackupTables to sink from one
+// repeatable-read snapshot, starting with the schema migration version of
+// that snapshot.
+func (r *UserRepository) Backup(ctx context.Context, sink BackupSink) error {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var version uint
+	var dirty bool
+	if err := tx.QueryRowContext(ctx, `select version, dirty from schema_migrations limit 1`).Scan(&version, &dirty); err != nil {
+		return fmt.Errorf("read schema version: %w", err)
+	}
+	if dirty {
+		return fmt.Errorf("%w: version %d", ErrSchemaDirty, version)
+	}
+	if err := sink.Begin(version); err != nil {
+		return err
+	}
+
+	for _, t := range BackupTables {
+		query := fmt.Sprintf("select %s from %s order by %s", strings.Join(t.Columns, ", "), t.Name, t.OrderBy)
+		if err := exportTable(ctx, tx, t.Name, query, sink); err != nil {
+			return fmt.Errorf("backup %s: %w", t.Name, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// seedRowsQuery counts the rows that the seed migration (002_init) did not
+// insert. A freshly migrated database has none.
+const seedRowsQuery = `
+	select (select count(*) from team where name <> 'backend')
+		+ (select count(*) from users where id <> '123')
+		+ (select count(*) from user_teams where (user_id, team_name) <> ('123', 'backend'))
+		+ (select count(*) from pull_requests)
+		+ (select count(*) from pr_reviewers)
+		+ (select count(*) from pr_reassignments)
+		+ (select count(*) from api_keys)`
+
+// Restore loads a backup into a freshly migrated database in one
+// transaction. The seed rows of the migrations are replaced by the
+// backup; any other data makes it fail with ErrDatabaseNotEmpty. Foreign
+// keys are checked row by row, so a row that references a missing one
+// aborts the restore and nothing is committed. It returns the number of
+// rows restored per table.
+func (r *UserRepository) Restore(ctx context.Context, src RestoreSource) (map[string]int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if _, err := tx.ExecContext(ctx, `lock table team, users, user_teams, pull_requests, pr_reviewers, pr_reassignments, api_keys in exclusive mode`); err != nil {
+		return nil, err
+	}
+	var extra int
+	if err := tx.QueryRowContext(ctx, seedRowsQuery).Scan(&extra); err != nil {
+		return nil, err
+	}
+	if extra > 0 {
+		return nil, ErrDatabaseNotEmpty
+	}
+	if _, err := tx.ExecContext(ctx, `delete from user_teams; delete from users; delete from team`); err != nil {
+		return nil, err
+	}
+
+	stmts := map[string]*sql.Stmt{}
+	defer func() {
+		for _, stmt := range stmts {
+			_ = stmt.Close()
+		}
+	}()
+
+	counts := make(map[string]int, len(BackupTables))
+	for {
+		table, row, err := src.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		columns, err := restoreColumns(table, row)
+		if err != nil {
+			return nil, err
+		}
+		key := table + "(" + strings.Join(columns, ",") + ")"
+		stmt, ok := stmts[key]
+		if !ok {
+			stmt, err = tx.PrepareContext(ctx, insertQuery(table, columns))
+			if err != nil {
+				return nil, err
+			}
+			stmts[key] = stmt
+		}
+
+		args := make([]any, len(columns))
+		for i, c := range columns {
+			args[i] = row[c]
+		}
+		if _, err := stmt.ExecContext(ctx, args...); err != nil {
+			return nil, fmt.Errorf("restore %s row %d: %w", table, counts[table]+1, err)
+		}
+		counts[table]++
+	}
+
+	for _, t := range []string{"pr_reassignments", "api_keys"} {
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf(
+			`select setval(pg_get_serial_sequence('%[1]s', 'id'), coalesce(max(id), 0) + 1, false) from %[1]s`, t)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// restoreColumns returns the columns of row in a stable order, checking
+// them against BackupTables since they end up in the insert statement.
+func restoreColumns(table string, row map[string]any) ([]string, error) {
+	i := slices.IndexFunc(BackupTables, func(t BackupTable) bool { return t.Name == table })
+	if i < 0 {
+		return nil, fmt.Errorf("unknown table %q", table)
+	}
+	columns := make([]string, 0, len(row))
+	for c := range row {
+		if !slices.Contains(BackupTables[i].Columns, c) {
+			return nil, fmt.Errorf("unknown column %s.%s", table, c)
+		}
+		columns = append(columns, c)
+	}
+	sort.Strings(columns)
+	return columns, nil
+}
+
+func insertQuery(table string, columns []string) string {
+	quoted := make([]string, len(columns))
+	params := make([]string, len(columns))
+	for i, c := range columns {
+		quoted[i] = pq.QuoteIdentifier(c)
+		params[i] = fmt.Sprintf("$%d", i+1)
+	}
+	return fmt.Sprintf("insert into %s (%s) values (%s)", table, strings.Join(quoted, ", "), strings.Join(params, ", "))
+}
@@ -1,12 +1,30 @@
 package repository
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
 var ErrTeamExists = errors.New("team already exists")
 var ErrTeamNotFound = errors.New("team not found")
 var ErrPRExists = errors.New("PR already exists")
 var ErrUserNotFound = errors.New("user not found")
+var ErrUserExists = errors.New("user already exists")
+var ErrUserHasActivity = errors.New("user authored or reviewed pull requests")
 var ErrPRNotFound = errors.New("PR not found")
 var ErrPRMerged = errors.New("can not ressign on merged pr")
 var ErrReviewerNotAssign = errors.New("no is not assigned")
 var ErrNoCandidates = errors.New("no active replacement candidates")
+var ErrAPIKeyNotFound = errors.New("api key not found")
+var ErrIdempotencyConflict = errors.New("idempotency key is in use")
+
+// ReassignLimitError is returned when a pull request was reassigned too
+// often within the last hour.
+type ReassignLimitError struct {
+	RetryAfter time.Duration
+}
+
+func (e *ReassignLimitError) Error() string {
+	return fmt.Sprintf("reassignment limit reached, retry after %s", e.RetryAfter.Round(time.Second))
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// exportBatch is how many rows each FETCH reads from an export cursor.
+const exportBatch = 500
+
+// ExportSink receives the exported tables one after another: Table once
+// with the column names, then Row for every row of that table.
+type ExportSink interface {
+	Table(name string, columns []string) error
+	Row(values []any) error
+}
+
+// ExportTables lists the exported tables in the order they are written, so
+// that every row comes after the rows it references.
+var ExportTables = []string{"team", "users", "user_teams", "pull_requests", "pr_reviewers"}
+
+// Export streams the tables to sink from one repeatable-read snapshot.
+// Pull requests are limited to those created in [from, to), reviews to
+// those of the exported pull requests; the other tables are exported in
+// full. Each table is read through a server-side cursor in batches of
+// exportBatch rows.
+func (r *UserRepository) Export(ctx context.Context, from, to *time.Time, sink ExportSink) error {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	window := createdInWindow(from, to)
+	queries := map[string]string{
+		"team":       `select name from team order by name`,
+		"users":      `select id, name, is_active, deleted_at from users order by id`,
+		"user_teams": `select user_id, team_name, role from user_teams order by team_name, user_id`,
+		"pull_requests": `
+			select pr.id, pr.title, pr.author_id, pr.status, pr.created_at, pr.merged_at
+			from pull_requests pr
+			where ` + window + `
+			order by pr.created_at, pr.id`,
+		"pr_reviewers": `
+			select prr.pr_id, prr.reviewer_id, prr.assigned_at
+			from pr_reviewers prr
+			join pull_requests pr on pr.id = prr.pr_id
+			where ` + window + `
+			order by prr.pr_id, prr.reviewer_id`,
+	}
+
+	for _, table := range ExportTables {
+		if err := exportTable(ctx, tx, table, queries[table], sink); err != nil {
+			return fmt.Errorf("export %s: %w", table, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// createdInWindow filters pr by created_at. DECLARE cannot take bind
+// parameters, so the bounds are inlined as quoted literals.
+func createdInWindow(from, to *time.Time) string {
+	cond := "true"
+	if from != nil {
+		cond += " and pr.created_at >= " + pq.QuoteLiteral(from.UTC().Format(time.RFC3339Nano)) + "::timestamptz"
+	}
+	if to != nil {
+		cond += " and pr.created_at < " + pq.QuoteLiteral(to.UTC().Format(time.RFC3339Nano)) + "::timestamptz"
+	}
+	return cond
+}
+
+func exportTable(ctx context.Context, tx *sql.Tx, table, query string, sink ExportSink) error {
+	cursor := "export_" + table
+	if _, err := tx.ExecContext(ctx, "declare "+cursor+" no scroll cursor for "+query); err != nil {
+		return err
+	}
+
+	fetch := fmt.Sprintf("fetch forward %d from %s", exportBatch, cursor)
+	started := false
+	for {
+		n, err := fetchBatch(ctx, tx, fetch, func(columns []string) error {
+			if started {
+				return nil
+			}
+			started = true
+			return sink.Table(table, columns)
+		}, sink)
+		if err != nil {
+			return err
+		}
+		if n < exportBatch {
+			break
+		}
+	}
+
+	_, err := tx.ExecContext(ctx, "close "+cursor)
+	return err
+}
+
+// fetchBatch runs one FETCH, passes the column names to header and every
+// row to sink, and returns the number of rows read.
+func fetchBatch(ctx context.Context, tx *sql.Tx, fetch string, header func([]string) error, sink ExportSink) (int, error) {
+	rows, err := tx.QueryContext(ctx, fetch)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+	if err := header(columns); err != nil {
+		return 0, err
+	}
+
+	values := make([]any, len(columns))
+	dest := make([]any, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	n := 0
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return n, err
+		}
+		if err := sink.Row(values); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, rows.Err()
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// IdempotencyRecord is a stored request. StatusCode is zero while the first
+// request is still being processed; LockedUntil bounds how long that request
+// may hold the key.
+type IdempotencyRecord struct {
+	Scope       string
+	Key         string
+	RequestHash string
+	StatusCode  int
+	ContentType string
+	Body        []byte
+	ExpiresAt   time.Time
+	LockedUntil time.Time
+}
+
+type IdempotencyRepository struct {
+	db *sql.DB
+}
+
+func NewIdempotencyRepository(db *sql.DB) *IdempotencyRepository {
+	return &IdempotencyRepository{db: db}
+}
+
+// Reserve claims rec.Key for a new request. When the key is already taken
+// it returns the stored record and false. A reservation whose lease has run
+// out without a response, e.g. after a crash, is taken over.
+func (r *IdempotencyRepository) Reserve(ctx context.Context, rec IdempotencyRecord) (*IdempotencyRecord, bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	_, err = tx.ExecContext(ctx,
+		`delete from idempotency_keys
+		where scope = $1 and key = $2
+		and (expires_at <= now() or (status_code is null and locked_until <= now()))`,
+		rec.Scope, rec.Key)
+	if err != nil {
+		return nil, false, err
+	}
+
+	res, err := tx.ExecContext(ctx,
+		`insert into idempotency_keys (scope, key, request_hash, expires_at, locked_until)
+		values ($1, $2, $3, $4, $5)
+		on conflict do nothing`,
+		rec.Scope, rec.Key, rec.RequestHash, rec.ExpiresAt, rec.LockedUntil)
+	if err != nil {
+		return nil, false, err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return nil, false, err
+	} else if n == 1 {
+		return &rec, true, tx.Commit()
+	}
+
+	var (
+		stored      IdempotencyRecord
+		status      sql.NullInt64
+		contentType sql.NullString
+	)
+	err = tx.QueryRowContext(ctx,
+		`select scope, key, request_hash, status_code, content_type, response_body, expires_at
+		from idempotency_keys
+		where scope = $1 and key = $2`, rec.Scope, rec.Key).Scan(
+		&stored.Scope, &stored.Key, &stored.RequestHash, &status, &contentType, &stored.Body, &stored.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// The other request released the key between our insert and
+			// select; the caller may simply retry.
+			return nil, false, ErrIdempotencyConflict
+		}
+		return nil, false, err
+	}
+	stored.StatusCode = int(status.Int64)
+	stored.ContentType = contentType.String
+	return &stored, false, tx.Commit()
+}
+
+// Complete stores the response of a reserved request.
+func (r *IdempotencyRepository) Complete(ctx context.Context, scope, key string, status int, contentType string, body []byte) error {
+	_, err := r.db.ExecContext(ctx,
+		`update idempotency_keys
+		set status_code = $3, content_type = $4, response_body = $5
+		where scope = $1 and key = $2`,
+		scope, key, status, contentType, body)
+	return err
+}
+
+// Release drops a reservation so the request can be retried.
+func (r *IdempotencyRepository) Release(ctx context.Context, scope, key string) error {
+	_, err := r.db.ExecContext(ctx,
+		`delete from idempotency_keys where scope = $1 and key = $2 and status_code is null`,
+		scope, key)
+	return err
+}
+
+func (r *IdempotencyRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	res, err := r.db.ExecContext(ctx, `delete from idempotency_keys where expires_at <= now()`)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
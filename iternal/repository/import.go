@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/lib/pq"
+)
+
+// ImportTeams creates the missing teams, upserts every user and adds the
+// memberships in one transaction, with one statement per table. Existing
+// memberships and roles are left as they are.
+func (r *UserRepository) ImportTeams(ctx context.Context, teams []api.Team) (*api.ImportResult, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	names := make([]string, len(teams))
+	var teamNames []string
+	var members []api.TeamMember
+	for i, team := range teams {
+		names[i] = team.TeamName
+		for _, member := range team.Members {
+			teamNames = append(teamNames, team.TeamName)
+			members = append(members, member)
+		}
+	}
+
+	res, err := tx.ExecContext(ctx,
+		`insert into team (name) select unnest($1::text[]) on conflict do nothing`, pq.Array(names))
+	if err != nil {
+		return nil, err
+	}
+	created, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := upsertUsers(ctx, tx, members, true); err != nil {
+		return nil, err
+	}
+	added, err := insertMemberships(ctx, tx, teamNames, members)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	users := make(map[string]bool, len(members))
+	for _, member := range members {
+		users[member.UserId] = true
+	}
+	return &api.ImportResult{
+		Teams:        len(teams),
+		TeamsCreated: int(created),
+		Users:        len(users),
+		MembersAdded: int(added),
+	}, nil
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/lib/pq"
+)
+
+// PullRequestFilter narrows ListPullRequests. Nil fields are not applied;
+// TeamName matches the author's teams and Title is a case-insensitive
+// substring.
+type PullRequestFilter struct {
+	AuthorID      *string
+	TeamName      *string
+	Status        *string
+	ReviewerID    *string
+	Title         *string
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	Ascending     bool
+	Limit         int
+	After         *PageCursor
+}
+
+const pullRequestColumns = `
+	pr.id, pr.title, pr.author_id, pr.status, pr.created_at, pr.merged_at,
+	array(select prr.reviewer_id from pr_reviewers prr where prr.pr_id = pr.id order by prr.reviewer_id)`
+
+const listPullRequestsQuery = `
+	select ` + pullRequestColumns + `
+	from pull_requests pr
+	where ($1::text is null or pr.author_id = $1)
+	and ($2::text is null or exists(
+		select 1 from user_teams ut where ut.user_id = pr.author_id and ut.team_name = $2))
+	and ($3::text is null or pr.status = $3)
+	and ($4::text is null or exists(
+		select 1 from pr_reviewers prr where prr.pr_id = pr.id and prr.reviewer_id = $4))
+	and ($5::text is null or pr.title ilike $5)
+	and ($6::timestamptz is null or pr.created_at >= $6)
+	and ($7::timestamptz is null or pr.created_at < $7)
+	and ($8::timestamptz is null or (pr.created_at, pr.id) %s ($8, $9))
+	order by pr.created_at %s, pr.id %s
+	limit $10`
+
+func (r *UserRepository) GetPullRequest(ctx context.Context, pullRequestId string) (*api.PullRequest, error) {
+	row := r.db.QueryRowContext(ctx,
+		`select `+pullRequestColumns+` from pull_requests pr where pr.id = $1`, pullRequestId)
+	pr, err := scanPullRequest(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrPRNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return pr, nil
+}
+
+func (r *UserRepository) ListPullRequests(ctx context.Context, filter PullRequestFilter) ([]api.PullRequest, error) {
+	cmp, dir := "<", "desc"
+	if filter.Ascending {
+		cmp, dir = ">", "asc"
+	}
+	var title *string
+	if filter.Title != nil {
+		pattern := "%" + escapeLike(*filter.Title) + "%"
+		title = &pattern
+	}
+	var afterAt *time.Time
+	var afterID *string
+	if filter.After != nil {
+		afterAt, afterID = &filter.After.CreatedAt, &filter.After.ID
+	}
+
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(listPullRequestsQuery, cmp, dir, dir),
+		filter.AuthorID, filter.TeamName, filter.Status, filter.ReviewerID, title,
+		filter.CreatedAfter, filter.CreatedBefore, afterAt, afterID, filter.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	prs := []api.PullRequest{}
+	for rows.Next() {
+		pr, err := scanPullRequest(rows)
+		if err != nil {
+			return nil, err
+		}
+		prs = append(prs, *pr)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return prs, nil
+}
+
+func scanPullRequest(row rowScanner) (*api.PullRequest, error) {
+	var pr api.PullRequest
+	var reviewers pq.StringArray
+	if err := row.Scan(&pr.PullRequestId, &pr.PullRequestName, &pr.AuthorId, &pr.Status,
+		&pr.CreatedAt, &pr.MergedAt, &reviewers); err != nil {
+		return nil, err
+	}
+	pr.AssignedReviewers = []string(reviewers)
+	return &pr, nil
+}
+
+// escapeLike quotes the LIKE wildcards so s matches literally.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/chimort/avito_test_task/iternal/api"
@@ -14,10 +15,41 @@ type UserRepo interface {
 	UpdateActive(ctx context.Context, userID string, isActive bool) (*api.User, error)
 	TeamAdd(ctx context.Context, teamName string, teamMembers []api.TeamMember) (*api.Team, error)
 	GetTeam(ctx context.Context, teamName string) (*api.Team, error)
-	PullRequestCreate(ctx context.Context, pullRequestId string, pullRequestName string, authorId string) (*api.PullRequest, error)
-	PullRequestMerge(ctx context.Context, pullRequestId string) (*api.PullRequest, error)
-	PullRequestReassign(ctx context.Context, pullRequestId string, oldUserId string) (*api.PullRequest, string, error)
-	GetPRsByReviewer(ctx context.Context, reviewerId string) ([]*api.PullRequestShort, error)
+	AddMembers(ctx context.Context, teamName string, teamMembers []api.TeamMember) (*api.Team, error)
+	RemoveMembers(ctx context.Context, teamName string, userIDs []string) (*MembersRemoval, error)
+	RenameTeam(ctx context.Context, teamName, newTeamName string) (*api.Team, error)
+	DeleteTeam(ctx context.Context, teamName string) error
+	SyncTeams(ctx context.Context, teams []api.Team, dryRun bool) ([]api.TeamSyncDiff, error)
+	ImportTeams(ctx context.Context, teams []api.Team) (*api.ImportResult, error)
+	CreateTeam(ctx context.Context, teamName string, userIDs []string) (*api.Team, error)
+	AddTeamUsers(ctx context.Context, teamName string, userIDs []string) (*api.Team, error)
+	PatchTeam(ctx context.Context, teamName, newTeamName string, add, remove []string) (*MembersRemoval, error)
+	ListTeams(ctx context.Context, filter TeamFilter) ([]api.Team, error)
+	CountTeams(ctx context.Context, filter TeamFilter) (int, error)
+	PullRequestCreate(ctx context.Context, pullRequestId string, pullRequestName string, authorId string, reviewersCount int) (*api.PullRequest, error)
+	PullRequestMerge(ctx context.Context, pullRequestId string) (*api.PullRequest, bool, error)
+	PullRequestReassign(ctx context.Context, pullRequestId string, oldUserId string, maxPerHour int) (*api.PullRequest, string, error)
+	GetPullRequest(ctx context.Context, pullRequestId string) (*api.PullRequest, error)
+	ListPullRequests(ctx context.Context, filter PullRequestFilter) ([]api.PullRequest, error)
+	GetUser(ctx context.Context, userID string) (*api.User, error)
+	UpdateUser(ctx context.Context, userID string, username *string, isActive *bool) (*api.User, error)
+	ListUsers(ctx context.Context, filter UserFilter) ([]api.User, error)
+	CountUsers(ctx context.Context, filter UserFilter) (int, error)
+	CreateUser(ctx context.Context, userID, username string, isActive bool) (*api.User, error)
+	DeleteUser(ctx context.Context, userID string, mode DeleteMode) (*UserDeletion, error)
+	GetPRsByReviewer(ctx context.Context, reviewerId string, filter ReviewFilter) ([]api.ReviewAssignment, error)
+	GetReviewerStats(ctx context.Context, from, to *time.Time) ([]api.ReviewerStats, error)
+	GetTeamStats(ctx context.Context, from, to *time.Time) ([]api.TeamStats, error)
+	GetCycleTimeReport(ctx context.Context, from, to *time.Time) (*api.CycleTimeReport, error)
+	Export(ctx context.Context, from, to *time.Time, sink ExportSink) error
+	Backup(ctx context.Context, sink BackupSink) error
+	Restore(ctx context.Context, src RestoreSource) (map[string]int, error)
+	UserInTeam(ctx context.Context, userID, teamName string) (bool, error)
+	PullRequestInTeam(ctx context.Context, pullRequestId, teamName string) (bool, error)
+	IsTeamLead(ctx context.Context, userID, teamName string) (bool, error)
+	UsersOutsideTeam(ctx context.Context, teamName string, userIDs []string) ([]string, error)
+	LeadsUser(ctx context.Context, leadID, userID string) (bool, error)
+	LeadsPullRequest(ctx context.Context, leadID, pullRequestId string) (bool, error)
 }
 
 type UserRepository struct {
@@ -45,23 +77,16 @@ func (r *UserRepository) TeamAdd(ctx context.Context, teamName string, teamMembe
 		return nil, err
 	}
 
-	for _, member := range teamMembers {
-		_, err := tx.ExecContext(ctx,
-			`insert into users (id, name, is_active) values ($1, $2, $3)
-		on conflict (id) do update set is_active = EXCLUDED.is_active`,
-			member.UserId, member.Username, member.IsActive)
-		if err != nil {
-			return nil, err
-		}
+	if err := upsertUsers(ctx, tx, teamMembers, true); err != nil {
+		return nil, err
 	}
 
-	for _, member := range teamMembers {
-		_, err := tx.ExecContext(ctx,
-			`insert into user_teams (user_id, team_name) values ($1, $2)
-		on conflict do nothing`, member.UserId, teamName)
-		if err != nil {
-			return nil, err
-		}
+	teams := make([]string, len(teamMembers))
+	for i := range teams {
+		teams[i] = teamName
+	}
+	if _, err := insertMemberships(ctx, tx, teams, teamMembers); err != nil {
+		return nil, err
 	}
 
 	if err := tx.Commit(); err != nil {
@@ -73,9 +98,82 @@ func (r *UserRepository) TeamAdd(ctx context.Context, teamName string, teamMembe
 	}, nil
 }
 
+func memberRole(m api.TeamMember) string {
+	if m.Role == nil {
+		return string(api.Member)
+	}
+	return string(*m.Role)
+}
+
+// upsertUsersQuery creates missing users. Existing ones take is_active from
+// %s: EXCLUDED to apply the new value, users to keep the current one.
+// Deleted users are skipped.
+const upsertUsersQuery = `insert into users (id, name, is_active)
+		select * from unnest($1::text[], $2::text[], $3::boolean[])
+		on conflict (id) do update set is_active = %s.is_active
+		where users.deleted_at is null`
+
+// upsertUsers creates missing users in a single statement. With setActive
+// existing users get is_active from teamMembers, otherwise they are left as
+// they are. When a user is listed twice the last entry wins. Deleted users
+// are not brought back; any of them fails with ErrUserNotFound.
+func upsertUsers(ctx context.Context, tx *sql.Tx, teamMembers []api.TeamMember, setActive bool) error {
+	index := make(map[string]int, len(teamMembers))
+	var ids, names []string
+	var active []bool
+	for _, member := range teamMembers {
+		if i, ok := index[member.UserId]; ok {
+			names[i], active[i] = member.Username, member.IsActive
+			continue
+		}
+		index[member.UserId] = len(ids)
+		ids = append(ids, member.UserId)
+		names = append(names, member.Username)
+		active = append(active, member.IsActive)
+	}
+
+	source := "users"
+	if setActive {
+		source = "EXCLUDED"
+	}
+	res, err := tx.ExecContext(ctx, fmt.Sprintf(upsertUsersQuery, source),
+		pq.Array(ids), pq.Array(names), pq.Array(active))
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n != int64(len(ids)) {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+// insertMemberships adds teamMembers[i] to teams[i] with its role, keeping
+// memberships that already exist. It returns how many were added.
+func insertMemberships(ctx context.Context, tx *sql.Tx, teams []string, teamMembers []api.TeamMember) (int64, error) {
+	ids := make([]string, len(teamMembers))
+	roles := make([]string, len(teamMembers))
+	for i, member := range teamMembers {
+		ids[i], roles[i] = member.UserId, memberRole(member)
+	}
+
+	res, err := tx.ExecContext(ctx,
+		`insert into user_teams (user_id, team_name, role)
+		select * from unnest($1::text[], $2::text[], $3::text[])
+		on conflict do nothing`,
+		pq.Array(ids), pq.Array(teams), pq.Array(roles))
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
 func (r *UserRepository) GetTeam(ctx context.Context, teamName string) (*api.Team, error) {
 	query := `
-	select u.id, u.name, u.is_active
+	select u.id, u.name, u.is_active, ut.role
 	from user_teams ut
 	join users u on ut.user_id = u.id
 	where ut.team_name = $1
@@ -90,9 +188,11 @@ func (r *UserRepository) GetTeam(ctx context.Context, teamName string) (*api.Tea
 	var members []api.TeamMember
 	for rows.Next() {
 		var m api.TeamMember
-		if err := rows.Scan(&m.UserId, &m.Username, &m.IsActive); err != nil {
+		var role api.TeamMemberRole
+		if err := rows.Scan(&m.UserId, &m.Username, &m.IsActive, &role); err != nil {
 			return nil, err
 		}
+		m.Role = &role
 		members = append(members, m)
 	}
 	if len(members) == 0 {
@@ -107,6 +207,14 @@ func (r *UserRepository) GetTeam(ctx context.Context, teamName string) (*api.Tea
 }
 
 func (r *UserRepository) UpdateActive(ctx context.Context, userID string, isActive bool) (*api.User, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
 
 	var user api.User
 
@@ -118,10 +226,10 @@ func (r *UserRepository) UpdateActive(ctx context.Context, userID string, isActi
 			COALESCE(ut.team_name, '')
 		FROM users u
 		LEFT JOIN user_teams ut ON ut.user_id = u.id
-		WHERE u.id = $1
+		WHERE u.id = $1 AND u.deleted_at IS NULL
 	`
 
-	err := r.db.QueryRowContext(ctx, query, userID).Scan(
+	err = tx.QueryRowContext(ctx, query, userID).Scan(
 		&user.UserId,
 		&user.Username,
 		&user.IsActive,
@@ -129,13 +237,15 @@ func (r *UserRepository) UpdateActive(ctx context.Context, userID string, isActi
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, sql.ErrNoRows
+			return nil, ErrUserNotFound
 		}
 		return nil, err
 	}
 
-	_, err = r.db.ExecContext(ctx, `UPDATE users SET is_active = $1 WHERE id = $2`, isActive, userID)
-	if err != nil {
+	if err := setActive(ctx, tx, userID, isActive); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
 		return nil, err
 	}
 
@@ -144,7 +254,23 @@ func (r *UserRepository) UpdateActive(ctx context.Context, userID string, isActi
 	return &user, nil
 }
 
-func (r *UserRepository) PullRequestCreate(ctx context.Context, pullRequestId string, pullRequestName string, authorId string) (*api.PullRequest, error) {
+// setActive activates or deactivates one user. UpdateActive and UpdateUser
+// both change the flag here. The user may have been deleted since it was
+// looked up, so a deleted user is not found.
+func setActive(ctx context.Context, tx *sql.Tx, userID string, isActive bool) error {
+	res, err := tx.ExecContext(ctx, `UPDATE users SET is_active = $1 WHERE id = $2 AND deleted_at IS NULL`, isActive, userID)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+func (r *UserRepository) PullRequestCreate(ctx context.Context, pullRequestId string, pullRequestName string, authorId string, reviewersCount int) (*api.PullRequest, error) {
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, err
@@ -156,7 +282,7 @@ func (r *UserRepository) PullRequestCreate(ctx context.Context, pullRequestId st
 
 	res, err := tx.ExecContext(ctx,
 		`insert into pull_requests (id, title, author_id)
-		select $1, $2, id from users where id = $3`,
+		select $1, $2, id from users where id = $3 and deleted_at is null`,
 		pullRequestId, pullRequestName, authorId)
 
 	if err != nil {
@@ -180,10 +306,10 @@ func (r *UserRepository) PullRequestCreate(ctx context.Context, pullRequestId st
 		join user_teams ut on u.id = ut.user_id
 		where ut.team_name = (
 		select team_name from user_teams where user_id = $1 limit 1
-		) and u.id <> $1 and u.is_active = true
+		) and u.id <> $1 and u.is_active = true and u.deleted_at is null
 		order by random()
-		limit 2`,
-		authorId)
+		limit $2`,
+		authorId, reviewersCount)
 
 	if err != nil {
 		return nil, err
@@ -225,10 +351,12 @@ func (r *UserRepository) PullRequestCreate(ctx context.Context, pullRequestId st
 	return pr, nil
 }
 
-func (r *UserRepository) PullRequestMerge(ctx context.Context, pullRequestId string) (*api.PullRequest, error) {
+// PullRequestMerge marks the pull request as merged. An already merged PR
+// is returned unchanged; the bool reports whether this call merged it.
+func (r *UserRepository) PullRequestMerge(ctx context.Context, pullRequestId string) (*api.PullRequest, bool, error) {
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 	defer func() {
 		_ = tx.Rollback()
@@ -239,9 +367,9 @@ func (r *UserRepository) PullRequestMerge(ctx context.Context, pullRequestId str
 		`select status from pull_requests where id = $1 for update`, pullRequestId).Scan(&currentStatus)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, ErrPRNotFound
+			return nil, false, ErrPRNotFound
 		}
-		return nil, err
+		return nil, false, err
 	}
 
 	if currentStatus == "MERGED" {
@@ -251,25 +379,25 @@ func (r *UserRepository) PullRequestMerge(ctx context.Context, pullRequestId str
 			pullRequestId,
 		).Scan(&pr.PullRequestId, &pr.PullRequestName, &pr.AuthorId, &pr.Status, &pr.CreatedAt, &pr.MergedAt)
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
 
 		rows, err := tx.QueryContext(ctx,
 			`select reviewer_id from pr_reviewers where pr_id = $1`, pullRequestId)
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
 		defer func() { _ = rows.Close() }()
 
 		for rows.Next() {
 			var id string
 			if err := rows.Scan(&id); err != nil {
-				return nil, err
+				return nil, false, err
 			}
 			pr.AssignedReviewers = append(pr.AssignedReviewers, id)
 		}
 
-		return &pr, nil
+		return &pr, false, nil
 	}
 
 	mergedAt := time.Now()
@@ -277,7 +405,7 @@ func (r *UserRepository) PullRequestMerge(ctx context.Context, pullRequestId str
 		`update pull_requests set status = 'MERGED', merged_at = $2 where id = $1`,
 		pullRequestId, mergedAt)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	var pr api.PullRequest
@@ -286,32 +414,35 @@ func (r *UserRepository) PullRequestMerge(ctx context.Context, pullRequestId str
 		pullRequestId,
 	).Scan(&pr.PullRequestId, &pr.PullRequestName, &pr.AuthorId, &pr.Status, &pr.CreatedAt, &pr.MergedAt)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	rows, err := tx.QueryContext(ctx,
 		`select reviewer_id from pr_reviewers where pr_id = $1`, pullRequestId)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 	defer func() { _ = rows.Close() }()
 
 	for rows.Next() {
 		var id string
 		if err := rows.Scan(&id); err != nil {
-			return nil, err
+			return nil, false, err
 		}
 		pr.AssignedReviewers = append(pr.AssignedReviewers, id)
 	}
 
 	if err := tx.Commit(); err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
-	return &pr, nil
+	return &pr, true, nil
 }
 
-func (r *UserRepository) PullRequestReassign(ctx context.Context, pullRequestId string, oldUserId string) (*api.PullRequest, string, error) {
+// PullRequestReassign replaces oldUserId on the pull request with a random
+// active teammate. With maxPerHour > 0 it fails with ReassignLimitError once
+// the PR was reassigned that many times within the last hour.
+func (r *UserRepository) PullRequestReassign(ctx context.Context, pullRequestId string, oldUserId string, maxPerHour int) (*api.PullRequest, string, error) {
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, "", err
@@ -326,7 +457,8 @@ func (r *UserRepository) PullRequestReassign(ctx context.Context, pullRequestId
 	err = tx.QueryRowContext(ctx,
 		`select author_id, status, title, created_at
 		from pull_requests
-		where id = $1`,
+		where id = $1
+		for update`,
 		pullRequestId,
 	).Scan(&authorId, &status, &pullRequestName, &createdAt)
 
@@ -341,6 +473,12 @@ func (r *UserRepository) PullRequestReassign(ctx context.Context, pullRequestId
 		return nil, "", ErrPRMerged
 	}
 
+	if maxPerHour > 0 {
+		if err := checkReassignLimit(ctx, tx, pullRequestId, maxPerHour); err != nil {
+			return nil, "", err
+		}
+	}
+
 	var reviewerExists int
 	err = tx.QueryRowContext(ctx,
 		`select 1 
@@ -357,26 +495,7 @@ func (r *UserRepository) PullRequestReassign(ctx context.Context, pullRequestId
 	}
 
 	var newReviewer string
-	err = tx.QueryRowContext(ctx,
-		`select u.id
-		from users u
-		join user_teams ut on ut.user_id = u.id
-		where ut.team_name = (
-				select team_name
-				from user_teams
-				where user_id = $2
-				limit 1
-		)
-		and u.is_active = true
-		and u.id <> $2
-		and u.id <> $3
-		and u.id not in (
-				select reviewer_id
-				from pr_reviewers
-				where pr_id = $1
-		)
-		order by random()
-		limit 1`,
+	err = tx.QueryRowContext(ctx, replacementQuery,
 		pullRequestId, oldUserId, authorId,
 	).Scan(&newReviewer)
 
@@ -405,6 +524,15 @@ func (r *UserRepository) PullRequestReassign(ctx context.Context, pullRequestId
 		return nil, "", err
 	}
 
+	_, err = tx.ExecContext(ctx,
+		`insert into pr_reassignments(pr_id, old_reviewer_id, new_reviewer_id)
+		 values ($1, $2, $3)`,
+		pullRequestId, oldUserId, newReviewer,
+	)
+	if err != nil {
+		return nil, "", err
+	}
+
 	rows, err := tx.QueryContext(ctx,
 		`select reviewer_id 
 		 from pr_reviewers 
@@ -442,26 +570,168 @@ func (r *UserRepository) PullRequestReassign(ctx context.Context, pullRequestId
 	return pr, newReviewer, nil
 }
 
-func (r *UserRepository) GetPRsByReviewer(ctx context.Context, reviewerId string) ([]*api.PullRequestShort, error) {
-	rows, err := r.db.QueryContext(ctx,
-		`select pr.id, pr.title, pr.author_id, pr.status
-		 from pull_requests pr
-		 join pr_reviewers prr on prr.pr_id = pr.id
-		 where prr.reviewer_id = $1`, reviewerId)
+// PageCursor is the (created_at, id) of the last row on a page.
+type PageCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// ReviewFilter narrows GetPRsByReviewer. Nil fields are not applied;
+// After resumes right behind the given row in the chosen order.
+type ReviewFilter struct {
+	Status        *string
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	Ascending     bool
+	Limit         int
+	After         *PageCursor
+}
+
+const reviewsByReviewerQuery = `
+	select pr.id, pr.title, pr.author_id, pr.status, pr.created_at, prr.assigned_at
+	from pull_requests pr
+	join pr_reviewers prr on prr.pr_id = pr.id
+	where prr.reviewer_id = $1
+	and ($2::text is null or pr.status = $2)
+	and ($3::timestamptz is null or pr.created_at >= $3)
+	and ($4::timestamptz is null or pr.created_at < $4)
+	and ($5::timestamptz is null or (pr.created_at, pr.id) %s ($5, $6))
+	order by pr.created_at %s, pr.id %s
+	limit $7`
+
+func (r *UserRepository) GetPRsByReviewer(ctx context.Context, reviewerId string, filter ReviewFilter) ([]api.ReviewAssignment, error) {
+	cmp, dir := "<", "desc"
+	if filter.Ascending {
+		cmp, dir = ">", "asc"
+	}
+	var afterAt *time.Time
+	var afterID *string
+	if filter.After != nil {
+		afterAt, afterID = &filter.After.CreatedAt, &filter.After.ID
+	}
+
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(reviewsByReviewerQuery, cmp, dir, dir),
+		reviewerId, filter.Status, filter.CreatedAfter, filter.CreatedBefore, afterAt, afterID, filter.Limit)
 	if err != nil {
 		return nil, err
 	}
 	defer func() { _ = rows.Close() }()
 
-	var prs []*api.PullRequestShort
+	prs := []api.ReviewAssignment{}
 	for rows.Next() {
-		var pr api.PullRequestShort
+		var pr api.ReviewAssignment
 		var status string
-		if err := rows.Scan(&pr.PullRequestId, &pr.PullRequestName, &pr.AuthorId, &status); err != nil {
+		if err := rows.Scan(&pr.PullRequestId, &pr.PullRequestName, &pr.AuthorId, &status, &pr.CreatedAt, &pr.AssignedAt); err != nil {
 			return nil, err
 		}
-		pr.Status = api.PullRequestShortStatus(status)
-		prs = append(prs, &pr)
+		pr.Status = api.ReviewAssignmentStatus(status)
+		prs = append(prs, pr)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
 	return prs, nil
 }
+
+func (r *UserRepository) UserInTeam(ctx context.Context, userID, teamName string) (bool, error) {
+	var ok bool
+	err := r.db.QueryRowContext(ctx,
+		`select exists(select 1 from user_teams where user_id = $1 and team_name = $2)`,
+		userID, teamName).Scan(&ok)
+	return ok, err
+}
+
+// PullRequestInTeam reports whether the author of the pull request belongs
+// to teamName.
+func (r *UserRepository) PullRequestInTeam(ctx context.Context, pullRequestId, teamName string) (bool, error) {
+	var ok bool
+	err := r.db.QueryRowContext(ctx,
+		`select exists(
+			select 1
+			from pull_requests pr
+			join user_teams ut on ut.user_id = pr.author_id
+			where pr.id = $1 and ut.team_name = $2
+		)`, pullRequestId, teamName).Scan(&ok)
+	return ok, err
+}
+
+func (r *UserRepository) IsTeamLead(ctx context.Context, userID, teamName string) (bool, error) {
+	var ok bool
+	err := r.db.QueryRowContext(ctx,
+		`select exists(select 1 from user_teams where user_id = $1 and team_name = $2 and role = 'lead')`,
+		userID, teamName).Scan(&ok)
+	return ok, err
+}
+
+// UsersOutsideTeam returns the existing users among userIDs who are not
+// members of teamName.
+func (r *UserRepository) UsersOutsideTeam(ctx context.Context, teamName string, userIDs []string) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`select u.id
+		from users u
+		where u.id = any($2)
+		and not exists(select 1 from user_teams ut where ut.user_id = u.id and ut.team_name = $1)
+		order by u.id`, teamName, pq.Array(userIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// LeadsUser reports whether leadID leads any team userID belongs to.
+func (r *UserRepository) LeadsUser(ctx context.Context, leadID, userID string) (bool, error) {
+	var ok bool
+	err := r.db.QueryRowContext(ctx,
+		`select exists(
+			select 1
+			from user_teams lead
+			join user_teams ut on ut.team_name = lead.team_name
+			where lead.user_id = $1 and lead.role = 'lead' and ut.user_id = $2
+		)`, leadID, userID).Scan(&ok)
+	return ok, err
+}
+
+// LeadsPullRequest reports whether leadID leads a team of the pull
+// request's author.
+func (r *UserRepository) LeadsPullRequest(ctx context.Context, leadID, pullRequestId string) (bool, error) {
+	var ok bool
+	err := r.db.QueryRowContext(ctx,
+		`select exists(
+			select 1
+			from pull_requests pr
+			join user_teams ut on ut.user_id = pr.author_id
+			join user_teams lead on lead.team_name = ut.team_name
+			where pr.id = $2 and lead.user_id = $1 and lead.role = 'lead'
+		)`, leadID, pullRequestId).Scan(&ok)
+	return ok, err
+}
+
+// checkReassignLimit fails with ReassignLimitError when the pull request
+// was reassigned maxPerHour times within the last hour. It runs in the
+// reassigning transaction after the PR row is locked and uses the database
+// clock, the one reassigned_at is written with.
+func checkReassignLimit(ctx context.Context, tx *sql.Tx, pullRequestId string, maxPerHour int) error {
+	var count int
+	var retryAfter float64
+	err := tx.QueryRowContext(ctx,
+		`select count(*), coalesce(extract(epoch from min(reassigned_at) + interval '1 hour' - now()), 0)
+		from pr_reassignments
+		where pr_id = $1 and reassigned_at > now() - interval '1 hour'`, pullRequestId).Scan(&count, &retryAfter)
+	if err != nil {
+		return err
+	}
+	if count >= maxPerHour {
+		return &ReassignLimitError{RetryAfter: time.Duration(retryAfter * float64(time.Second))}
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+)
+
+// reviewCountsCTE counts reviews and reassignments per user. A review falls
+// into the [$1, $2) window by the PR's created_at, a reassignment by its
+// reassigned_at. Either bound may be NULL.
+const reviewCountsCTE = `
+	with assigned as (
+		select prr.reviewer_id as user_id,
+			count(*) as assigned,
+			count(*) filter (where pr.status = 'OPEN') as open,
+			count(*) filter (where pr.status = 'MERGED') as merged
+		from pr_reviewers prr
+		join pull_requests pr on pr.id = prr.pr_id
+		where ($1::timestamptz is null or pr.created_at >= $1)
+		and ($2::timestamptz is null or pr.created_at < $2)
+		group by prr.reviewer_id
+	),
+	moved_out as (
+		select old_reviewer_id as user_id, count(*) as cnt
+		from pr_reassignments
+		where ($1::timestamptz is null or reassigned_at >= $1)
+		and ($2::timestamptz is null or reassigned_at < $2)
+		group by old_reviewer_id
+	),
+	moved_in as (
+		select new_reviewer_id as user_id, count(*) as cnt
+		from pr_reassignments
+		where ($1::timestamptz is null or reassigned_at >= $1)
+		and ($2::timestamptz is null or reassigned_at < $2)
+		group by new_reviewer_id
+	),
+	counts as (
+		select u.id as user_id,
+			coalesce(a.assigned, 0) as assigned,
+			coalesce(a.open, 0) as open,
+			coalesce(a.merged, 0) as merged,
+			coalesce(o.cnt, 0) as reassigned_out,
+			coalesce(i.cnt, 0) as reassigned_in
+		from users u
+		left join assigned a on a.user_id = u.id
+		left join moved_out o on o.user_id = u.id
+		left join moved_in i on i.user_id = u.id
+	)
+`
+
+func (r *UserRepository) GetReviewerStats(ctx context.Context, from, to *time.Time) ([]api.ReviewerStats, error) {
+	rows, err := r.db.QueryContext(ctx, reviewCountsCTE+`
+		select u.id, u.name, c.assigned, c.open, c.merged, c.reassigned_out, c.reassigned_in
+		from counts c
+		join users u on u.id = c.user_id
+		order by u.id`,
+		from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	stats := []api.ReviewerStats{}
+	for rows.Next() {
+		var s api.ReviewerStats
+		if err := rows.Scan(&s.UserId, &s.Username, &s.Assigned, &s.Open, &s.Merged, &s.ReassignedOut, &s.ReassignedIn); err != nil {
+			return nil, err
+		}
+		stats = append(stats, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (r *UserRepository) GetTeamStats(ctx context.Context, from, to *time.Time) ([]api.TeamStats, error) {
+	rows, err := r.db.QueryContext(ctx, reviewCountsCTE+`
+		select t.name,
+			coalesce(sum(c.assigned), 0),
+			coalesce(sum(c.open), 0),
+			coalesce(sum(c.merged), 0),
+			coalesce(sum(c.reassigned_out), 0),
+			coalesce(sum(c.reassigned_in), 0)
+		from team t
+		left join user_teams ut on ut.team_name = t.name
+		left join counts c on c.user_id = ut.user_id
+		group by t.name
+		order by t.name`,
+		from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	stats := []api.TeamStats{}
+	for rows.Next() {
+		var s api.TeamStats
+		if err := rows.Scan(&s.TeamName, &s.Assigned, &s.Open, &s.Merged, &s.ReassignedOut, &s.ReassignedIn); err != nil {
+			return nil, err
+		}
+		stats = append(stats, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+type TeamActivity struct {
+	TeamName    string
+	OpenPRs     int
+	ActiveUsers int
+}
+
+func (r *UserRepository) GetTeamActivity(ctx context.Context) ([]TeamActivity, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		select t.name,
+			(select count(*)
+			 from pull_requests pr
+			 join user_teams aut on aut.user_id = pr.author_id
+			 where aut.team_name = t.name and pr.status = 'OPEN'),
+			(select count(*)
+			 from user_teams ut
+			 join users u on u.id = ut.user_id
+			 where ut.team_name = t.name and u.is_active = true)
+		from team t
+		order by t.name`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var activity []TeamActivity
+	for rows.Next() {
+		var a TeamActivity
+		if err := rows.Scan(&a.TeamName, &a.OpenPRs, &a.ActiveUsers); err != nil {
+			return nil, err
+		}
+		activity = append(activity, a)
+	}
+	return activity, rows.Err()
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/lib/pq"
+)
+
+// SyncTeams makes each team's roster match the given members in a single
+// transaction: missing teams are created, new members added, absent members
+// removed the way RemoveMembers does it, and is_active and roles set as
+// given. A member without a role keeps the current one; new members
+// default to member. With dryRun the same statements run and are rolled back, so the
+// returned diff is exactly what a real run would do.
+func (r *UserRepository) SyncTeams(ctx context.Context, teams []api.Team, dryRun bool) ([]api.TeamSyncDiff, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	diffs := make([]api.TeamSyncDiff, 0, len(teams))
+	for _, team := range teams {
+		diff := api.TeamSyncDiff{
+			TeamName:    team.TeamName,
+			Added:       []string{},
+			Removed:     []string{},
+			Activated:   []string{},
+			Deactivated: []string{},
+		}
+
+		res, err := tx.ExecContext(ctx, `insert into team (name) values ($1) on conflict do nothing`, team.TeamName)
+		if err != nil {
+			return nil, err
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		diff.Created = n == 1
+		if err := lockTeam(ctx, tx, team.TeamName); err != nil {
+			return nil, err
+		}
+
+		current, err := loadTeam(ctx, tx, team.TeamName)
+		if err != nil {
+			return nil, err
+		}
+		inTeam := make(map[string]bool, len(current.Members))
+		for _, m := range current.Members {
+			inTeam[m.UserId] = true
+		}
+		wanted := make(map[string]bool, len(team.Members))
+		ids := make([]string, 0, len(team.Members))
+		for _, m := range team.Members {
+			wanted[m.UserId] = true
+			ids = append(ids, m.UserId)
+			if !inTeam[m.UserId] {
+				diff.Added = append(diff.Added, m.UserId)
+			}
+		}
+		for _, m := range current.Members {
+			if !wanted[m.UserId] {
+				diff.Removed = append(diff.Removed, m.UserId)
+			}
+		}
+
+		active, err := activeFlags(ctx, tx, ids)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range team.Members {
+			was, ok := active[m.UserId]
+			switch {
+			case !ok || was == m.IsActive:
+			case m.IsActive:
+				diff.Activated = append(diff.Activated, m.UserId)
+			default:
+				diff.Deactivated = append(diff.Deactivated, m.UserId)
+			}
+		}
+
+		if err := upsertUsers(ctx, tx, team.Members, true); err != nil {
+			return nil, err
+		}
+		for _, m := range team.Members {
+			_, err := tx.ExecContext(ctx,
+				`insert into user_teams (user_id, team_name, role) values ($1, $2, coalesce($3, 'member'))
+			on conflict (user_id, team_name) do update set role = coalesce($3, user_teams.role)`,
+				m.UserId, team.TeamName, m.Role)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if len(diff.Removed) > 0 {
+			diff.ReassignedReviews, diff.RemovedReviews, err = removeMembers(ctx, tx, team.TeamName, diff.Removed)
+			if err != nil {
+				return nil, err
+			}
+		}
+		diffs = append(diffs, diff)
+	}
+
+	if dryRun {
+		return diffs, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return diffs, nil
+}
+
+// activeFlags returns is_active of the existing users among ids.
+func activeFlags(ctx context.Context, tx *sql.Tx, ids []string) (map[string]bool, error) {
+	rows, err := tx.QueryContext(ctx, `select id, is_active from users where id = any($1)`, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	active := make(map[string]bool, len(ids))
+	for rows.Next() {
+		var id string
+		var isActive bool
+		if err := rows.Scan(&id, &isActive); err != nil {
+			return nil, err
+		}
+		active[id] = isActive
+	}
+	return active, rows.Err()
+}
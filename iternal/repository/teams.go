@@ -0,0 +1,494 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/lib/pq"
+)
+
+// MembersRemoval reports what RemoveMembers did with the removed members'
+// open reviews on the team's PRs.
+type MembersRemoval struct {
+	Team              *api.Team
+	ReassignedReviews int
+	RemovedReviews    int
+}
+
+// TeamFilter narrows ListTeams and CountTeams. A nil Name matches every team.
+type TeamFilter struct {
+	Name   *string
+	Limit  int
+	Offset int
+}
+
+// teamReplacementQuery picks a random active member of team $2 who is not
+// being removed ($3), is not the author $4 and is not already reviewing
+// PR $1.
+const teamReplacementQuery = `select u.id
+		from users u
+		join user_teams ut on ut.user_id = u.id
+		where ut.team_name = $2
+		and u.is_active = true
+		and u.deleted_at is null
+		and u.id <> all($3)
+		and u.id <> $4
+		and u.id not in (
+				select reviewer_id
+				from pr_reviewers
+				where pr_id = $1
+		)
+		order by random()
+		limit 1`
+
+// AddMembers adds members to an existing team. Missing users are created;
+// existing ones keep their is_active. A member already in the team keeps
+// its role unless a new one is given.
+func (r *UserRepository) AddMembers(ctx context.Context, teamName string, teamMembers []api.TeamMember) (*api.Team, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if err := lockTeam(ctx, tx, teamName); err != nil {
+		return nil, err
+	}
+
+	if err := upsertUsers(ctx, tx, teamMembers, false); err != nil {
+		return nil, err
+	}
+
+	for _, member := range teamMembers {
+		_, err := tx.ExecContext(ctx,
+			`insert into user_teams (user_id, team_name, role) values ($1, $2, coalesce($3, 'member'))
+		on conflict (user_id, team_name) do update set role = coalesce($3, user_teams.role)`,
+			member.UserId, teamName, member.Role)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	team, err := loadTeam(ctx, tx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return team, nil
+}
+
+// RemoveMembers takes users out of the team. Their open reviews on PRs
+// authored by team members go to other active members, or are dropped when
+// nobody is left to take them. Every user must be a member, otherwise
+// ErrUserNotFound is returned and nothing changes.
+func (r *UserRepository) RemoveMembers(ctx context.Context, teamName string, userIDs []string) (*MembersRemoval, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if err := lockTeam(ctx, tx, teamName); err != nil {
+		return nil, err
+	}
+
+	userIDs = uniqueIDs(userIDs)
+	var members int
+	err = tx.QueryRowContext(ctx,
+		`select count(*) from user_teams where team_name = $1 and user_id = any($2)`,
+		teamName, pq.Array(userIDs)).Scan(&members)
+	if err != nil {
+		return nil, err
+	}
+	if members != len(userIDs) {
+		return nil, ErrUserNotFound
+	}
+
+	result := &MembersRemoval{}
+	if result.ReassignedReviews, result.RemovedReviews, err = removeMembers(ctx, tx, teamName, userIDs); err != nil {
+		return nil, err
+	}
+
+	if result.Team, err = loadTeam(ctx, tx, teamName); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// removeMembers hands the open reviews userIDs hold on PRs of the team's
+// authors to the remaining active members, drops the ones nobody can take
+// and deletes the memberships.
+func removeMembers(ctx context.Context, tx *sql.Tx, teamName string, userIDs []string) (reassigned, removed int, err error) {
+	rows, err := tx.QueryContext(ctx,
+		`select pr.id, pr.author_id, prr.reviewer_id
+		from pull_requests pr
+		join pr_reviewers prr on prr.pr_id = pr.id
+		where pr.status = 'OPEN'
+		and prr.reviewer_id = any($2)
+		and exists(select 1 from user_teams ut where ut.user_id = pr.author_id and ut.team_name = $1)
+		order by pr.id, prr.reviewer_id
+		for update of pr`,
+		teamName, pq.Array(userIDs))
+	if err != nil {
+		return 0, 0, err
+	}
+	type review struct{ prID, authorID, reviewerID string }
+	var reviews []review
+	for rows.Next() {
+		var rv review
+		if err := rows.Scan(&rv.prID, &rv.authorID, &rv.reviewerID); err != nil {
+			_ = rows.Close()
+			return 0, 0, err
+		}
+		reviews = append(reviews, rv)
+	}
+	err = rows.Err()
+	_ = rows.Close()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, rv := range reviews {
+		var newReviewer string
+		err := tx.QueryRowContext(ctx, teamReplacementQuery,
+			rv.prID, teamName, pq.Array(userIDs), rv.authorID).Scan(&newReviewer)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return 0, 0, err
+		}
+		if err := replaceReviewer(ctx, tx, rv.prID, rv.reviewerID, newReviewer); err != nil {
+			return 0, 0, err
+		}
+		if newReviewer == "" {
+			removed++
+		} else {
+			reassigned++
+		}
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`delete from user_teams where team_name = $1 and user_id = any($2)`,
+		teamName, pq.Array(userIDs))
+	if err != nil {
+		return 0, 0, err
+	}
+	return reassigned, removed, nil
+}
+
+// RenameTeam changes the team's name; user_teams and api_keys follow
+// through on update cascade.
+func (r *UserRepository) RenameTeam(ctx context.Context, teamName, newTeamName string) (*api.Team, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	res, err := tx.ExecContext(ctx, `update team set name = $2 where name = $1`, teamName, newTeamName)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return nil, ErrTeamExists
+		}
+		return nil, err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return nil, err
+	} else if n == 0 {
+		return nil, ErrTeamNotFound
+	}
+
+	team, err := loadTeam(ctx, tx, newTeamName)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return team, nil
+}
+
+// DeleteTeam removes the team together with its memberships and the API
+// keys scoped to it. Users and PRs stay.
+func (r *UserRepository) DeleteTeam(ctx context.Context, teamName string) error {
+	res, err := r.db.ExecContext(ctx, `delete from team where name = $1`, teamName)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrTeamNotFound
+	}
+	return nil
+}
+
+// CreateTeam creates an empty team, or one made of existing users, who join
+// as members. It returns ErrTeamExists or, when a user does not exist,
+// ErrUserNotFound.
+func (r *UserRepository) CreateTeam(ctx context.Context, teamName string, userIDs []string) (*api.Team, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if _, err := tx.ExecContext(ctx, `insert into team (name) values ($1)`, teamName); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return nil, ErrTeamExists
+		}
+		return nil, err
+	}
+	if err := addTeamUsers(ctx, tx, teamName, userIDs); err != nil {
+		return nil, err
+	}
+
+	team, err := loadTeam(ctx, tx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return team, nil
+}
+
+// AddTeamUsers adds existing users to the team as members. Unlike
+// AddMembers it does not touch the users themselves.
+func (r *UserRepository) AddTeamUsers(ctx context.Context, teamName string, userIDs []string) (*api.Team, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if err := lockTeam(ctx, tx, teamName); err != nil {
+		return nil, err
+	}
+	if err := addTeamUsers(ctx, tx, teamName, userIDs); err != nil {
+		return nil, err
+	}
+
+	team, err := loadTeam(ctx, tx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return team, nil
+}
+
+// PatchTeam renames the team to newTeamName, adds existing users as
+// members and removes members, all in one transaction: either every change
+// is made or none is. Removed members' reviews are handed over as in
+// RemoveMembers, and every removed user must be a member.
+func (r *UserRepository) PatchTeam(ctx context.Context, teamName, newTeamName string, add, remove []string) (*MembersRemoval, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if err := lockTeam(ctx, tx, teamName); err != nil {
+		return nil, err
+	}
+	if newTeamName != teamName {
+		if _, err := tx.ExecContext(ctx, `update team set name = $2 where name = $1`, teamName, newTeamName); err != nil {
+			if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+				return nil, ErrTeamExists
+			}
+			return nil, err
+		}
+	}
+	if err := addTeamUsers(ctx, tx, newTeamName, add); err != nil {
+		return nil, err
+	}
+
+	result := &MembersRemoval{}
+	if len(remove) > 0 {
+		remove = uniqueIDs(remove)
+		var members int
+		err = tx.QueryRowContext(ctx,
+			`select count(*) from user_teams where team_name = $1 and user_id = any($2)`,
+			newTeamName, pq.Array(remove)).Scan(&members)
+		if err != nil {
+			return nil, err
+		}
+		if members != len(remove) {
+			return nil, ErrUserNotFound
+		}
+		if result.ReassignedReviews, result.RemovedReviews, err = removeMembers(ctx, tx, newTeamName, remove); err != nil {
+			return nil, err
+		}
+	}
+
+	if result.Team, err = loadTeam(ctx, tx, newTeamName); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func addTeamUsers(ctx context.Context, tx *sql.Tx, teamName string, userIDs []string) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	userIDs = uniqueIDs(userIDs)
+	var found int
+	err := tx.QueryRowContext(ctx,
+		`select count(*) from users where id = any($1) and deleted_at is null`, pq.Array(userIDs)).Scan(&found)
+	if err != nil {
+		return err
+	}
+	if found != len(userIDs) {
+		return ErrUserNotFound
+	}
+	_, err = tx.ExecContext(ctx,
+		`insert into user_teams (user_id, team_name, role)
+		select id, $1, 'member' from users where id = any($2)
+		on conflict do nothing`,
+		teamName, pq.Array(userIDs))
+	return err
+}
+
+// ListTeams returns teams ordered by name with their members. Unlike
+// GetTeam it also returns teams without members.
+func (r *UserRepository) ListTeams(ctx context.Context, filter TeamFilter) ([]api.Team, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`select name from team
+		where ($1::text is null or name = $1)
+		order by name
+		limit $2 offset $3`,
+		filter.Name, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, err
+	}
+	teams := []api.Team{}
+	index := make(map[string]int)
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			_ = rows.Close()
+			return nil, err
+		}
+		index[name] = len(teams)
+		names = append(names, name)
+		teams = append(teams, api.Team{TeamName: name, Members: []api.TeamMember{}})
+	}
+	err = rows.Err()
+	_ = rows.Close()
+	if err != nil || len(teams) == 0 {
+		return teams, err
+	}
+
+	rows, err = r.db.QueryContext(ctx,
+		`select ut.team_name, u.id, u.name, u.is_active, ut.role
+		from user_teams ut
+		join users u on ut.user_id = u.id
+		where ut.team_name = any($1)
+		order by ut.team_name, u.id`,
+		pq.Array(names))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	for rows.Next() {
+		var teamName string
+		var m api.TeamMember
+		var role api.TeamMemberRole
+		if err := rows.Scan(&teamName, &m.UserId, &m.Username, &m.IsActive, &role); err != nil {
+			return nil, err
+		}
+		m.Role = &role
+		i := index[teamName]
+		teams[i].Members = append(teams[i].Members, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return teams, nil
+}
+
+// CountTeams counts the teams matching filter, ignoring Limit and Offset.
+func (r *UserRepository) CountTeams(ctx context.Context, filter TeamFilter) (int, error) {
+	var n int
+	err := r.db.QueryRowContext(ctx,
+		`select count(*) from team where ($1::text is null or name = $1)`, filter.Name).Scan(&n)
+	return n, err
+}
+
+// uniqueIDs drops repeated ids, keeping the first of each, so that a count
+// of the rows they match can be compared with the number of ids.
+func uniqueIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	unique := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+func lockTeam(ctx context.Context, tx *sql.Tx, teamName string) error {
+	var name string
+	err := tx.QueryRowContext(ctx, `select name from team where name = $1 for update`, teamName).Scan(&name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrTeamNotFound
+	}
+	return err
+}
+
+// loadTeam is GetTeam inside a transaction; an empty team is not an
+// error here.
+func loadTeam(ctx context.Context, tx *sql.Tx, teamName string) (*api.Team, error) {
+	rows, err := tx.QueryContext(ctx,
+		`select u.id, u.name, u.is_active, ut.role
+		from user_teams ut
+		join users u on ut.user_id = u.id
+		where ut.team_name = $1
+		order by u.id`, teamName)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	team := &api.Team{TeamName: teamName, Members: []api.TeamMember{}}
+	for rows.Next() {
+		var m api.TeamMember
+		var role api.TeamMemberRole
+		if err := rows.Scan(&m.UserId, &m.Username, &m.IsActive, &role); err != nil {
+			return nil, err
+		}
+		m.Role = &role
+		team.Members = append(team.Members, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return team, nil
+}
@@ -0,0 +1,350 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/lib/pq"
+)
+
+// DeletedUserName replaces the name of an anonymized user.
+const DeletedUserName = "deleted user"
+
+// DeleteMode chooses what happens to a user's reviews on deletion.
+type DeleteMode string
+
+const (
+	// DeleteBlock refuses to delete a user that authored or reviewed PRs.
+	DeleteBlock DeleteMode = "block"
+	// DeleteReassign moves open reviews to active teammates first.
+	DeleteReassign DeleteMode = "reassign"
+	// DeleteAnonymize drops open reviews without replacement.
+	DeleteAnonymize DeleteMode = "anonymize"
+)
+
+// UserDeletion reports what DeleteUser did. A user that is still referenced
+// by PRs after its open reviews are handled is anonymized instead of
+// removed.
+type UserDeletion struct {
+	Anonymized        bool
+	ReassignedReviews int
+	RemovedReviews    int
+}
+
+// UserFilter narrows ListUsers and CountUsers. Nil fields are not applied;
+// Username is a case-insensitive substring and After is the last user_id of
+// the previous page. Offset skips rows instead, for callers that page by
+// position.
+type UserFilter struct {
+	UserID         *string
+	TeamName       *string
+	IsActive       *bool
+	Username       *string
+	IncludeDeleted bool
+	Limit          int
+	Offset         int
+	After          *string
+}
+
+const userColumns = `
+	u.id, u.name, u.is_active, u.deleted_at,
+	coalesce((select min(ut.team_name) from user_teams ut where ut.user_id = u.id), '')`
+
+// replacementQuery picks a random active teammate of $2 who is neither the
+// author $3 nor already reviewing PR $1.
+const replacementQuery = `select u.id
+		from users u
+		join user_teams ut on ut.user_id = u.id
+		where ut.team_name = (
+				select team_name
+				from user_teams
+				where user_id = $2
+				limit 1
+		)
+		and u.is_active = true
+		and u.deleted_at is null
+		and u.id <> $2
+		and u.id <> $3
+		and u.id not in (
+				select reviewer_id
+				from pr_reviewers
+				where pr_id = $1
+		)
+		order by random()
+		limit 1`
+
+func (r *UserRepository) GetUser(ctx context.Context, userID string) (*api.User, error) {
+	row := r.db.QueryRowContext(ctx, `select `+userColumns+` from users u where u.id = $1`, userID)
+	user, err := scanUser(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// UpdateUser changes the name and active flag of a user that is not
+// deleted in one transaction. Nil arguments keep the current value. The
+// flag is changed by setActive, the same way UpdateActive does.
+func (r *UserRepository) UpdateUser(ctx context.Context, userID string, username *string, isActive *bool) (*api.User, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	res, err := tx.ExecContext(ctx,
+		`update users set name = coalesce($2, name)
+		where id = $1 and deleted_at is null`,
+		userID, username)
+	if err != nil {
+		return nil, err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return nil, err
+	} else if n == 0 {
+		return nil, ErrUserNotFound
+	}
+	if isActive != nil {
+		if err := setActive(ctx, tx, userID, *isActive); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return r.GetUser(ctx, userID)
+}
+
+// userFilterWhere applies a UserFilter; $1..$6 are the arguments returned
+// by userFilterArgs.
+const userFilterWhere = `
+	where ($1::text is null or exists(
+		select 1 from user_teams ut where ut.user_id = u.id and ut.team_name = $1))
+	and ($2::boolean is null or u.is_active = $2)
+	and ($3::text is null or u.name ilike $3)
+	and ($4 or u.deleted_at is null)
+	and ($5::text is null or u.id > $5)
+	and ($6::text is null or u.id = $6)`
+
+func userFilterArgs(filter UserFilter) []any {
+	var username *string
+	if filter.Username != nil {
+		pattern := "%" + escapeLike(*filter.Username) + "%"
+		username = &pattern
+	}
+	return []any{filter.TeamName, filter.IsActive, username, filter.IncludeDeleted, filter.After, filter.UserID}
+}
+
+func (r *UserRepository) ListUsers(ctx context.Context, filter UserFilter) ([]api.User, error) {
+	args := append(userFilterArgs(filter), filter.Limit, filter.Offset)
+	rows, err := r.db.QueryContext(ctx,
+		`select `+userColumns+`
+		from users u`+userFilterWhere+`
+		order by u.id
+		limit $7 offset $8`,
+		args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	users := []api.User{}
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, *user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// CountUsers counts the users matching filter, ignoring Limit and Offset.
+func (r *UserRepository) CountUsers(ctx context.Context, filter UserFilter) (int, error) {
+	var n int
+	err := r.db.QueryRowContext(ctx, `select count(*) from users u`+userFilterWhere, userFilterArgs(filter)...).Scan(&n)
+	return n, err
+}
+
+// CreateUser adds a user that is not in any team yet.
+func (r *UserRepository) CreateUser(ctx context.Context, userID, username string, isActive bool) (*api.User, error) {
+	_, err := r.db.ExecContext(ctx,
+		`insert into users (id, name, is_active) values ($1, $2, $3)`, userID, username, isActive)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return nil, ErrUserExists
+		}
+		return nil, err
+	}
+	return r.GetUser(ctx, userID)
+}
+
+// DeleteUser removes a user according to mode. Open reviews are reassigned
+// or dropped first; if the user still authored or reviewed PRs it is
+// anonymized, otherwise its row is deleted. DeleteBlock returns
+// ErrUserHasActivity for any such user.
+func (r *UserRepository) DeleteUser(ctx context.Context, userID string, mode DeleteMode) (*UserDeletion, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var deleted bool
+	err = tx.QueryRowContext(ctx,
+		`select deleted_at is not null from users where id = $1 for update`, userID).Scan(&deleted)
+	if errors.Is(err, sql.ErrNoRows) || deleted {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var result UserDeletion
+	switch mode {
+	case DeleteBlock:
+	case DeleteReassign:
+		if result.ReassignedReviews, result.RemovedReviews, err = reassignOpenReviews(ctx, tx, userID); err != nil {
+			return nil, err
+		}
+	case DeleteAnonymize:
+		res, err := tx.ExecContext(ctx,
+			`delete from pr_reviewers prr
+			using pull_requests pr
+			where pr.id = prr.pr_id and prr.reviewer_id = $1 and pr.status = 'OPEN'`, userID)
+		if err != nil {
+			return nil, err
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		result.RemovedReviews = int(n)
+	default:
+		return nil, fmt.Errorf("unknown delete mode %q", mode)
+	}
+
+	var referenced bool
+	err = tx.QueryRowContext(ctx,
+		`select exists(select 1 from pull_requests where author_id = $1)
+			or exists(select 1 from pr_reviewers where reviewer_id = $1)`, userID).Scan(&referenced)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case referenced && mode == DeleteBlock:
+		return nil, ErrUserHasActivity
+	case referenced:
+		result.Anonymized = true
+		if _, err := tx.ExecContext(ctx,
+			`update users set name = $2, is_active = false, deleted_at = now() where id = $1`,
+			userID, DeletedUserName); err != nil {
+			return nil, err
+		}
+		if _, err := tx.ExecContext(ctx, `delete from user_teams where user_id = $1`, userID); err != nil {
+			return nil, err
+		}
+		if _, err := tx.ExecContext(ctx, `delete from api_keys where user_id = $1`, userID); err != nil {
+			return nil, err
+		}
+	default:
+		if _, err := tx.ExecContext(ctx, `delete from users where id = $1`, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// reassignOpenReviews hands every open review of userID to a teammate, the
+// same way PullRequestReassign does, and drops the reviews nobody can take.
+func reassignOpenReviews(ctx context.Context, tx *sql.Tx, userID string) (reassigned, removed int, err error) {
+	rows, err := tx.QueryContext(ctx,
+		`select pr.id, pr.author_id
+		from pull_requests pr
+		join pr_reviewers prr on prr.pr_id = pr.id
+		where prr.reviewer_id = $1 and pr.status = 'OPEN'
+		order by pr.id
+		for update of pr`, userID)
+	if err != nil {
+		return 0, 0, err
+	}
+	type review struct{ prID, authorID string }
+	var reviews []review
+	for rows.Next() {
+		var rv review
+		if err := rows.Scan(&rv.prID, &rv.authorID); err != nil {
+			_ = rows.Close()
+			return 0, 0, err
+		}
+		reviews = append(reviews, rv)
+	}
+	err = rows.Err()
+	_ = rows.Close()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, rv := range reviews {
+		var newReviewer string
+		err := tx.QueryRowContext(ctx, replacementQuery, rv.prID, userID, rv.authorID).Scan(&newReviewer)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return 0, 0, err
+		}
+
+		if err := replaceReviewer(ctx, tx, rv.prID, userID, newReviewer); err != nil {
+			return 0, 0, err
+		}
+		if newReviewer == "" {
+			removed++
+		} else {
+			reassigned++
+		}
+	}
+	return reassigned, removed, nil
+}
+
+// replaceReviewer moves the review of PR prID from oldID to newID and logs
+// it in pr_reassignments. An empty newID just drops the review.
+func replaceReviewer(ctx context.Context, tx *sql.Tx, prID, oldID, newID string) error {
+	if _, err := tx.ExecContext(ctx,
+		`delete from pr_reviewers where pr_id = $1 and reviewer_id = $2`, prID, oldID); err != nil {
+		return err
+	}
+	if newID == "" {
+		return nil
+	}
+	if _, err := tx.ExecContext(ctx,
+		`insert into pr_reviewers(pr_id, reviewer_id) values ($1, $2)`, prID, newID); err != nil {
+		return err
+	}
+	_, err := tx.ExecContext(ctx,
+		`insert into pr_reassignments(pr_id, old_reviewer_id, new_reviewer_id) values ($1, $2, $3)`,
+		prID, oldID, newID)
+	return err
+}
+
+func scanUser(row rowScanner) (*api.User, error) {
+	var user api.User
+	if err := row.Scan(&user.UserId, &user.Username, &user.IsActive, &user.DeletedAt, &user.TeamName); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
@@ -0,0 +1,39 @@
+package scim
+
+import (
+	"errors"
+	"strings"
+)
+
+var errUnsupportedFilter = errors.New("only filters of the form `attribute eq \"value\"` are supported")
+
+// equalityFilter is the one filter form identity providers use to look a
+// resource up before creating it: `userName eq "alice"`.
+type equalityFilter struct {
+	Attribute string
+	Value     string
+}
+
+func parseFilter(s string) (*equalityFilter, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+	attr, rest, ok := strings.Cut(s, " ")
+	if !ok {
+		return nil, errUnsupportedFilter
+	}
+	op, value, ok := strings.Cut(strings.TrimSpace(rest), " ")
+	if !ok || !strings.EqualFold(op, "eq") {
+		return nil, errUnsupportedFilter
+	}
+	value = strings.TrimSpace(value)
+	switch {
+	case len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"':
+		value = strings.ReplaceAll(value[1:len(value)-1], `\"`, `"`)
+	case value == "true" || value == "false":
+	default:
+		return nil, errUnsupportedFilter
+	}
+	return &equalityFilter{Attribute: attr, Value: value}, nil
+}
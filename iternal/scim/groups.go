@@ -0,0 +1,273 @@
+package scim
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/chimort/avito_test_task/iternal/repository"
+	"github.com/labstack/echo/v4"
+)
+
+type Group struct {
+	Schemas     []string    `json:"schemas"`
+	ID          string      `json:"id,omitempty"`
+	DisplayName string      `json:"displayName"`
+	Members     []MemberRef `json:"members"`
+	Meta        *Meta       `json:"meta,omitempty"`
+}
+
+type MemberRef struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+func toGroup(t api.Team) Group {
+	members := make([]MemberRef, len(t.Members))
+	for i, m := range t.Members {
+		members[i] = MemberRef{Value: m.UserId, Display: m.Username, Ref: basePath + "/Users/" + m.UserId}
+	}
+	return Group{
+		Schemas:     []string{SchemaGroup},
+		ID:          t.TeamName,
+		DisplayName: t.TeamName,
+		Members:     members,
+		Meta:        &Meta{ResourceType: "Group", Location: basePath + "/Groups/" + t.TeamName},
+	}
+}
+
+func (h *Handler) ListGroups(c echo.Context) error {
+	startIndex, count, ok := page(c)
+	if !ok {
+		return scimError(c, http.StatusBadRequest, errInvalidValue, "startIndex and count must be integers")
+	}
+	f, err := parseFilter(c.QueryParam("filter"))
+	if err != nil {
+		return scimError(c, http.StatusBadRequest, errInvalidFilter, err.Error())
+	}
+
+	filter := repository.TeamFilter{Limit: count, Offset: startIndex - 1}
+	if f != nil {
+		switch strings.ToLower(f.Attribute) {
+		case "displayname", "id":
+			filter.Name = &f.Value
+		default:
+			return scimError(c, http.StatusBadRequest, errInvalidFilter, "groups can be filtered by displayName or id")
+		}
+	}
+
+	teams, total, err := h.svc.FindTeams(c.Request().Context(), filter)
+	if err != nil {
+		return h.internalError(c, err, "failed to list groups")
+	}
+	resources := make([]any, len(teams))
+	for i, t := range teams {
+		resources[i] = toGroup(t)
+	}
+	return write(c, http.StatusOK, listResponse(total, startIndex, resources))
+}
+
+func (h *Handler) GetGroup(c echo.Context) error {
+	team, err := h.findTeam(c, c.Param("id"))
+	if err != nil {
+		return h.groupError(c, err, "failed to get group")
+	}
+	return write(c, http.StatusOK, toGroup(*team))
+}
+
+func (h *Handler) CreateGroup(c echo.Context) error {
+	var in Group
+	if err := decode(c, &in); err != nil {
+		return scimError(c, http.StatusBadRequest, errInvalidSyntax, "invalid body")
+	}
+	if in.DisplayName == "" {
+		return scimError(c, http.StatusBadRequest, errInvalidValue, "displayName is required")
+	}
+	userIDs := make([]string, len(in.Members))
+	for i, m := range in.Members {
+		userIDs[i] = m.Value
+	}
+
+	team, err := h.svc.CreateTeam(c.Request().Context(), in.DisplayName, userIDs)
+	if err != nil {
+		return h.groupError(c, err, "failed to create group")
+	}
+	out := toGroup(*team)
+	c.Response().Header().Set(echo.HeaderLocation, out.Meta.Location)
+	return write(c, http.StatusCreated, out)
+}
+
+// PatchGroup applies the operations to the group's name and member list
+// and then makes the changes in one transaction, so a failed PATCH leaves
+// the group as it was (RFC 7644, section 3.5.2). Removed members' reviews
+// are handed over as in /team/removeMembers.
+func (h *Handler) PatchGroup(c echo.Context) error {
+	var req PatchRequest
+	if err := decode(c, &req); err != nil {
+		return scimError(c, http.StatusBadRequest, errInvalidSyntax, "invalid body")
+	}
+	team, err := h.findTeam(c, c.Param("id"))
+	if err != nil {
+		return h.groupError(c, err, "failed to get group")
+	}
+
+	current := make(map[string]bool, len(team.Members))
+	for _, m := range team.Members {
+		current[m.UserId] = true
+	}
+	p := groupPatch{name: team.TeamName, members: make(map[string]bool, len(current))}
+	for id := range current {
+		p.members[id] = true
+	}
+	for _, op := range req.Operations {
+		if err := p.apply(op); err != nil {
+			return scimError(c, http.StatusBadRequest, errInvalidPath, err.Error())
+		}
+	}
+
+	var added, removed []string
+	for _, id := range p.order {
+		if p.members[id] && !current[id] {
+			added = append(added, id)
+		}
+	}
+	for _, m := range team.Members {
+		if !p.members[m.UserId] {
+			removed = append(removed, m.UserId)
+		}
+	}
+
+	res, err := h.svc.PatchTeam(c.Request().Context(), team.TeamName, p.name, added, removed)
+	if err != nil {
+		return h.groupError(c, err, "failed to patch group")
+	}
+	return write(c, http.StatusOK, toGroup(*res.Team))
+}
+
+func (h *Handler) DeleteGroup(c echo.Context) error {
+	if err := h.svc.DeleteTeam(c.Request().Context(), c.Param("id")); err != nil {
+		return h.groupError(c, err, "failed to delete group")
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *Handler) findTeam(c echo.Context, name string) (*api.Team, error) {
+	teams, _, err := h.svc.FindTeams(c.Request().Context(), repository.TeamFilter{Name: &name, Limit: 1})
+	if err != nil {
+		return nil, err
+	}
+	if len(teams) == 0 {
+		return nil, repository.ErrTeamNotFound
+	}
+	return &teams[0], nil
+}
+
+// groupError maps repository errors to SCIM errors and anything else to
+// 500 with msg.
+func (h *Handler) groupError(c echo.Context, err error, msg string) error {
+	switch {
+	case errors.Is(err, repository.ErrTeamNotFound):
+		return scimError(c, http.StatusNotFound, "", "group not found")
+	case errors.Is(err, repository.ErrTeamExists):
+		return scimError(c, http.StatusConflict, errUniqueness, "displayName is already taken")
+	case errors.Is(err, repository.ErrUserNotFound):
+		return scimError(c, http.StatusBadRequest, errInvalidValue, "members must be existing users")
+	}
+	return h.internalError(c, err, msg)
+}
+
+// groupPatch is the desired state of a group while PATCH operations are
+// applied. order keeps members in the order they were first mentioned.
+type groupPatch struct {
+	name    string
+	members map[string]bool
+	order   []string
+}
+
+func (p *groupPatch) add(id string) {
+	if !p.members[id] {
+		p.members[id] = true
+		p.order = append(p.order, id)
+	}
+}
+
+func (p *groupPatch) apply(op PatchOperation) error {
+	kind := strings.ToLower(op.Op)
+	path := strings.ToLower(op.Path)
+
+	if kind == "remove" {
+		switch {
+		case path == "members" && len(op.Value) == 0:
+			clear(p.members)
+		case path == "members":
+			refs, err := memberRefs(op.Value)
+			if err != nil {
+				return err
+			}
+			for _, id := range refs {
+				delete(p.members, id)
+			}
+		case strings.HasPrefix(path, "members[") && strings.HasSuffix(path, "]"):
+			f, err := parseFilter(op.Path[len("members[") : len(op.Path)-1])
+			if err != nil || f == nil || !strings.EqualFold(f.Attribute, "value") {
+				return fmt.Errorf("unsupported path %q", op.Path)
+			}
+			delete(p.members, f.Value)
+		default:
+			return fmt.Errorf("unsupported path %q", op.Path)
+		}
+		return nil
+	}
+	if kind != "add" && kind != "replace" {
+		return fmt.Errorf("unsupported op %q", op.Op)
+	}
+
+	values := map[string]json.RawMessage{}
+	if op.Path == "" {
+		if err := json.Unmarshal(op.Value, &values); err != nil {
+			return errors.New("value must be an object when path is empty")
+		}
+	} else {
+		values[op.Path] = op.Value
+	}
+	for attr, value := range values {
+		switch strings.ToLower(attr) {
+		case "displayname":
+			var name string
+			if err := json.Unmarshal(value, &name); err != nil || name == "" {
+				return errors.New("displayName must be a non-empty string")
+			}
+			p.name = name
+		case "members":
+			refs, err := memberRefs(value)
+			if err != nil {
+				return err
+			}
+			if kind == "replace" {
+				clear(p.members)
+			}
+			for _, id := range refs {
+				p.add(id)
+			}
+		default:
+			return fmt.Errorf("attribute %q cannot be changed", attr)
+		}
+	}
+	return nil
+}
+
+func memberRefs(raw json.RawMessage) ([]string, error) {
+	var refs []MemberRef
+	if err := json.Unmarshal(raw, &refs); err != nil {
+		return nil, errors.New("members must be a list of {\"value\": user id}")
+	}
+	ids := make([]string, len(refs))
+	for i, r := range refs {
+		ids[i] = r.Value
+	}
+	return ids, nil
+}
@@ -0,0 +1,178 @@
+// Package scim serves SCIM 2.0 (RFC 7643, RFC 7644) provisioning endpoints
+// under /scim/v2. Users map to users with userName as users.id, groups map
+// to teams with displayName as the team name.
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/chimort/avito_test_task/iternal/auth"
+	"github.com/chimort/avito_test_task/iternal/pkg/logger"
+	"github.com/chimort/avito_test_task/iternal/repository"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	SchemaUser         = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SchemaGroup        = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SchemaListResponse = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SchemaPatchOp      = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SchemaError        = "urn:ietf:params:scim:api:messages:2.0:Error"
+
+	// MediaType is the content type of every SCIM response.
+	MediaType = "application/scim+json"
+
+	basePath = "/scim/v2"
+
+	defaultCount = 100
+	maxCount     = 500
+)
+
+// Service is the part of service.UserService that provisioning needs.
+type Service interface {
+	GetUser(ctx context.Context, userID string) (*api.User, error)
+	CreateUser(ctx context.Context, userID, username string, isActive bool) (*api.User, error)
+	UpdateUser(ctx context.Context, userID string, username *string, isActive *bool) (*api.User, error)
+	DeleteUser(ctx context.Context, userID string, mode api.UserDeleteMode) (*api.UserDeletion, error)
+	FindUsers(ctx context.Context, filter repository.UserFilter) ([]api.User, int, error)
+	CreateTeam(ctx context.Context, teamName string, userIDs []string) (*api.Team, error)
+	PatchTeam(ctx context.Context, teamName, newTeamName string, add, remove []string) (*repository.MembersRemoval, error)
+	DeleteTeam(ctx context.Context, teamName string) error
+	FindTeams(ctx context.Context, filter repository.TeamFilter) ([]api.Team, int, error)
+}
+
+type Handler struct {
+	svc Service
+	log *logger.Logger
+}
+
+func NewHandler(svc Service, log *logger.Logger) *Handler {
+	return &Handler{svc: svc, log: log}
+}
+
+// Register mounts the SCIM routes on e. Keys limited to one team cannot
+// provision.
+func (h *Handler) Register(e *echo.Echo) {
+	g := e.Group(basePath, unscoped)
+	g.GET("/Users", h.ListUsers)
+	g.POST("/Users", h.CreateUser)
+	g.GET("/Users/:id", h.GetUser)
+	g.PATCH("/Users/:id", h.PatchUser)
+	g.DELETE("/Users/:id", h.DeleteUser)
+	g.GET("/Groups", h.ListGroups)
+	g.POST("/Groups", h.CreateGroup)
+	g.GET("/Groups/:id", h.GetGroup)
+	g.PATCH("/Groups/:id", h.PatchGroup)
+	g.DELETE("/Groups/:id", h.DeleteGroup)
+}
+
+func unscoped(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if p, ok := auth.FromContext(c.Request().Context()); ok && p.Team != "" {
+			return scimError(c, http.StatusForbidden, "", "team-scoped keys cannot provision")
+		}
+		return next(c)
+	}
+}
+
+type Meta struct {
+	ResourceType string `json:"resourceType"`
+	Location     string `json:"location"`
+}
+
+type ListResponse struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []any    `json:"Resources"`
+}
+
+type PatchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []PatchOperation `json:"Operations"`
+}
+
+type PatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+type Error struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail"`
+}
+
+// Error types from RFC 7644, section 3.12.
+const (
+	errInvalidFilter = "invalidFilter"
+	errInvalidSyntax = "invalidSyntax"
+	errInvalidPath   = "invalidPath"
+	errInvalidValue  = "invalidValue"
+	errUniqueness    = "uniqueness"
+)
+
+func scimError(c echo.Context, status int, scimType, detail string) error {
+	return write(c, status, Error{
+		Schemas:  []string{SchemaError},
+		Status:   strconv.Itoa(status),
+		ScimType: scimType,
+		Detail:   detail,
+	})
+}
+
+// internalError logs err and answers 500.
+func (h *Handler) internalError(c echo.Context, err error, msg string) error {
+	h.log.Error(msg, "error", err)
+	return scimError(c, http.StatusInternalServerError, "", msg)
+}
+
+func write(c echo.Context, status int, v any) error {
+	c.Response().Header().Set(echo.HeaderContentType, MediaType)
+	c.Response().WriteHeader(status)
+	return json.NewEncoder(c.Response()).Encode(v)
+}
+
+// decode reads a JSON body. Echo's binder only accepts application/json,
+// while SCIM clients send application/scim+json.
+func decode(c echo.Context, v any) error {
+	return json.NewDecoder(c.Request().Body).Decode(v)
+}
+
+// page reads startIndex and count (RFC 7644, section 3.4.2.4). startIndex is
+// 1-based; count is capped at maxCount.
+func page(c echo.Context) (startIndex, count int, ok bool) {
+	startIndex, count = 1, defaultCount
+	if v := c.QueryParam("startIndex"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, 0, false
+		}
+		startIndex = max(n, 1)
+	}
+	if v := c.QueryParam("count"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, 0, false
+		}
+		count = min(max(n, 0), maxCount)
+	}
+	return startIndex, count, true
+}
+
+func listResponse(total, startIndex int, resources []any) ListResponse {
+	return ListResponse{
+		Schemas:      []string{SchemaListResponse},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}
+}
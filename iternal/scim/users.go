@@ -0,0 +1,221 @@
+package scim
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/chimort/avito_test_task/iternal/repository"
+	"github.com/labstack/echo/v4"
+)
+
+type User struct {
+	Schemas     []string `json:"schemas"`
+	ID          string   `json:"id,omitempty"`
+	UserName    string   `json:"userName"`
+	DisplayName string   `json:"displayName,omitempty"`
+	Name        *Name    `json:"name,omitempty"`
+	Active      *bool    `json:"active,omitempty"`
+	Meta        *Meta    `json:"meta,omitempty"`
+}
+
+type Name struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+func toUser(u api.User) User {
+	active := u.IsActive
+	return User{
+		Schemas:     []string{SchemaUser},
+		ID:          u.UserId,
+		UserName:    u.UserId,
+		DisplayName: u.Username,
+		Name:        &Name{Formatted: u.Username},
+		Active:      &active,
+		Meta:        &Meta{ResourceType: "User", Location: basePath + "/Users/" + u.UserId},
+	}
+}
+
+// username picks the name to store: displayName, then name, then userName.
+func (u User) username() string {
+	if u.DisplayName != "" {
+		return u.DisplayName
+	}
+	if u.Name != nil {
+		if u.Name.Formatted != "" {
+			return u.Name.Formatted
+		}
+		if full := strings.TrimSpace(u.Name.GivenName + " " + u.Name.FamilyName); full != "" {
+			return full
+		}
+	}
+	return u.UserName
+}
+
+func (h *Handler) ListUsers(c echo.Context) error {
+	startIndex, count, ok := page(c)
+	if !ok {
+		return scimError(c, http.StatusBadRequest, errInvalidValue, "startIndex and count must be integers")
+	}
+	f, err := parseFilter(c.QueryParam("filter"))
+	if err != nil {
+		return scimError(c, http.StatusBadRequest, errInvalidFilter, err.Error())
+	}
+
+	filter := repository.UserFilter{Limit: count, Offset: startIndex - 1}
+	if f != nil {
+		switch strings.ToLower(f.Attribute) {
+		case "username", "id":
+			filter.UserID = &f.Value
+		case "active":
+			active, err := strconv.ParseBool(f.Value)
+			if err != nil {
+				return scimError(c, http.StatusBadRequest, errInvalidFilter, "active must be true or false")
+			}
+			filter.IsActive = &active
+		default:
+			return scimError(c, http.StatusBadRequest, errInvalidFilter, "users can be filtered by userName, id or active")
+		}
+	}
+
+	users, total, err := h.svc.FindUsers(c.Request().Context(), filter)
+	if err != nil {
+		return h.internalError(c, err, "failed to list users")
+	}
+	resources := make([]any, len(users))
+	for i, u := range users {
+		resources[i] = toUser(u)
+	}
+	return write(c, http.StatusOK, listResponse(total, startIndex, resources))
+}
+
+func (h *Handler) GetUser(c echo.Context) error {
+	user, err := h.svc.GetUser(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return h.userError(c, err, "failed to get user")
+	}
+	if user.DeletedAt != nil {
+		return scimError(c, http.StatusNotFound, "", "user not found")
+	}
+	return write(c, http.StatusOK, toUser(*user))
+}
+
+func (h *Handler) CreateUser(c echo.Context) error {
+	var in User
+	if err := decode(c, &in); err != nil {
+		return scimError(c, http.StatusBadRequest, errInvalidSyntax, "invalid body")
+	}
+	if in.UserName == "" {
+		return scimError(c, http.StatusBadRequest, errInvalidValue, "userName is required")
+	}
+	active := in.Active == nil || *in.Active
+
+	user, err := h.svc.CreateUser(c.Request().Context(), in.UserName, in.username(), active)
+	if errors.Is(err, repository.ErrUserExists) {
+		return scimError(c, http.StatusConflict, errUniqueness, "userName is already taken")
+	}
+	if err != nil {
+		return h.internalError(c, err, "failed to create user")
+	}
+	out := toUser(*user)
+	c.Response().Header().Set(echo.HeaderLocation, out.Meta.Location)
+	return write(c, http.StatusCreated, out)
+}
+
+// PatchUser supports add and replace of active and the display name. Both
+// are changed by one update, so a failed PATCH changes nothing, and active
+// is changed the way UserService.SetIsActive changes it.
+func (h *Handler) PatchUser(c echo.Context) error {
+	var req PatchRequest
+	if err := decode(c, &req); err != nil {
+		return scimError(c, http.StatusBadRequest, errInvalidSyntax, "invalid body")
+	}
+
+	var username *string
+	var active *bool
+	for _, op := range req.Operations {
+		if o := strings.ToLower(op.Op); o != "add" && o != "replace" {
+			return scimError(c, http.StatusBadRequest, errInvalidPath, fmt.Sprintf("op %q is not supported for users", op.Op))
+		}
+		values := map[string]json.RawMessage{}
+		if op.Path == "" {
+			if err := json.Unmarshal(op.Value, &values); err != nil {
+				return scimError(c, http.StatusBadRequest, errInvalidValue, "value must be an object when path is empty")
+			}
+		} else {
+			values[op.Path] = op.Value
+		}
+
+		for path, value := range values {
+			switch strings.ToLower(path) {
+			case "active":
+				b, err := parseBool(value)
+				if err != nil {
+					return scimError(c, http.StatusBadRequest, errInvalidValue, "active must be a boolean")
+				}
+				active = &b
+			case "displayname", "name.formatted":
+				var s string
+				if err := json.Unmarshal(value, &s); err != nil || s == "" {
+					return scimError(c, http.StatusBadRequest, errInvalidValue, path+" must be a non-empty string")
+				}
+				username = &s
+			case "name":
+				var n Name
+				if err := json.Unmarshal(value, &n); err != nil {
+					return scimError(c, http.StatusBadRequest, errInvalidValue, "name must be an object")
+				}
+				if s := (User{Name: &n}).username(); s != "" {
+					username = &s
+				}
+			default:
+				return scimError(c, http.StatusBadRequest, errInvalidPath, fmt.Sprintf("attribute %q cannot be changed", path))
+			}
+		}
+	}
+
+	if username != nil || active != nil {
+		if _, err := h.svc.UpdateUser(c.Request().Context(), c.Param("id"), username, active); err != nil {
+			return h.userError(c, err, "failed to update user")
+		}
+	}
+	return h.GetUser(c)
+}
+
+// DeleteUser deletes the user the way /users/delete does with mode
+// reassign: open reviews go to teammates, and a user with history is
+// anonymized.
+func (h *Handler) DeleteUser(c echo.Context) error {
+	if _, err := h.svc.DeleteUser(c.Request().Context(), c.Param("id"), api.Reassign); err != nil {
+		return h.userError(c, err, "failed to delete user")
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// userError maps a missing user to 404 and anything else to 500 with msg.
+func (h *Handler) userError(c echo.Context, err error, msg string) error {
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return scimError(c, http.StatusNotFound, "", "user not found")
+	}
+	return h.internalError(c, err, msg)
+}
+
+// parseBool accepts JSON booleans and the "True"/"False" strings some
+// identity providers send.
+func parseBool(raw json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(raw, &b); err == nil {
+		return b, nil
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return false, err
+	}
+	return strconv.ParseBool(s)
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/chimort/avito_test_task/iternal/auth"
+	"github.com/chimort/avito_test_task/iternal/pkg/logger"
+	"github.com/chimort/avito_test_task/iternal/pkg/tracing"
+	"github.com/chimort/avito_test_task/iternal/repository"
+)
+
+// apiKeyPrefix marks issued keys so they are easy to spot in leaked logs
+// and configs.
+const apiKeyPrefix = "prs_"
+
+type APIKeyServiceInterface interface {
+	CreateAPIKey(ctx context.Context, key api.ApiKey) (*api.ApiKey, string, error)
+	ListAPIKeys(ctx context.Context, teamName *string) ([]api.ApiKey, error)
+	RevokeAPIKey(ctx context.Context, id int64, teamName *string) (*api.ApiKey, error)
+}
+
+type APIKeyService struct {
+	repo repository.APIKeyRepo
+	log  *logger.Logger
+}
+
+func NewAPIKeyService(repo repository.APIKeyRepo, log *logger.Logger) *APIKeyService {
+	return &APIKeyService{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// CreateAPIKey stores a new key and returns it together with the secret,
+// which is not recoverable afterwards.
+func (s *APIKeyService) CreateAPIKey(ctx context.Context, key api.ApiKey) (*api.ApiKey, string, error) {
+	ctx, span := tracer.Start(ctx, "APIKeyService.CreateAPIKey")
+	defer span.End()
+
+	s.log.Info("creating api key", "name", key.Name, "role", key.Role, "team_name", key.TeamName)
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		tracing.Fail(span, err)
+		s.log.Error("failed to generate api key", "error", err)
+		return nil, "", err
+	}
+	token := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(buf)
+
+	created, err := s.repo.CreateAPIKey(ctx, key, hashAPIKey(token))
+	if err != nil {
+		if errors.Is(err, repository.ErrTeamNotFound) || errors.Is(err, repository.ErrUserNotFound) {
+			s.log.Warn("api key owner not found", "error", err)
+			return nil, "", err
+		}
+		tracing.Fail(span, err)
+		s.log.Error("failed to create api key", "error", err)
+		return nil, "", err
+	}
+	s.log.Info("api key created", "id", created.Id, "name", created.Name)
+	return created, token, nil
+}
+
+func (s *APIKeyService) ListAPIKeys(ctx context.Context, teamName *string) ([]api.ApiKey, error) {
+	ctx, span := tracer.Start(ctx, "APIKeyService.ListAPIKeys")
+	defer span.End()
+
+	s.log.Info("listing api keys", "team_name", teamName)
+	keys, err := s.repo.ListAPIKeys(ctx, teamName)
+	if err != nil {
+		tracing.Fail(span, err)
+		s.log.Error("failed to list api keys", "error", err)
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (s *APIKeyService) RevokeAPIKey(ctx context.Context, id int64, teamName *string) (*api.ApiKey, error) {
+	ctx, span := tracer.Start(ctx, "APIKeyService.RevokeAPIKey")
+	defer span.End()
+
+	s.log.Info("revoking api key", "id", id)
+	key, err := s.repo.RevokeAPIKey(ctx, id, teamName)
+	if err != nil {
+		if errors.Is(err, repository.ErrAPIKeyNotFound) {
+			s.log.Warn("api key not found", "id", id)
+			return nil, err
+		}
+		tracing.Fail(span, err)
+		s.log.Error("failed to revoke api key", "error", err)
+		return nil, err
+	}
+	s.log.Info("api key revoked", "id", id)
+	return key, nil
+}
+
+// Authenticate implements auth.Authenticator for issued API keys.
+func (s *APIKeyService) Authenticate(ctx context.Context, token string) (*auth.Principal, error) {
+	if !strings.HasPrefix(token, apiKeyPrefix) {
+		return nil, auth.ErrInvalidToken
+	}
+
+	ctx, span := tracer.Start(ctx, "APIKeyService.Authenticate")
+	defer span.End()
+
+	key, err := s.repo.UseAPIKey(ctx, hashAPIKey(token))
+	if err != nil {
+		if errors.Is(err, repository.ErrAPIKeyNotFound) {
+			return nil, auth.ErrInvalidToken
+		}
+		tracing.Fail(span, err)
+		s.log.Error("failed to look up api key", "error", err)
+		return nil, err
+	}
+
+	p := &auth.Principal{Name: key.Name, ID: "apikey:" + strconv.FormatInt(key.Id, 10), Role: auth.Role(key.Role)}
+	if key.UserId != nil {
+		p.UserID = *key.UserId
+	}
+	if key.TeamName != nil {
+		p.Team = *key.TeamName
+	}
+	return p, nil
+}
+
+func hashAPIKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
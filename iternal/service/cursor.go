@@ -0,0 +1,71 @@
+package service
+
+import (
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/chimort/avito_test_task/iternal/repository"
+)
+
+const defaultPageSize = 50
+
+func pageSize(limit *int) int {
+	if limit == nil {
+		return defaultPageSize
+	}
+	return *limit
+}
+
+// nextPage trims items fetched with one extra row to limit and returns the
+// cursor of the last kept item when more rows follow.
+func nextPage[T any](items []T, limit int, cursor func(T) repository.PageCursor) ([]T, string) {
+	if len(items) <= limit {
+		return items, ""
+	}
+	items = items[:limit]
+	return items, encodeCursor(cursor(items[limit-1]))
+}
+
+// encodeCursor turns the last row of a page into an opaque next_cursor.
+func encodeCursor(c repository.PageCursor) string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeCursor returns nil for a missing cursor.
+func decodeCursor(s *string) (*repository.PageCursor, error) {
+	if s == nil {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(*s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	at, id, ok := strings.Cut(string(raw), "|")
+	if !ok || id == "" {
+		return nil, ErrInvalidCursor
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, at)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return &repository.PageCursor{CreatedAt: createdAt, ID: id}, nil
+}
+
+// encodeIDCursor is encodeCursor for lists ordered by id alone.
+func encodeIDCursor(id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(id))
+}
+
+func decodeIDCursor(s *string) (*string, error) {
+	if s == nil {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(*s)
+	if err != nil || len(raw) == 0 {
+		return nil, ErrInvalidCursor
+	}
+	id := string(raw)
+	return &id, nil
+}
@@ -0,0 +1,7 @@
+package service
+
+import (
+	"errors"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
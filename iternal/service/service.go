@@ -3,35 +3,69 @@ package service
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/chimort/avito_test_task/iternal/config"
 	"github.com/chimort/avito_test_task/iternal/pkg/logger"
+	"github.com/chimort/avito_test_task/iternal/pkg/metrics"
+	"github.com/chimort/avito_test_task/iternal/pkg/tracing"
 	"github.com/chimort/avito_test_task/iternal/repository"
+	"go.opentelemetry.io/otel"
 )
 
+var tracer = otel.Tracer("github.com/chimort/avito_test_task/iternal/service")
+
 type UserServiceInterface interface {
 	SetIsActive(ctx context.Context, userID string, isActive bool) (*api.User, error)
 	TeamAdd(ctx context.Context, teamName string, teamMembers []api.TeamMember) (*api.Team, error)
 	GetTeam(ctx context.Context, teamName string) (*api.Team, error)
+	AddMembers(ctx context.Context, teamName string, teamMembers []api.TeamMember) (*api.Team, error)
+	RemoveMembers(ctx context.Context, teamName string, userIDs []string) (*repository.MembersRemoval, error)
+	RenameTeam(ctx context.Context, teamName, newTeamName string) (*api.Team, error)
+	DeleteTeam(ctx context.Context, teamName string) error
+	SyncTeams(ctx context.Context, teams []api.Team, dryRun bool) ([]api.TeamSyncDiff, error)
+	ImportTeams(ctx context.Context, teams []api.Team) (*api.ImportResult, error)
 	PullRequestCreate(ctx context.Context, pullRequestId string, pullRequestName string, authorId string) (*api.PullRequest, error)
 	PullRequestMerge(ctx context.Context, pullRequestId string) (*api.PullRequest, error)
 	PullRequestReassign(ctx context.Context, pullRequestId string, oldUserId string) (*api.PullRequest, string, error)
-	GetPRsByReviewer(ctx context.Context, reviewerId string) ([]*api.PullRequestShort, error)
+	GetPullRequest(ctx context.Context, pullRequestId string) (*api.PullRequest, error)
+	ListPullRequests(ctx context.Context, params api.GetPullRequestListParams) ([]api.PullRequest, string, error)
+	GetUser(ctx context.Context, userID string) (*api.User, error)
+	UpdateUser(ctx context.Context, userID string, username *string, isActive *bool) (*api.User, error)
+	DeleteUser(ctx context.Context, userID string, mode api.UserDeleteMode) (*api.UserDeletion, error)
+	ListUsers(ctx context.Context, params api.GetUsersListParams) ([]api.User, string, error)
+	GetPRsByReviewer(ctx context.Context, reviewerId string, params api.GetUsersGetReviewParams) ([]api.ReviewAssignment, string, error)
+	GetReviewerStats(ctx context.Context, from, to *time.Time) ([]api.ReviewerStats, error)
+	GetTeamStats(ctx context.Context, from, to *time.Time) ([]api.TeamStats, error)
+	GetCycleTimeReport(ctx context.Context, from, to *time.Time) (*api.CycleTimeReport, error)
+	Export(ctx context.Context, from, to *time.Time, sink repository.ExportSink) error
+	UserInTeam(ctx context.Context, userID, teamName string) (bool, error)
+	PullRequestInTeam(ctx context.Context, pullRequestId, teamName string) (bool, error)
+	IsTeamLead(ctx context.Context, userID, teamName string) (bool, error)
+	UsersOutsideTeam(ctx context.Context, teamName string, userIDs []string) ([]string, error)
+	LeadsUser(ctx context.Context, leadID, userID string) (bool, error)
+	LeadsPullRequest(ctx context.Context, leadID, pullRequestId string) (bool, error)
 }
 
 type UserService struct {
-	repo repository.UserRepo
-	log  *logger.Logger
+	repo       repository.UserRepo
+	assignment config.Assignment
+	log        *logger.Logger
 }
 
-func NewUserService(repo repository.UserRepo, log *logger.Logger) *UserService {
+func NewUserService(repo repository.UserRepo, assignment config.Assignment, log *logger.Logger) *UserService {
 	return &UserService{
-		repo: repo,
-		log:  log,
+		repo:       repo,
+		assignment: assignment,
+		log:        log,
 	}
 }
 
 func (s *UserService) TeamAdd(ctx context.Context, teamName string, teamMembers []api.TeamMember) (*api.Team, error) {
+	ctx, span := tracer.Start(ctx, "UserService.TeamAdd")
+	defer span.End()
+
 	s.log.Info("adding team", "team_name", teamName, "members", teamMembers)
 	team, err := s.repo.TeamAdd(ctx, teamName, teamMembers)
 	if err != nil {
@@ -39,6 +73,7 @@ func (s *UserService) TeamAdd(ctx context.Context, teamName string, teamMembers
 			s.log.Warn("team already exists", "team_name", teamName)
 			return nil, repository.ErrTeamExists
 		}
+		tracing.Fail(span, err)
 		s.log.Error("failed to create team", "error", err, "team_name", teamName)
 		return nil, err
 	}
@@ -47,6 +82,9 @@ func (s *UserService) TeamAdd(ctx context.Context, teamName string, teamMembers
 }
 
 func (s *UserService) GetTeam(ctx context.Context, teamName string) (*api.Team, error) {
+	ctx, span := tracer.Start(ctx, "UserService.GetTeam")
+	defer span.End()
+
 	s.log.Info("getting team", "team_name", teamName)
 	team, err := s.repo.GetTeam(ctx, teamName)
 	if err != nil {
@@ -54,6 +92,7 @@ func (s *UserService) GetTeam(ctx context.Context, teamName string) (*api.Team,
 			s.log.Warn("team not found", "team_name", teamName)
 			return nil, repository.ErrTeamNotFound
 		}
+		tracing.Fail(span, err)
 		s.log.Error("failed to get team", "error", err, "team_name", teamName)
 		return nil, err
 	}
@@ -62,9 +101,17 @@ func (s *UserService) GetTeam(ctx context.Context, teamName string) (*api.Team,
 }
 
 func (s *UserService) SetIsActive(ctx context.Context, userID string, isActive bool) (*api.User, error) {
+	ctx, span := tracer.Start(ctx, "UserService.SetIsActive")
+	defer span.End()
+
 	s.log.Info("updating user active status", "user_id", userID, "active", isActive)
 	user, err := s.repo.UpdateActive(ctx, userID, isActive)
 	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			s.log.Warn("user not found", "user_id", userID)
+			return nil, err
+		}
+		tracing.Fail(span, err)
 		s.log.Error("failed to update user active status", "error", err)
 		return nil, err
 	}
@@ -73,45 +120,327 @@ func (s *UserService) SetIsActive(ctx context.Context, userID string, isActive b
 }
 
 func (s *UserService) PullRequestCreate(ctx context.Context, pullRequestId string, pullRequestName string, authorId string) (*api.PullRequest, error) {
+	ctx, span := tracer.Start(ctx, "UserService.PullRequestCreate")
+	defer span.End()
+
 	s.log.Info("creating pull request", "pr_id", pullRequestId, "pr_name", pullRequestName, "author_id", authorId)
-	pr, err := s.repo.PullRequestCreate(ctx, pullRequestId, pullRequestName, authorId)
+	pr, err := s.repo.PullRequestCreate(ctx, pullRequestId, pullRequestName, authorId, s.assignment.ReviewersPerPR)
 	if err != nil {
+		tracing.Fail(span, err)
 		s.log.Error("failed to create pull request", "error", err)
 		return nil, err
 	}
+	metrics.PRsCreated.Inc()
 	s.log.Info("pull request created", "pr_id", pullRequestId)
 	return pr, nil
 }
 
 func (s *UserService) PullRequestMerge(ctx context.Context, pullRequestId string) (*api.PullRequest, error) {
+	ctx, span := tracer.Start(ctx, "UserService.PullRequestMerge")
+	defer span.End()
+
 	s.log.Info("merging pull request", "pr_id", pullRequestId)
-	pr, err := s.repo.PullRequestMerge(ctx, pullRequestId)
+	pr, merged, err := s.repo.PullRequestMerge(ctx, pullRequestId)
 	if err != nil {
+		tracing.Fail(span, err)
 		s.log.Error("failed to merge pull request", "error", err)
 		return nil, err
 	}
+	if !merged {
+		s.log.Info("pull request already merged", "pr_id", pullRequestId)
+		return pr, nil
+	}
+	metrics.PRsMerged.Inc()
 	s.log.Info("pull request merged", "pr_id", pullRequestId)
 	return pr, nil
 }
 
 func (s *UserService) PullRequestReassign(ctx context.Context, pullRequestId string, oldUserId string) (*api.PullRequest, string, error) {
+	ctx, span := tracer.Start(ctx, "UserService.PullRequestReassign")
+	defer span.End()
+
 	s.log.Info("reassign pull request", "pr_id", pullRequestId, "by_user", oldUserId)
-	pr, newUserId, err := s.repo.PullRequestReassign(ctx, pullRequestId, oldUserId)
+	pr, newUserId, err := s.repo.PullRequestReassign(ctx, pullRequestId, oldUserId, s.assignment.MaxReassignmentsPerHour)
 	if err != nil {
+		var limitErr *repository.ReassignLimitError
+		if errors.As(err, &limitErr) {
+			s.log.Warn("reassignment limit reached", "pr_id", pullRequestId, "retry_after", limitErr.RetryAfter.String())
+			return nil, "", err
+		}
+		if errors.Is(err, repository.ErrNoCandidates) {
+			metrics.NoCandidateFailures.Inc()
+		}
+		tracing.Fail(span, err)
 		s.log.Error("failed to reassign pull request", "error", err)
 		return nil, "", err
 	}
+	metrics.PRsReassigned.Inc()
 	s.log.Info("pull request reassigned", "pr_id", pullRequestId, "new_user", newUserId)
 	return pr, newUserId, nil
 }
 
-func (s *UserService) GetPRsByReviewer(ctx context.Context, reviewerId string) ([]*api.PullRequestShort, error) {
-	s.log.Info("getting PRs for reviewers", "reviewer_id", reviewerId)
-	prs, err := s.repo.GetPRsByReviewer(ctx, reviewerId)
+func (s *UserService) GetPullRequest(ctx context.Context, pullRequestId string) (*api.PullRequest, error) {
+	ctx, span := tracer.Start(ctx, "UserService.GetPullRequest")
+	defer span.End()
+
+	s.log.Info("getting pull request", "pr_id", pullRequestId)
+	pr, err := s.repo.GetPullRequest(ctx, pullRequestId)
+	if err != nil {
+		if errors.Is(err, repository.ErrPRNotFound) {
+			s.log.Warn("pull request not found", "pr_id", pullRequestId)
+			return nil, err
+		}
+		tracing.Fail(span, err)
+		s.log.Error("failed to get pull request", "error", err)
+		return nil, err
+	}
+	return pr, nil
+}
+
+// ListPullRequests returns one page of pull requests matching params and
+// the cursor of the next one, empty on the last page.
+func (s *UserService) ListPullRequests(ctx context.Context, params api.GetPullRequestListParams) ([]api.PullRequest, string, error) {
+	ctx, span := tracer.Start(ctx, "UserService.ListPullRequests")
+	defer span.End()
+
+	after, err := decodeCursor(params.Cursor)
 	if err != nil {
+		s.log.Warn("invalid cursor", "cursor", *params.Cursor)
+		return nil, "", err
+	}
+	filter := repository.PullRequestFilter{
+		AuthorID:      params.AuthorId,
+		TeamName:      params.TeamName,
+		Status:        (*string)(params.Status),
+		ReviewerID:    params.ReviewerId,
+		Title:         params.Title,
+		CreatedAfter:  params.CreatedAfter,
+		CreatedBefore: params.CreatedBefore,
+		Ascending:     params.Order != nil && *params.Order == api.Asc,
+		Limit:         pageSize(params.Limit),
+		After:         after,
+	}
+
+	s.log.Info("listing pull requests", "limit", filter.Limit)
+	limit := filter.Limit
+	filter.Limit++
+	prs, err := s.repo.ListPullRequests(ctx, filter)
+	if err != nil {
+		tracing.Fail(span, err)
+		s.log.Error("failed to list pull requests", "error", err)
+		return nil, "", err
+	}
+
+	prs, next := nextPage(prs, limit, func(pr api.PullRequest) repository.PageCursor {
+		return repository.PageCursor{CreatedAt: *pr.CreatedAt, ID: pr.PullRequestId}
+	})
+	s.log.Info("listed pull requests", "count", len(prs), "has_more", next != "")
+	return prs, next, nil
+}
+
+// GetPRsByReviewer returns one page of the reviewer's pull requests and
+// the cursor of the next one, empty on the last page.
+func (s *UserService) GetPRsByReviewer(ctx context.Context, reviewerId string, params api.GetUsersGetReviewParams) ([]api.ReviewAssignment, string, error) {
+	ctx, span := tracer.Start(ctx, "UserService.GetPRsByReviewer")
+	defer span.End()
+
+	after, err := decodeCursor(params.Cursor)
+	if err != nil {
+		s.log.Warn("invalid cursor", "reviewer_id", reviewerId, "cursor", *params.Cursor)
+		return nil, "", err
+	}
+	filter := repository.ReviewFilter{
+		Status:        (*string)(params.Status),
+		CreatedAfter:  params.CreatedAfter,
+		CreatedBefore: params.CreatedBefore,
+		Ascending:     params.Order != nil && *params.Order == api.Asc,
+		Limit:         pageSize(params.Limit),
+		After:         after,
+	}
+
+	s.log.Info("getting PRs for reviewers", "reviewer_id", reviewerId, "limit", filter.Limit)
+	limit := filter.Limit
+	filter.Limit++
+	prs, err := s.repo.GetPRsByReviewer(ctx, reviewerId, filter)
+	if err != nil {
+		tracing.Fail(span, err)
 		s.log.Error("failed to get PRs for reviewers", "error", err)
+		return nil, "", err
+	}
+
+	prs, next := nextPage(prs, limit, func(pr api.ReviewAssignment) repository.PageCursor {
+		return repository.PageCursor{CreatedAt: pr.CreatedAt, ID: pr.PullRequestId}
+	})
+	s.log.Info("got PRs for reviewers", "count", len(prs), "has_more", next != "")
+	return prs, next, nil
+}
+
+func (s *UserService) GetReviewerStats(ctx context.Context, from, to *time.Time) ([]api.ReviewerStats, error) {
+	ctx, span := tracer.Start(ctx, "UserService.GetReviewerStats")
+	defer span.End()
+
+	s.log.Info("getting reviewer stats", "from", from, "to", to)
+	stats, err := s.repo.GetReviewerStats(ctx, from, to)
+	if err != nil {
+		tracing.Fail(span, err)
+		s.log.Error("failed to get reviewer stats", "error", err)
+		return nil, err
+	}
+	s.log.Info("got reviewer stats", "reviewers", len(stats))
+	return stats, nil
+}
+
+func (s *UserService) GetTeamStats(ctx context.Context, from, to *time.Time) ([]api.TeamStats, error) {
+	ctx, span := tracer.Start(ctx, "UserService.GetTeamStats")
+	defer span.End()
+
+	s.log.Info("getting team stats", "from", from, "to", to)
+	stats, err := s.repo.GetTeamStats(ctx, from, to)
+	if err != nil {
+		tracing.Fail(span, err)
+		s.log.Error("failed to get team stats", "error", err)
+		return nil, err
+	}
+	s.log.Info("got team stats", "teams", len(stats))
+	return stats, nil
+}
+
+func (s *UserService) GetCycleTimeReport(ctx context.Context, from, to *time.Time) (*api.CycleTimeReport, error) {
+	ctx, span := tracer.Start(ctx, "UserService.GetCycleTimeReport")
+	defer span.End()
+
+	s.log.Info("getting cycle time report", "from", from, "to", to)
+	report, err := s.repo.GetCycleTimeReport(ctx, from, to)
+	if err != nil {
+		tracing.Fail(span, err)
+		s.log.Error("failed to get cycle time report", "error", err)
+		return nil, err
+	}
+	s.log.Info("got cycle time report", "teams", len(report.ByTeam), "weeks", len(report.Weekly))
+	return report, nil
+}
+
+func (s *UserService) Export(ctx context.Context, from, to *time.Time, sink repository.ExportSink) error {
+	ctx, span := tracer.Start(ctx, "UserService.Export")
+	defer span.End()
+
+	s.log.Info("exporting data", "from", from, "to", to)
+	if err := s.repo.Export(ctx, from, to, sink); err != nil {
+		tracing.Fail(span, err)
+		s.log.Error("failed to export data", "error", err)
+		return err
+	}
+	s.log.Info("exported data")
+	return nil
+}
+
+func (s *UserService) Backup(ctx context.Context, sink repository.BackupSink) error {
+	ctx, span := tracer.Start(ctx, "UserService.Backup")
+	defer span.End()
+
+	s.log.Info("backing up database")
+	if err := s.repo.Backup(ctx, sink); err != nil {
+		tracing.Fail(span, err)
+		s.log.Error("failed to back up database", "error", err)
+		return err
+	}
+	s.log.Info("backed up database")
+	return nil
+}
+
+func (s *UserService) Restore(ctx context.Context, src repository.RestoreSource) (map[string]int, error) {
+	ctx, span := tracer.Start(ctx, "UserService.Restore")
+	defer span.End()
+
+	s.log.Info("restoring database")
+	counts, err := s.repo.Restore(ctx, src)
+	if err != nil {
+		if errors.Is(err, repository.ErrDatabaseNotEmpty) {
+			s.log.Warn("refusing to restore into a non-empty database")
+			return nil, err
+		}
+		tracing.Fail(span, err)
+		s.log.Error("failed to restore database", "error", err)
 		return nil, err
 	}
-	s.log.Info("got PRs for reviewers", "prs", prs)
-	return prs, nil
+	s.log.Info("restored database", "rows", counts)
+	return counts, nil
+}
+
+func (s *UserService) UserInTeam(ctx context.Context, userID, teamName string) (bool, error) {
+	ctx, span := tracer.Start(ctx, "UserService.UserInTeam")
+	defer span.End()
+
+	ok, err := s.repo.UserInTeam(ctx, userID, teamName)
+	if err != nil {
+		tracing.Fail(span, err)
+		s.log.Error("failed to check team membership", "error", err, "user_id", userID, "team_name", teamName)
+		return false, err
+	}
+	return ok, nil
+}
+
+func (s *UserService) PullRequestInTeam(ctx context.Context, pullRequestId, teamName string) (bool, error) {
+	ctx, span := tracer.Start(ctx, "UserService.PullRequestInTeam")
+	defer span.End()
+
+	ok, err := s.repo.PullRequestInTeam(ctx, pullRequestId, teamName)
+	if err != nil {
+		tracing.Fail(span, err)
+		s.log.Error("failed to check pull request team", "error", err, "pr_id", pullRequestId, "team_name", teamName)
+		return false, err
+	}
+	return ok, nil
+}
+
+func (s *UserService) IsTeamLead(ctx context.Context, userID, teamName string) (bool, error) {
+	ctx, span := tracer.Start(ctx, "UserService.IsTeamLead")
+	defer span.End()
+
+	ok, err := s.repo.IsTeamLead(ctx, userID, teamName)
+	if err != nil {
+		tracing.Fail(span, err)
+		s.log.Error("failed to check team lead", "error", err, "user_id", userID, "team_name", teamName)
+		return false, err
+	}
+	return ok, nil
+}
+
+func (s *UserService) UsersOutsideTeam(ctx context.Context, teamName string, userIDs []string) ([]string, error) {
+	ctx, span := tracer.Start(ctx, "UserService.UsersOutsideTeam")
+	defer span.End()
+
+	ids, err := s.repo.UsersOutsideTeam(ctx, teamName, userIDs)
+	if err != nil {
+		tracing.Fail(span, err)
+		s.log.Error("failed to check team members", "error", err, "team_name", teamName)
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (s *UserService) LeadsUser(ctx context.Context, leadID, userID string) (bool, error) {
+	ctx, span := tracer.Start(ctx, "UserService.LeadsUser")
+	defer span.End()
+
+	ok, err := s.repo.LeadsUser(ctx, leadID, userID)
+	if err != nil {
+		tracing.Fail(span, err)
+		s.log.Error("failed to check team lead", "error", err, "lead_id", leadID, "user_id", userID)
+		return false, err
+	}
+	return ok, nil
+}
+
+func (s *UserService) LeadsPullRequest(ctx context.Context, leadID, pullRequestId string) (bool, error) {
+	ctx, span := tracer.Start(ctx, "UserService.LeadsPullRequest")
+	defer span.End()
+
+	ok, err := s.repo.LeadsPullRequest(ctx, leadID, pullRequestId)
+	if err != nil {
+		tracing.Fail(span, err)
+		s.log.Error("failed to check team lead", "error", err, "lead_id", leadID, "pr_id", pullRequestId)
+		return false, err
+	}
+	return ok, nil
 }
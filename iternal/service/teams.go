@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/chimort/avito_test_task/iternal/pkg/tracing"
+	"github.com/chimort/avito_test_task/iternal/repository"
+)
+
+func (s *UserService) AddMembers(ctx context.Context, teamName string, teamMembers []api.TeamMember) (*api.Team, error) {
+	ctx, span := tracer.Start(ctx, "UserService.AddMembers")
+	defer span.End()
+
+	s.log.Info("adding team members", "team_name", teamName, "members", teamMembers)
+	team, err := s.repo.AddMembers(ctx, teamName, teamMembers)
+	if err != nil {
+		if errors.Is(err, repository.ErrTeamNotFound) {
+			s.log.Warn("team not found", "team_name", teamName)
+			return nil, err
+		}
+		tracing.Fail(span, err)
+		s.log.Error("failed to add team members", "error", err)
+		return nil, err
+	}
+	s.log.Info("team members added", "team_name", teamName, "size", len(team.Members))
+	return team, nil
+}
+
+func (s *UserService) RemoveMembers(ctx context.Context, teamName string, userIDs []string) (*repository.MembersRemoval, error) {
+	ctx, span := tracer.Start(ctx, "UserService.RemoveMembers")
+	defer span.End()
+
+	unique := dedupe(userIDs)
+
+	s.log.Info("removing team members", "team_name", teamName, "user_ids", unique)
+	res, err := s.repo.RemoveMembers(ctx, teamName, unique)
+	if err != nil {
+		if errors.Is(err, repository.ErrTeamNotFound) || errors.Is(err, repository.ErrUserNotFound) {
+			s.log.Warn("failed to remove team members", "team_name", teamName, "error", err)
+			return nil, err
+		}
+		tracing.Fail(span, err)
+		s.log.Error("failed to remove team members", "error", err)
+		return nil, err
+	}
+	s.log.Info("team members removed", "team_name", teamName,
+		"reassigned_reviews", res.ReassignedReviews, "removed_reviews", res.RemovedReviews)
+	return res, nil
+}
+
+func (s *UserService) RenameTeam(ctx context.Context, teamName, newTeamName string) (*api.Team, error) {
+	ctx, span := tracer.Start(ctx, "UserService.RenameTeam")
+	defer span.End()
+
+	s.log.Info("renaming team", "team_name", teamName, "new_team_name", newTeamName)
+	team, err := s.repo.RenameTeam(ctx, teamName, newTeamName)
+	if err != nil {
+		if errors.Is(err, repository.ErrTeamNotFound) || errors.Is(err, repository.ErrTeamExists) {
+			s.log.Warn("failed to rename team", "team_name", teamName, "error", err)
+			return nil, err
+		}
+		tracing.Fail(span, err)
+		s.log.Error("failed to rename team", "error", err)
+		return nil, err
+	}
+	return team, nil
+}
+
+func (s *UserService) DeleteTeam(ctx context.Context, teamName string) error {
+	ctx, span := tracer.Start(ctx, "UserService.DeleteTeam")
+	defer span.End()
+
+	s.log.Info("deleting team", "team_name", teamName)
+	if err := s.repo.DeleteTeam(ctx, teamName); err != nil {
+		if errors.Is(err, repository.ErrTeamNotFound) {
+			s.log.Warn("team not found", "team_name", teamName)
+			return err
+		}
+		tracing.Fail(span, err)
+		s.log.Error("failed to delete team", "error", err)
+		return err
+	}
+	s.log.Info("team deleted", "team_name", teamName)
+	return nil
+}
+
+func (s *UserService) SyncTeams(ctx context.Context, teams []api.Team, dryRun bool) ([]api.TeamSyncDiff, error) {
+	ctx, span := tracer.Start(ctx, "UserService.SyncTeams")
+	defer span.End()
+
+	s.log.Info("syncing teams", "teams", len(teams), "dry_run", dryRun)
+	diffs, err := s.repo.SyncTeams(ctx, teams, dryRun)
+	if err != nil {
+		tracing.Fail(span, err)
+		s.log.Error("failed to sync teams", "error", err)
+		return nil, err
+	}
+	for _, d := range diffs {
+		s.log.Info("team synced", "team_name", d.TeamName, "dry_run", dryRun, "created", d.Created,
+			"added", len(d.Added), "removed", len(d.Removed),
+			"activated", len(d.Activated), "deactivated", len(d.Deactivated))
+	}
+	return diffs, nil
+}
+
+func (s *UserService) ImportTeams(ctx context.Context, teams []api.Team) (*api.ImportResult, error) {
+	ctx, span := tracer.Start(ctx, "UserService.ImportTeams")
+	defer span.End()
+
+	s.log.Info("importing teams", "teams", len(teams))
+	res, err := s.repo.ImportTeams(ctx, teams)
+	if err != nil {
+		tracing.Fail(span, err)
+		s.log.Error("failed to import teams", "error", err)
+		return nil, err
+	}
+	s.log.Info("teams imported", "teams_created", res.TeamsCreated, "users", res.Users, "members_added", res.MembersAdded)
+	return res, nil
+}
+
+func (s *UserService) CreateTeam(ctx context.Context, teamName string, userIDs []string) (*api.Team, error) {
+	ctx, span := tracer.Start(ctx, "UserService.CreateTeam")
+	defer span.End()
+
+	s.log.Info("creating team", "team_name", teamName, "user_ids", userIDs)
+	team, err := s.repo.CreateTeam(ctx, teamName, dedupe(userIDs))
+	if err != nil {
+		if errors.Is(err, repository.ErrTeamExists) || errors.Is(err, repository.ErrUserNotFound) {
+			s.log.Warn("failed to create team", "team_name", teamName, "error", err)
+			return nil, err
+		}
+		tracing.Fail(span, err)
+		s.log.Error("failed to create team", "error", err)
+		return nil, err
+	}
+	return team, nil
+}
+
+func (s *UserService) AddTeamUsers(ctx context.Context, teamName string, userIDs []string) (*api.Team, error) {
+	ctx, span := tracer.Start(ctx, "UserService.AddTeamUsers")
+	defer span.End()
+
+	s.log.Info("adding users to team", "team_name", teamName, "user_ids", userIDs)
+	team, err := s.repo.AddTeamUsers(ctx, teamName, dedupe(userIDs))
+	if err != nil {
+		if errors.Is(err, repository.ErrTeamNotFound) || errors.Is(err, repository.ErrUserNotFound) {
+			s.log.Warn("failed to add users to team", "team_name", teamName, "error", err)
+			return nil, err
+		}
+		tracing.Fail(span, err)
+		s.log.Error("failed to add users to team", "error", err)
+		return nil, err
+	}
+	return team, nil
+}
+
+// PatchTeam renames the team, adds and removes members in one transaction.
+func (s *UserService) PatchTeam(ctx context.Context, teamName, newTeamName string, add, remove []string) (*repository.MembersRemoval, error) {
+	ctx, span := tracer.Start(ctx, "UserService.PatchTeam")
+	defer span.End()
+
+	s.log.Info("patching team", "team_name", teamName, "new_team_name", newTeamName, "add", add, "remove", remove)
+	res, err := s.repo.PatchTeam(ctx, teamName, newTeamName, dedupe(add), dedupe(remove))
+	if err != nil {
+		if errors.Is(err, repository.ErrTeamNotFound) || errors.Is(err, repository.ErrTeamExists) || errors.Is(err, repository.ErrUserNotFound) {
+			s.log.Warn("failed to patch team", "team_name", teamName, "error", err)
+			return nil, err
+		}
+		tracing.Fail(span, err)
+		s.log.Error("failed to patch team", "error", err)
+		return nil, err
+	}
+	s.log.Info("team patched", "team_name", newTeamName,
+		"reassigned_reviews", res.ReassignedReviews, "removed_reviews", res.RemovedReviews)
+	return res, nil
+}
+
+// FindTeams pages teams by offset and also returns how many match in total.
+func (s *UserService) FindTeams(ctx context.Context, filter repository.TeamFilter) ([]api.Team, int, error) {
+	ctx, span := tracer.Start(ctx, "UserService.FindTeams")
+	defer span.End()
+
+	s.log.Info("finding teams", "offset", filter.Offset, "limit", filter.Limit)
+	total, err := s.repo.CountTeams(ctx, filter)
+	if err != nil {
+		tracing.Fail(span, err)
+		s.log.Error("failed to count teams", "error", err)
+		return nil, 0, err
+	}
+	teams, err := s.repo.ListTeams(ctx, filter)
+	if err != nil {
+		tracing.Fail(span, err)
+		s.log.Error("failed to find teams", "error", err)
+		return nil, 0, err
+	}
+	return teams, total, nil
+}
+
+func dedupe(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	unique := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
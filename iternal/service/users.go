@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/chimort/avito_test_task/iternal/pkg/tracing"
+	"github.com/chimort/avito_test_task/iternal/repository"
+)
+
+func (s *UserService) GetUser(ctx context.Context, userID string) (*api.User, error) {
+	ctx, span := tracer.Start(ctx, "UserService.GetUser")
+	defer span.End()
+
+	s.log.Info("getting user", "user_id", userID)
+	user, err := s.repo.GetUser(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			s.log.Warn("user not found", "user_id", userID)
+			return nil, err
+		}
+		tracing.Fail(span, err)
+		s.log.Error("failed to get user", "error", err)
+		return nil, err
+	}
+	return user, nil
+}
+
+// UpdateUser changes the name and active flag together. The flag goes
+// through the same repository code as SetIsActive.
+func (s *UserService) UpdateUser(ctx context.Context, userID string, username *string, isActive *bool) (*api.User, error) {
+	ctx, span := tracer.Start(ctx, "UserService.UpdateUser")
+	defer span.End()
+
+	s.log.Info("updating user", "user_id", userID)
+	if isActive != nil {
+		s.log.Info("updating user active status", "user_id", userID, "active", *isActive)
+	}
+	user, err := s.repo.UpdateUser(ctx, userID, username, isActive)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			s.log.Warn("user not found", "user_id", userID)
+			return nil, err
+		}
+		tracing.Fail(span, err)
+		s.log.Error("failed to update user", "error", err)
+		return nil, err
+	}
+	s.log.Info("user updated", "user", user)
+	return user, nil
+}
+
+func (s *UserService) DeleteUser(ctx context.Context, userID string, mode api.UserDeleteMode) (*api.UserDeletion, error) {
+	ctx, span := tracer.Start(ctx, "UserService.DeleteUser")
+	defer span.End()
+
+	s.log.Info("deleting user", "user_id", userID, "mode", mode)
+	res, err := s.repo.DeleteUser(ctx, userID, repository.DeleteMode(mode))
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrUserNotFound):
+			s.log.Warn("user not found", "user_id", userID)
+		case errors.Is(err, repository.ErrUserHasActivity):
+			s.log.Warn("user has activity, deletion blocked", "user_id", userID)
+		default:
+			tracing.Fail(span, err)
+			s.log.Error("failed to delete user", "error", err)
+		}
+		return nil, err
+	}
+
+	s.log.Info("user deleted", "user_id", userID, "anonymized", res.Anonymized,
+		"reassigned_reviews", res.ReassignedReviews, "removed_reviews", res.RemovedReviews)
+	return &api.UserDeletion{
+		UserId:            userID,
+		Mode:              mode,
+		Anonymized:        res.Anonymized,
+		ReassignedReviews: res.ReassignedReviews,
+		RemovedReviews:    res.RemovedReviews,
+	}, nil
+}
+
+// ListUsers returns one page of users ordered by user_id and the cursor of
+// the next one, empty on the last page.
+func (s *UserService) ListUsers(ctx context.Context, params api.GetUsersListParams) ([]api.User, string, error) {
+	ctx, span := tracer.Start(ctx, "UserService.ListUsers")
+	defer span.End()
+
+	after, err := decodeIDCursor(params.Cursor)
+	if err != nil {
+		s.log.Warn("invalid cursor", "cursor", *params.Cursor)
+		return nil, "", err
+	}
+	filter := repository.UserFilter{
+		TeamName:       params.TeamName,
+		IsActive:       params.IsActive,
+		Username:       params.Username,
+		IncludeDeleted: params.IncludeDeleted != nil && *params.IncludeDeleted,
+		Limit:          pageSize(params.Limit),
+		After:          after,
+	}
+
+	s.log.Info("listing users", "limit", filter.Limit)
+	limit := filter.Limit
+	filter.Limit++
+	users, err := s.repo.ListUsers(ctx, filter)
+	if err != nil {
+		tracing.Fail(span, err)
+		s.log.Error("failed to list users", "error", err)
+		return nil, "", err
+	}
+
+	var next string
+	if len(users) > limit {
+		users = users[:limit]
+		next = encodeIDCursor(users[limit-1].UserId)
+	}
+	s.log.Info("listed users", "count", len(users), "has_more", next != "")
+	return users, next, nil
+}
+
+func (s *UserService) CreateUser(ctx context.Context, userID, username string, isActive bool) (*api.User, error) {
+	ctx, span := tracer.Start(ctx, "UserService.CreateUser")
+	defer span.End()
+
+	s.log.Info("creating user", "user_id", userID, "active", isActive)
+	user, err := s.repo.CreateUser(ctx, userID, username, isActive)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserExists) {
+			s.log.Warn("user already exists", "user_id", userID)
+			return nil, err
+		}
+		tracing.Fail(span, err)
+		s.log.Error("failed to create user", "error", err)
+		return nil, err
+	}
+	return user, nil
+}
+
+// FindUsers pages users by offset and also returns how many match in
+// total, as SCIM list responses require.
+func (s *UserService) FindUsers(ctx context.Context, filter repository.UserFilter) ([]api.User, int, error) {
+	ctx, span := tracer.Start(ctx, "UserService.FindUsers")
+	defer span.End()
+
+	s.log.Info("finding users", "offset", filter.Offset, "limit", filter.Limit)
+	total, err := s.repo.CountUsers(ctx, filter)
+	if err != nil {
+		tracing.Fail(span, err)
+		s.log.Error("failed to count users", "error", err)
+		return nil, 0, err
+	}
+	users, err := s.repo.ListUsers(ctx, filter)
+	if err != nil {
+		tracing.Fail(span, err)
+		s.log.Error("failed to find users", "error", err)
+		return nil, 0, err
+	}
+	return users, total, nil
+}
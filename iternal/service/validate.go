@@ -0,0 +1,98 @@
+package service
+
+import (
+	"time"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+)
+
+// The Validate functions return a message describing the first problem
+// with a request, or "" if it is fine. The HTTP and gRPC APIs answer the
+// message as a bad request.
+
+func ValidateMember(m api.TeamMember) string {
+	switch {
+	case m.UserId == "" || m.Username == "":
+		return "user_id and username are required"
+	case m.Role != nil && *m.Role != api.Lead && *m.Role != api.Member:
+		return "role must be lead or member"
+	}
+	return ""
+}
+
+// ValidateMembers checks a team name with the members to add to it.
+func ValidateMembers(teamName string, members []api.TeamMember) string {
+	if teamName == "" || len(members) == 0 {
+		return "team_name and members are required"
+	}
+	for _, m := range members {
+		if msg := ValidateMember(m); msg != "" {
+			return msg
+		}
+	}
+	return ""
+}
+
+// ValidateSync checks a sync request. A user may appear in several teams
+// but must have the same is_active everywhere.
+func ValidateSync(teams []api.Team) string {
+	if len(teams) == 0 {
+		return "teams is required"
+	}
+	seenTeams := make(map[string]bool, len(teams))
+	active := make(map[string]bool)
+	for _, team := range teams {
+		if team.TeamName == "" {
+			return "team_name is required"
+		}
+		if seenTeams[team.TeamName] {
+			return "duplicate team " + team.TeamName
+		}
+		seenTeams[team.TeamName] = true
+
+		seenUsers := make(map[string]bool, len(team.Members))
+		for _, m := range team.Members {
+			if msg := ValidateMember(m); msg != "" {
+				return msg
+			}
+			if seenUsers[m.UserId] {
+				return "duplicate user " + m.UserId + " in team " + team.TeamName
+			}
+			seenUsers[m.UserId] = true
+			if was, ok := active[m.UserId]; ok && was != m.IsActive {
+				return "conflicting is_active for user " + m.UserId
+			}
+			active[m.UserId] = m.IsActive
+		}
+	}
+	return ""
+}
+
+// ValidatePage checks the filters shared by the paginated list endpoints.
+func ValidatePage(status *string, order *api.SortOrder, limit *int, createdAfter, createdBefore *time.Time) string {
+	switch {
+	case status != nil && *status != string(api.PullRequestStatusOPEN) && *status != string(api.PullRequestStatusMERGED):
+		return "status must be OPEN or MERGED"
+	case order != nil && *order != api.Asc && *order != api.Desc:
+		return "order must be asc or desc"
+	case limit != nil && (*limit < 1 || *limit > 100):
+		return "limit must be between 1 and 100"
+	case createdAfter != nil && createdBefore != nil && !createdAfter.Before(*createdBefore):
+		return "created_after must be before created_before"
+	}
+	return ""
+}
+
+func ValidateAPIKey(body api.PostApiKeysCreateJSONRequestBody) string {
+	switch {
+	case body.Name == "":
+		return "name is required"
+	case body.Role != api.PostApiKeysCreateJSONBodyRoleAdmin && body.Role != api.PostApiKeysCreateJSONBodyRoleUser:
+		return "role must be admin or user"
+	case body.Role == api.PostApiKeysCreateJSONBodyRoleUser && (body.UserId == nil || *body.UserId == ""):
+		return "user_id is required for the user role"
+	case body.ExpiresAt != nil && !body.ExpiresAt.After(time.Now()):
+		return "expires_at must be in the future"
+	}
+	return ""
+}
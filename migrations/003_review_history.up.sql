@@ -0,0 +1,9 @@
+create table if not exists pr_reassignments (
+    id bigserial PRIMARY KEY,
+    pr_id text not null references pull_requests(id) on delete cascade,
+    old_reviewer_id text not null,
+    new_reviewer_id text not null,
+    reassigned_at timestamp with time zone not null DEFAULT CURRENT_TIMESTAMP
+);
+
+create index if not exists pr_reassignments_reassigned_at_idx on pr_reassignments (reassigned_at);
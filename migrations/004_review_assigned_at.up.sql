@@ -0,0 +1,7 @@
+alter table pr_reviewers
+    add column if not exists assigned_at timestamp with time zone not null DEFAULT CURRENT_TIMESTAMP;
+
+update pr_reviewers prr
+set assigned_at = pr.created_at
+from pull_requests pr
+where pr.id = prr.pr_id;
@@ -0,0 +1 @@
+DROP TABLE if exists api_keys;
@@ -0,0 +1,12 @@
+create table if not exists api_keys (
+    id bigserial PRIMARY KEY,
+    name text not null,
+    key_hash text not null unique,
+    role text not null check(role in ('admin', 'user')),
+    user_id text references users(id) on delete cascade,
+    team_name text references team(name) on delete cascade,
+    expires_at timestamp with time zone DEFAULT NULL,
+    created_at timestamp with time zone not null DEFAULT CURRENT_TIMESTAMP,
+    last_used_at timestamp with time zone DEFAULT NULL,
+    revoked_at timestamp with time zone DEFAULT NULL
+);
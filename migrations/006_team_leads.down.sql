@@ -0,0 +1 @@
+alter table user_teams drop column if exists role;
@@ -0,0 +1,2 @@
+alter table user_teams
+    add column if not exists role text not null DEFAULT 'member' check(role in ('member', 'lead'));
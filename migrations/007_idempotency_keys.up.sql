@@ -0,0 +1,13 @@
+create table if not exists idempotency_keys (
+    scope text not null,
+    key text not null,
+    request_hash text not null,
+    status_code int DEFAULT NULL,
+    content_type text DEFAULT NULL,
+    response_body bytea DEFAULT NULL,
+    created_at timestamp with time zone not null DEFAULT CURRENT_TIMESTAMP,
+    expires_at timestamp with time zone not null,
+    PRIMARY KEY(scope, key)
+);
+
+create index if not exists idempotency_keys_expires_at_idx on idempotency_keys (expires_at);
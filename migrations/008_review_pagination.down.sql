@@ -0,0 +1,2 @@
+DROP INDEX if exists pull_requests_created_at_id_idx;
+DROP INDEX if exists pr_reviewers_reviewer_id_idx;
@@ -0,0 +1,3 @@
+create index if not exists pr_reviewers_reviewer_id_idx on pr_reviewers (reviewer_id);
+
+create index if not exists pull_requests_created_at_id_idx on pull_requests (created_at, id);
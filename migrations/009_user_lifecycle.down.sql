@@ -0,0 +1,9 @@
+alter table pr_reviewers drop constraint if exists pr_reviewers_reviewer_id_fkey;
+alter table pr_reviewers
+    add constraint pr_reviewers_reviewer_id_fkey foreign key (reviewer_id) references users(id) on delete cascade;
+
+alter table pull_requests drop constraint if exists pull_requests_author_id_fkey;
+alter table pull_requests
+    add constraint pull_requests_author_id_fkey foreign key (author_id) references users(id) on delete cascade;
+
+alter table users drop column if exists deleted_at;
@@ -0,0 +1,7 @@
+alter table user_teams drop constraint if exists user_teams_team_name_fkey;
+alter table user_teams
+    add constraint user_teams_team_name_fkey foreign key (team_name) references team(name) on delete cascade on update cascade;
+
+alter table api_keys drop constraint if exists api_keys_team_name_fkey;
+alter table api_keys
+    add constraint api_keys_team_name_fkey foreign key (team_name) references team(name) on delete cascade on update cascade;
@@ -0,0 +1,2 @@
+alter table idempotency_keys
+    add column if not exists locked_until timestamp with time zone DEFAULT NULL;
@@ -0,0 +1,4 @@
+// Package prv1 is the gRPC API of the service, generated from pr.proto.
+package prv1
+
+//go:generate protoc -I ../.. --go_out=../.. --go_opt=paths=source_relative --go-grpc_out=../.. --go-grpc_opt=paths=source_relative pr/v1/pr.proto
@@ -0,0 +1,4681 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: pr/v1/pr.proto
+
+package prv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TeamMemberRole int32
+
+const (
+	// Без роли считается MEMBER
+	TeamMemberRole_TEAM_MEMBER_ROLE_UNSPECIFIED TeamMemberRole = 0
+	TeamMemberRole_TEAM_MEMBER_ROLE_MEMBER      TeamMemberRole = 1
+	TeamMemberRole_TEAM_MEMBER_ROLE_LEAD        TeamMemberRole = 2
+)
+
+// Enum value maps for TeamMemberRole.
+var (
+	TeamMemberRole_name = map[int32]string{
+		0: "TEAM_MEMBER_ROLE_UNSPECIFIED",
+		1: "TEAM_MEMBER_ROLE_MEMBER",
+		2: "TEAM_MEMBER_ROLE_LEAD",
+	}
+	TeamMemberRole_value = map[string]int32{
+		"TEAM_MEMBER_ROLE_UNSPECIFIED": 0,
+		"TEAM_MEMBER_ROLE_MEMBER":      1,
+		"TEAM_MEMBER_ROLE_LEAD":        2,
+	}
+)
+
+func (x TeamMemberRole) Enum() *TeamMemberRole {
+	p := new(TeamMemberRole)
+	*p = x
+	return p
+}
+
+func (x TeamMemberRole) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TeamMemberRole) Descriptor() protoreflect.EnumDescriptor {
+	return file_pr_v1_pr_proto_enumTypes[0].Descriptor()
+}
+
+func (TeamMemberRole) Type() protoreflect.EnumType {
+	return &file_pr_v1_pr_proto_enumTypes[0]
+}
+
+func (x TeamMemberRole) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TeamMemberRole.Descriptor instead.
+func (TeamMemberRole) EnumDescriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{0}
+}
+
+type PullRequestStatus int32
+
+const (
+	// В фильтрах — любой статус
+	PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED PullRequestStatus = 0
+	PullRequestStatus_PULL_REQUEST_STATUS_OPEN        PullRequestStatus = 1
+	PullRequestStatus_PULL_REQUEST_STATUS_MERGED      PullRequestStatus = 2
+)
+
+// Enum value maps for PullRequestStatus.
+var (
+	PullRequestStatus_name = map[int32]string{
+		0: "PULL_REQUEST_STATUS_UNSPECIFIED",
+		1: "PULL_REQUEST_STATUS_OPEN",
+		2: "PULL_REQUEST_STATUS_MERGED",
+	}
+	PullRequestStatus_value = map[string]int32{
+		"PULL_REQUEST_STATUS_UNSPECIFIED": 0,
+		"PULL_REQUEST_STATUS_OPEN":        1,
+		"PULL_REQUEST_STATUS_MERGED":      2,
+	}
+)
+
+func (x PullRequestStatus) Enum() *PullRequestStatus {
+	p := new(PullRequestStatus)
+	*p = x
+	return p
+}
+
+func (x PullRequestStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PullRequestStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_pr_v1_pr_proto_enumTypes[1].Descriptor()
+}
+
+func (PullRequestStatus) Type() protoreflect.EnumType {
+	return &file_pr_v1_pr_proto_enumTypes[1]
+}
+
+func (x PullRequestStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PullRequestStatus.Descriptor instead.
+func (PullRequestStatus) EnumDescriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{1}
+}
+
+type SortOrder int32
+
+const (
+	// По умолчанию DESC
+	SortOrder_SORT_ORDER_UNSPECIFIED SortOrder = 0
+	SortOrder_SORT_ORDER_ASC         SortOrder = 1
+	SortOrder_SORT_ORDER_DESC        SortOrder = 2
+)
+
+// Enum value maps for SortOrder.
+var (
+	SortOrder_name = map[int32]string{
+		0: "SORT_ORDER_UNSPECIFIED",
+		1: "SORT_ORDER_ASC",
+		2: "SORT_ORDER_DESC",
+	}
+	SortOrder_value = map[string]int32{
+		"SORT_ORDER_UNSPECIFIED": 0,
+		"SORT_ORDER_ASC":         1,
+		"SORT_ORDER_DESC":        2,
+	}
+)
+
+func (x SortOrder) Enum() *SortOrder {
+	p := new(SortOrder)
+	*p = x
+	return p
+}
+
+func (x SortOrder) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SortOrder) Descriptor() protoreflect.EnumDescriptor {
+	return file_pr_v1_pr_proto_enumTypes[2].Descriptor()
+}
+
+func (SortOrder) Type() protoreflect.EnumType {
+	return &file_pr_v1_pr_proto_enumTypes[2]
+}
+
+func (x SortOrder) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SortOrder.Descriptor instead.
+func (SortOrder) EnumDescriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{2}
+}
+
+type UserDeleteMode int32
+
+const (
+	// По умолчанию BLOCK
+	UserDeleteMode_USER_DELETE_MODE_UNSPECIFIED UserDeleteMode = 0
+	// Отказать, если пользователь автор или ревьювер хотя бы одного PR
+	UserDeleteMode_USER_DELETE_MODE_BLOCK UserDeleteMode = 1
+	// Передать открытые ревью активным коллегам по команде
+	UserDeleteMode_USER_DELETE_MODE_REASSIGN UserDeleteMode = 2
+	// Снять открытые ревью без замены
+	UserDeleteMode_USER_DELETE_MODE_ANONYMIZE UserDeleteMode = 3
+)
+
+// Enum value maps for UserDeleteMode.
+var (
+	UserDeleteMode_name = map[int32]string{
+		0: "USER_DELETE_MODE_UNSPECIFIED",
+		1: "USER_DELETE_MODE_BLOCK",
+		2: "USER_DELETE_MODE_REASSIGN",
+		3: "USER_DELETE_MODE_ANONYMIZE",
+	}
+	UserDeleteMode_value = map[string]int32{
+		"USER_DELETE_MODE_UNSPECIFIED": 0,
+		"USER_DELETE_MODE_BLOCK":       1,
+		"USER_DELETE_MODE_REASSIGN":    2,
+		"USER_DELETE_MODE_ANONYMIZE":   3,
+	}
+)
+
+func (x UserDeleteMode) Enum() *UserDeleteMode {
+	p := new(UserDeleteMode)
+	*p = x
+	return p
+}
+
+func (x UserDeleteMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (UserDeleteMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_pr_v1_pr_proto_enumTypes[3].Descriptor()
+}
+
+func (UserDeleteMode) Type() protoreflect.EnumType {
+	return &file_pr_v1_pr_proto_enumTypes[3]
+}
+
+func (x UserDeleteMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use UserDeleteMode.Descriptor instead.
+func (UserDeleteMode) EnumDescriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{3}
+}
+
+type APIKeyRole int32
+
+const (
+	APIKeyRole_API_KEY_ROLE_UNSPECIFIED APIKeyRole = 0
+	APIKeyRole_API_KEY_ROLE_ADMIN       APIKeyRole = 1
+	APIKeyRole_API_KEY_ROLE_USER        APIKeyRole = 2
+)
+
+// Enum value maps for APIKeyRole.
+var (
+	APIKeyRole_name = map[int32]string{
+		0: "API_KEY_ROLE_UNSPECIFIED",
+		1: "API_KEY_ROLE_ADMIN",
+		2: "API_KEY_ROLE_USER",
+	}
+	APIKeyRole_value = map[string]int32{
+		"API_KEY_ROLE_UNSPECIFIED": 0,
+		"API_KEY_ROLE_ADMIN":       1,
+		"API_KEY_ROLE_USER":        2,
+	}
+)
+
+func (x APIKeyRole) Enum() *APIKeyRole {
+	p := new(APIKeyRole)
+	*p = x
+	return p
+}
+
+func (x APIKeyRole) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (APIKeyRole) Descriptor() protoreflect.EnumDescriptor {
+	return file_pr_v1_pr_proto_enumTypes[4].Descriptor()
+}
+
+func (APIKeyRole) Type() protoreflect.EnumType {
+	return &file_pr_v1_pr_proto_enumTypes[4]
+}
+
+func (x APIKeyRole) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use APIKeyRole.Descriptor instead.
+func (APIKeyRole) EnumDescriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{4}
+}
+
+type TeamMember struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	IsActive      bool                   `protobuf:"varint,3,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	Role          TeamMemberRole         `protobuf:"varint,4,opt,name=role,proto3,enum=pr.v1.TeamMemberRole" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TeamMember) Reset() {
+	*x = TeamMember{}
+	mi := &file_pr_v1_pr_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TeamMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TeamMember) ProtoMessage() {}
+
+func (x *TeamMember) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TeamMember.ProtoReflect.Descriptor instead.
+func (*TeamMember) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TeamMember) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *TeamMember) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *TeamMember) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *TeamMember) GetRole() TeamMemberRole {
+	if x != nil {
+		return x.Role
+	}
+	return TeamMemberRole_TEAM_MEMBER_ROLE_UNSPECIFIED
+}
+
+type Team struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	Members       []*TeamMember          `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Team) Reset() {
+	*x = Team{}
+	mi := &file_pr_v1_pr_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Team) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Team) ProtoMessage() {}
+
+func (x *Team) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Team.ProtoReflect.Descriptor instead.
+func (*Team) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Team) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *Team) GetMembers() []*TeamMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type User struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	UserId   string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	TeamName string                 `protobuf:"bytes,3,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	IsActive bool                   `protobuf:"varint,4,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	// Время удаления (анонимизации), пусто для действующих пользователей
+	DeletedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_pr_v1_pr_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *User) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *User) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *User) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *User) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *User) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+type PullRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId     string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	PullRequestName   string                 `protobuf:"bytes,2,opt,name=pull_request_name,json=pullRequestName,proto3" json:"pull_request_name,omitempty"`
+	AuthorId          string                 `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Status            PullRequestStatus      `protobuf:"varint,4,opt,name=status,proto3,enum=pr.v1.PullRequestStatus" json:"status,omitempty"`
+	AssignedReviewers []string               `protobuf:"bytes,5,rep,name=assigned_reviewers,json=assignedReviewers,proto3" json:"assigned_reviewers,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	MergedAt          *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=merged_at,json=mergedAt,proto3" json:"merged_at,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *PullRequest) Reset() {
+	*x = PullRequest{}
+	mi := &file_pr_v1_pr_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PullRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PullRequest) ProtoMessage() {}
+
+func (x *PullRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PullRequest.ProtoReflect.Descriptor instead.
+func (*PullRequest) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PullRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *PullRequest) GetPullRequestName() string {
+	if x != nil {
+		return x.PullRequestName
+	}
+	return ""
+}
+
+func (x *PullRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *PullRequest) GetStatus() PullRequestStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED
+}
+
+func (x *PullRequest) GetAssignedReviewers() []string {
+	if x != nil {
+		return x.AssignedReviewers
+	}
+	return nil
+}
+
+func (x *PullRequest) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *PullRequest) GetMergedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MergedAt
+	}
+	return nil
+}
+
+type ReviewAssignment struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId   string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	PullRequestName string                 `protobuf:"bytes,2,opt,name=pull_request_name,json=pullRequestName,proto3" json:"pull_request_name,omitempty"`
+	AuthorId        string                 `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Status          PullRequestStatus      `protobuf:"varint,4,opt,name=status,proto3,enum=pr.v1.PullRequestStatus" json:"status,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	AssignedAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=assigned_at,json=assignedAt,proto3" json:"assigned_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ReviewAssignment) Reset() {
+	*x = ReviewAssignment{}
+	mi := &file_pr_v1_pr_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewAssignment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewAssignment) ProtoMessage() {}
+
+func (x *ReviewAssignment) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewAssignment.ProtoReflect.Descriptor instead.
+func (*ReviewAssignment) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ReviewAssignment) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *ReviewAssignment) GetPullRequestName() string {
+	if x != nil {
+		return x.PullRequestName
+	}
+	return ""
+}
+
+func (x *ReviewAssignment) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *ReviewAssignment) GetStatus() PullRequestStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED
+}
+
+func (x *ReviewAssignment) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ReviewAssignment) GetAssignedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AssignedAt
+	}
+	return nil
+}
+
+type TeamSyncDiff struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	TeamName          string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	Created           bool                   `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
+	Added             []string               `protobuf:"bytes,3,rep,name=added,proto3" json:"added,omitempty"`
+	Removed           []string               `protobuf:"bytes,4,rep,name=removed,proto3" json:"removed,omitempty"`
+	Activated         []string               `protobuf:"bytes,5,rep,name=activated,proto3" json:"activated,omitempty"`
+	Deactivated       []string               `protobuf:"bytes,6,rep,name=deactivated,proto3" json:"deactivated,omitempty"`
+	ReassignedReviews int32                  `protobuf:"varint,7,opt,name=reassigned_reviews,json=reassignedReviews,proto3" json:"reassigned_reviews,omitempty"`
+	RemovedReviews    int32                  `protobuf:"varint,8,opt,name=removed_reviews,json=removedReviews,proto3" json:"removed_reviews,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *TeamSyncDiff) Reset() {
+	*x = TeamSyncDiff{}
+	mi := &file_pr_v1_pr_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TeamSyncDiff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TeamSyncDiff) ProtoMessage() {}
+
+func (x *TeamSyncDiff) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TeamSyncDiff.ProtoReflect.Descriptor instead.
+func (*TeamSyncDiff) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *TeamSyncDiff) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *TeamSyncDiff) GetCreated() bool {
+	if x != nil {
+		return x.Created
+	}
+	return false
+}
+
+func (x *TeamSyncDiff) GetAdded() []string {
+	if x != nil {
+		return x.Added
+	}
+	return nil
+}
+
+func (x *TeamSyncDiff) GetRemoved() []string {
+	if x != nil {
+		return x.Removed
+	}
+	return nil
+}
+
+func (x *TeamSyncDiff) GetActivated() []string {
+	if x != nil {
+		return x.Activated
+	}
+	return nil
+}
+
+func (x *TeamSyncDiff) GetDeactivated() []string {
+	if x != nil {
+		return x.Deactivated
+	}
+	return nil
+}
+
+func (x *TeamSyncDiff) GetReassignedReviews() int32 {
+	if x != nil {
+		return x.ReassignedReviews
+	}
+	return 0
+}
+
+func (x *TeamSyncDiff) GetRemovedReviews() int32 {
+	if x != nil {
+		return x.RemovedReviews
+	}
+	return 0
+}
+
+type ImportResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Teams         int32                  `protobuf:"varint,1,opt,name=teams,proto3" json:"teams,omitempty"`
+	TeamsCreated  int32                  `protobuf:"varint,2,opt,name=teams_created,json=teamsCreated,proto3" json:"teams_created,omitempty"`
+	Users         int32                  `protobuf:"varint,3,opt,name=users,proto3" json:"users,omitempty"`
+	MembersAdded  int32                  `protobuf:"varint,4,opt,name=members_added,json=membersAdded,proto3" json:"members_added,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportResult) Reset() {
+	*x = ImportResult{}
+	mi := &file_pr_v1_pr_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportResult) ProtoMessage() {}
+
+func (x *ImportResult) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportResult.ProtoReflect.Descriptor instead.
+func (*ImportResult) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ImportResult) GetTeams() int32 {
+	if x != nil {
+		return x.Teams
+	}
+	return 0
+}
+
+func (x *ImportResult) GetTeamsCreated() int32 {
+	if x != nil {
+		return x.TeamsCreated
+	}
+	return 0
+}
+
+func (x *ImportResult) GetUsers() int32 {
+	if x != nil {
+		return x.Users
+	}
+	return 0
+}
+
+func (x *ImportResult) GetMembersAdded() int32 {
+	if x != nil {
+		return x.MembersAdded
+	}
+	return 0
+}
+
+type UserDeletion struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Mode   UserDeleteMode         `protobuf:"varint,2,opt,name=mode,proto3,enum=pr.v1.UserDeleteMode" json:"mode,omitempty"`
+	// true — пользователь остался в истории PR и анонимизирован,
+	// false — запись удалена
+	Anonymized        bool  `protobuf:"varint,3,opt,name=anonymized,proto3" json:"anonymized,omitempty"`
+	ReassignedReviews int32 `protobuf:"varint,4,opt,name=reassigned_reviews,json=reassignedReviews,proto3" json:"reassigned_reviews,omitempty"`
+	RemovedReviews    int32 `protobuf:"varint,5,opt,name=removed_reviews,json=removedReviews,proto3" json:"removed_reviews,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *UserDeletion) Reset() {
+	*x = UserDeletion{}
+	mi := &file_pr_v1_pr_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserDeletion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserDeletion) ProtoMessage() {}
+
+func (x *UserDeletion) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserDeletion.ProtoReflect.Descriptor instead.
+func (*UserDeletion) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UserDeletion) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserDeletion) GetMode() UserDeleteMode {
+	if x != nil {
+		return x.Mode
+	}
+	return UserDeleteMode_USER_DELETE_MODE_UNSPECIFIED
+}
+
+func (x *UserDeletion) GetAnonymized() bool {
+	if x != nil {
+		return x.Anonymized
+	}
+	return false
+}
+
+func (x *UserDeletion) GetReassignedReviews() int32 {
+	if x != nil {
+		return x.ReassignedReviews
+	}
+	return 0
+}
+
+func (x *UserDeletion) GetRemovedReviews() int32 {
+	if x != nil {
+		return x.RemovedReviews
+	}
+	return 0
+}
+
+type ReviewerStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Assigned      int32                  `protobuf:"varint,3,opt,name=assigned,proto3" json:"assigned,omitempty"`
+	Open          int32                  `protobuf:"varint,4,opt,name=open,proto3" json:"open,omitempty"`
+	Merged        int32                  `protobuf:"varint,5,opt,name=merged,proto3" json:"merged,omitempty"`
+	ReassignedIn  int32                  `protobuf:"varint,6,opt,name=reassigned_in,json=reassignedIn,proto3" json:"reassigned_in,omitempty"`
+	ReassignedOut int32                  `protobuf:"varint,7,opt,name=reassigned_out,json=reassignedOut,proto3" json:"reassigned_out,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewerStats) Reset() {
+	*x = ReviewerStats{}
+	mi := &file_pr_v1_pr_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewerStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewerStats) ProtoMessage() {}
+
+func (x *ReviewerStats) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewerStats.ProtoReflect.Descriptor instead.
+func (*ReviewerStats) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ReviewerStats) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ReviewerStats) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *ReviewerStats) GetAssigned() int32 {
+	if x != nil {
+		return x.Assigned
+	}
+	return 0
+}
+
+func (x *ReviewerStats) GetOpen() int32 {
+	if x != nil {
+		return x.Open
+	}
+	return 0
+}
+
+func (x *ReviewerStats) GetMerged() int32 {
+	if x != nil {
+		return x.Merged
+	}
+	return 0
+}
+
+func (x *ReviewerStats) GetReassignedIn() int32 {
+	if x != nil {
+		return x.ReassignedIn
+	}
+	return 0
+}
+
+func (x *ReviewerStats) GetReassignedOut() int32 {
+	if x != nil {
+		return x.ReassignedOut
+	}
+	return 0
+}
+
+type TeamStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	Assigned      int32                  `protobuf:"varint,2,opt,name=assigned,proto3" json:"assigned,omitempty"`
+	Open          int32                  `protobuf:"varint,3,opt,name=open,proto3" json:"open,omitempty"`
+	Merged        int32                  `protobuf:"varint,4,opt,name=merged,proto3" json:"merged,omitempty"`
+	ReassignedIn  int32                  `protobuf:"varint,5,opt,name=reassigned_in,json=reassignedIn,proto3" json:"reassigned_in,omitempty"`
+	ReassignedOut int32                  `protobuf:"varint,6,opt,name=reassigned_out,json=reassignedOut,proto3" json:"reassigned_out,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TeamStats) Reset() {
+	*x = TeamStats{}
+	mi := &file_pr_v1_pr_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TeamStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TeamStats) ProtoMessage() {}
+
+func (x *TeamStats) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TeamStats.ProtoReflect.Descriptor instead.
+func (*TeamStats) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *TeamStats) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *TeamStats) GetAssigned() int32 {
+	if x != nil {
+		return x.Assigned
+	}
+	return 0
+}
+
+func (x *TeamStats) GetOpen() int32 {
+	if x != nil {
+		return x.Open
+	}
+	return 0
+}
+
+func (x *TeamStats) GetMerged() int32 {
+	if x != nil {
+		return x.Merged
+	}
+	return 0
+}
+
+func (x *TeamStats) GetReassignedIn() int32 {
+	if x != nil {
+		return x.ReassignedIn
+	}
+	return 0
+}
+
+func (x *TeamStats) GetReassignedOut() int32 {
+	if x != nil {
+		return x.ReassignedOut
+	}
+	return 0
+}
+
+type CycleTimeStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	P50Seconds    float64                `protobuf:"fixed64,3,opt,name=p50_seconds,json=p50Seconds,proto3" json:"p50_seconds,omitempty"`
+	P90Seconds    float64                `protobuf:"fixed64,4,opt,name=p90_seconds,json=p90Seconds,proto3" json:"p90_seconds,omitempty"`
+	P99Seconds    float64                `protobuf:"fixed64,5,opt,name=p99_seconds,json=p99Seconds,proto3" json:"p99_seconds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CycleTimeStats) Reset() {
+	*x = CycleTimeStats{}
+	mi := &file_pr_v1_pr_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CycleTimeStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CycleTimeStats) ProtoMessage() {}
+
+func (x *CycleTimeStats) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CycleTimeStats.ProtoReflect.Descriptor instead.
+func (*CycleTimeStats) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CycleTimeStats) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *CycleTimeStats) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *CycleTimeStats) GetP50Seconds() float64 {
+	if x != nil {
+		return x.P50Seconds
+	}
+	return 0
+}
+
+func (x *CycleTimeStats) GetP90Seconds() float64 {
+	if x != nil {
+		return x.P90Seconds
+	}
+	return 0
+}
+
+func (x *CycleTimeStats) GetP99Seconds() float64 {
+	if x != nil {
+		return x.P99Seconds
+	}
+	return 0
+}
+
+type CycleTimeBucket struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WeekStart     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=week_start,json=weekStart,proto3" json:"week_start,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	P50Seconds    float64                `protobuf:"fixed64,3,opt,name=p50_seconds,json=p50Seconds,proto3" json:"p50_seconds,omitempty"`
+	P90Seconds    float64                `protobuf:"fixed64,4,opt,name=p90_seconds,json=p90Seconds,proto3" json:"p90_seconds,omitempty"`
+	P99Seconds    float64                `protobuf:"fixed64,5,opt,name=p99_seconds,json=p99Seconds,proto3" json:"p99_seconds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CycleTimeBucket) Reset() {
+	*x = CycleTimeBucket{}
+	mi := &file_pr_v1_pr_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CycleTimeBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CycleTimeBucket) ProtoMessage() {}
+
+func (x *CycleTimeBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CycleTimeBucket.ProtoReflect.Descriptor instead.
+func (*CycleTimeBucket) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CycleTimeBucket) GetWeekStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.WeekStart
+	}
+	return nil
+}
+
+func (x *CycleTimeBucket) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *CycleTimeBucket) GetP50Seconds() float64 {
+	if x != nil {
+		return x.P50Seconds
+	}
+	return 0
+}
+
+func (x *CycleTimeBucket) GetP90Seconds() float64 {
+	if x != nil {
+		return x.P90Seconds
+	}
+	return 0
+}
+
+func (x *CycleTimeBucket) GetP99Seconds() float64 {
+	if x != nil {
+		return x.P99Seconds
+	}
+	return 0
+}
+
+type CycleTimeReport struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ByTeam        []*CycleTimeStats      `protobuf:"bytes,1,rep,name=by_team,json=byTeam,proto3" json:"by_team,omitempty"`
+	ByAuthor      []*CycleTimeStats      `protobuf:"bytes,2,rep,name=by_author,json=byAuthor,proto3" json:"by_author,omitempty"`
+	ByReviewer    []*CycleTimeStats      `protobuf:"bytes,3,rep,name=by_reviewer,json=byReviewer,proto3" json:"by_reviewer,omitempty"`
+	Weekly        []*CycleTimeBucket     `protobuf:"bytes,4,rep,name=weekly,proto3" json:"weekly,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CycleTimeReport) Reset() {
+	*x = CycleTimeReport{}
+	mi := &file_pr_v1_pr_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CycleTimeReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CycleTimeReport) ProtoMessage() {}
+
+func (x *CycleTimeReport) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CycleTimeReport.ProtoReflect.Descriptor instead.
+func (*CycleTimeReport) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CycleTimeReport) GetByTeam() []*CycleTimeStats {
+	if x != nil {
+		return x.ByTeam
+	}
+	return nil
+}
+
+func (x *CycleTimeReport) GetByAuthor() []*CycleTimeStats {
+	if x != nil {
+		return x.ByAuthor
+	}
+	return nil
+}
+
+func (x *CycleTimeReport) GetByReviewer() []*CycleTimeStats {
+	if x != nil {
+		return x.ByReviewer
+	}
+	return nil
+}
+
+func (x *CycleTimeReport) GetWeekly() []*CycleTimeBucket {
+	if x != nil {
+		return x.Weekly
+	}
+	return nil
+}
+
+type APIKey struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Role          APIKeyRole             `protobuf:"varint,3,opt,name=role,proto3,enum=pr.v1.APIKeyRole" json:"role,omitempty"`
+	UserId        *string                `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3,oneof" json:"user_id,omitempty"`
+	TeamName      *string                `protobuf:"bytes,5,opt,name=team_name,json=teamName,proto3,oneof" json:"team_name,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	LastUsedAt    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty"`
+	RevokedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *APIKey) Reset() {
+	*x = APIKey{}
+	mi := &file_pr_v1_pr_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APIKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIKey) ProtoMessage() {}
+
+func (x *APIKey) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APIKey.ProtoReflect.Descriptor instead.
+func (*APIKey) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *APIKey) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *APIKey) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *APIKey) GetRole() APIKeyRole {
+	if x != nil {
+		return x.Role
+	}
+	return APIKeyRole_API_KEY_ROLE_UNSPECIFIED
+}
+
+func (x *APIKey) GetUserId() string {
+	if x != nil && x.UserId != nil {
+		return *x.UserId
+	}
+	return ""
+}
+
+func (x *APIKey) GetTeamName() string {
+	if x != nil && x.TeamName != nil {
+		return *x.TeamName
+	}
+	return ""
+}
+
+func (x *APIKey) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *APIKey) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *APIKey) GetLastUsedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return nil
+}
+
+func (x *APIKey) GetRevokedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RevokedAt
+	}
+	return nil
+}
+
+type AddTeamRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Team          *Team                  `protobuf:"bytes,1,opt,name=team,proto3" json:"team,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddTeamRequest) Reset() {
+	*x = AddTeamRequest{}
+	mi := &file_pr_v1_pr_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddTeamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddTeamRequest) ProtoMessage() {}
+
+func (x *AddTeamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddTeamRequest.ProtoReflect.Descriptor instead.
+func (*AddTeamRequest) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *AddTeamRequest) GetTeam() *Team {
+	if x != nil {
+		return x.Team
+	}
+	return nil
+}
+
+type AddTeamResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Team          *Team                  `protobuf:"bytes,1,opt,name=team,proto3" json:"team,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddTeamResponse) Reset() {
+	*x = AddTeamResponse{}
+	mi := &file_pr_v1_pr_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddTeamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddTeamResponse) ProtoMessage() {}
+
+func (x *AddTeamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddTeamResponse.ProtoReflect.Descriptor instead.
+func (*AddTeamResponse) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *AddTeamResponse) GetTeam() *Team {
+	if x != nil {
+		return x.Team
+	}
+	return nil
+}
+
+type GetTeamRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTeamRequest) Reset() {
+	*x = GetTeamRequest{}
+	mi := &file_pr_v1_pr_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTeamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTeamRequest) ProtoMessage() {}
+
+func (x *GetTeamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTeamRequest.ProtoReflect.Descriptor instead.
+func (*GetTeamRequest) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetTeamRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+type GetTeamResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Team          *Team                  `protobuf:"bytes,1,opt,name=team,proto3" json:"team,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTeamResponse) Reset() {
+	*x = GetTeamResponse{}
+	mi := &file_pr_v1_pr_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTeamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTeamResponse) ProtoMessage() {}
+
+func (x *GetTeamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTeamResponse.ProtoReflect.Descriptor instead.
+func (*GetTeamResponse) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetTeamResponse) GetTeam() *Team {
+	if x != nil {
+		return x.Team
+	}
+	return nil
+}
+
+type AddTeamMembersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	Members       []*TeamMember          `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddTeamMembersRequest) Reset() {
+	*x = AddTeamMembersRequest{}
+	mi := &file_pr_v1_pr_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddTeamMembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddTeamMembersRequest) ProtoMessage() {}
+
+func (x *AddTeamMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddTeamMembersRequest.ProtoReflect.Descriptor instead.
+func (*AddTeamMembersRequest) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AddTeamMembersRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *AddTeamMembersRequest) GetMembers() []*TeamMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type AddTeamMembersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Team          *Team                  `protobuf:"bytes,1,opt,name=team,proto3" json:"team,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddTeamMembersResponse) Reset() {
+	*x = AddTeamMembersResponse{}
+	mi := &file_pr_v1_pr_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddTeamMembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddTeamMembersResponse) ProtoMessage() {}
+
+func (x *AddTeamMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddTeamMembersResponse.ProtoReflect.Descriptor instead.
+func (*AddTeamMembersResponse) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *AddTeamMembersResponse) GetTeam() *Team {
+	if x != nil {
+		return x.Team
+	}
+	return nil
+}
+
+type RemoveTeamMembersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	UserIds       []string               `protobuf:"bytes,2,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveTeamMembersRequest) Reset() {
+	*x = RemoveTeamMembersRequest{}
+	mi := &file_pr_v1_pr_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveTeamMembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveTeamMembersRequest) ProtoMessage() {}
+
+func (x *RemoveTeamMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveTeamMembersRequest.ProtoReflect.Descriptor instead.
+func (*RemoveTeamMembersRequest) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RemoveTeamMembersRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *RemoveTeamMembersRequest) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+type RemoveTeamMembersResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Team              *Team                  `protobuf:"bytes,1,opt,name=team,proto3" json:"team,omitempty"`
+	ReassignedReviews int32                  `protobuf:"varint,2,opt,name=reassigned_reviews,json=reassignedReviews,proto3" json:"reassigned_reviews,omitempty"`
+	RemovedReviews    int32                  `protobuf:"varint,3,opt,name=removed_reviews,json=removedReviews,proto3" json:"removed_reviews,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *RemoveTeamMembersResponse) Reset() {
+	*x = RemoveTeamMembersResponse{}
+	mi := &file_pr_v1_pr_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveTeamMembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveTeamMembersResponse) ProtoMessage() {}
+
+func (x *RemoveTeamMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveTeamMembersResponse.ProtoReflect.Descriptor instead.
+func (*RemoveTeamMembersResponse) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *RemoveTeamMembersResponse) GetTeam() *Team {
+	if x != nil {
+		return x.Team
+	}
+	return nil
+}
+
+func (x *RemoveTeamMembersResponse) GetReassignedReviews() int32 {
+	if x != nil {
+		return x.ReassignedReviews
+	}
+	return 0
+}
+
+func (x *RemoveTeamMembersResponse) GetRemovedReviews() int32 {
+	if x != nil {
+		return x.RemovedReviews
+	}
+	return 0
+}
+
+type RenameTeamRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	NewTeamName   string                 `protobuf:"bytes,2,opt,name=new_team_name,json=newTeamName,proto3" json:"new_team_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenameTeamRequest) Reset() {
+	*x = RenameTeamRequest{}
+	mi := &file_pr_v1_pr_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenameTeamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameTeamRequest) ProtoMessage() {}
+
+func (x *RenameTeamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameTeamRequest.ProtoReflect.Descriptor instead.
+func (*RenameTeamRequest) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *RenameTeamRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *RenameTeamRequest) GetNewTeamName() string {
+	if x != nil {
+		return x.NewTeamName
+	}
+	return ""
+}
+
+type RenameTeamResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Team          *Team                  `protobuf:"bytes,1,opt,name=team,proto3" json:"team,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenameTeamResponse) Reset() {
+	*x = RenameTeamResponse{}
+	mi := &file_pr_v1_pr_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenameTeamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameTeamResponse) ProtoMessage() {}
+
+func (x *RenameTeamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameTeamResponse.ProtoReflect.Descriptor instead.
+func (*RenameTeamResponse) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *RenameTeamResponse) GetTeam() *Team {
+	if x != nil {
+		return x.Team
+	}
+	return nil
+}
+
+type DeleteTeamRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTeamRequest) Reset() {
+	*x = DeleteTeamRequest{}
+	mi := &file_pr_v1_pr_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTeamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTeamRequest) ProtoMessage() {}
+
+func (x *DeleteTeamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTeamRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTeamRequest) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *DeleteTeamRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+type DeleteTeamResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTeamResponse) Reset() {
+	*x = DeleteTeamResponse{}
+	mi := &file_pr_v1_pr_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTeamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTeamResponse) ProtoMessage() {}
+
+func (x *DeleteTeamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTeamResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTeamResponse) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *DeleteTeamResponse) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+type SyncTeamsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Teams         []*Team                `protobuf:"bytes,1,rep,name=teams,proto3" json:"teams,omitempty"`
+	DryRun        bool                   `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncTeamsRequest) Reset() {
+	*x = SyncTeamsRequest{}
+	mi := &file_pr_v1_pr_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncTeamsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncTeamsRequest) ProtoMessage() {}
+
+func (x *SyncTeamsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncTeamsRequest.ProtoReflect.Descriptor instead.
+func (*SyncTeamsRequest) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *SyncTeamsRequest) GetTeams() []*Team {
+	if x != nil {
+		return x.Teams
+	}
+	return nil
+}
+
+func (x *SyncTeamsRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type SyncTeamsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DryRun        bool                   `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	Teams         []*TeamSyncDiff        `protobuf:"bytes,2,rep,name=teams,proto3" json:"teams,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncTeamsResponse) Reset() {
+	*x = SyncTeamsResponse{}
+	mi := &file_pr_v1_pr_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncTeamsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncTeamsResponse) ProtoMessage() {}
+
+func (x *SyncTeamsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncTeamsResponse.ProtoReflect.Descriptor instead.
+func (*SyncTeamsResponse) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *SyncTeamsResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *SyncTeamsResponse) GetTeams() []*TeamSyncDiff {
+	if x != nil {
+		return x.Teams
+	}
+	return nil
+}
+
+type ImportTeamsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Teams         []*Team                `protobuf:"bytes,1,rep,name=teams,proto3" json:"teams,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportTeamsRequest) Reset() {
+	*x = ImportTeamsRequest{}
+	mi := &file_pr_v1_pr_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportTeamsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportTeamsRequest) ProtoMessage() {}
+
+func (x *ImportTeamsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportTeamsRequest.ProtoReflect.Descriptor instead.
+func (*ImportTeamsRequest) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ImportTeamsRequest) GetTeams() []*Team {
+	if x != nil {
+		return x.Teams
+	}
+	return nil
+}
+
+type ImportTeamsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        *ImportResult          `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportTeamsResponse) Reset() {
+	*x = ImportTeamsResponse{}
+	mi := &file_pr_v1_pr_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportTeamsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportTeamsResponse) ProtoMessage() {}
+
+func (x *ImportTeamsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportTeamsResponse.ProtoReflect.Descriptor instead.
+func (*ImportTeamsResponse) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ImportTeamsResponse) GetResult() *ImportResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type SetUserIsActiveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	IsActive      bool                   `protobuf:"varint,2,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetUserIsActiveRequest) Reset() {
+	*x = SetUserIsActiveRequest{}
+	mi := &file_pr_v1_pr_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetUserIsActiveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUserIsActiveRequest) ProtoMessage() {}
+
+func (x *SetUserIsActiveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetUserIsActiveRequest.ProtoReflect.Descriptor instead.
+func (*SetUserIsActiveRequest) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *SetUserIsActiveRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetUserIsActiveRequest) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+type SetUserIsActiveResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetUserIsActiveResponse) Reset() {
+	*x = SetUserIsActiveResponse{}
+	mi := &file_pr_v1_pr_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetUserIsActiveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUserIsActiveResponse) ProtoMessage() {}
+
+func (x *SetUserIsActiveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetUserIsActiveResponse.ProtoReflect.Descriptor instead.
+func (*SetUserIsActiveResponse) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *SetUserIsActiveResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type GetUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserRequest) Reset() {
+	*x = GetUserRequest{}
+	mi := &file_pr_v1_pr_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserRequest) ProtoMessage() {}
+
+func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserRequest.ProtoReflect.Descriptor instead.
+func (*GetUserRequest) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserResponse) Reset() {
+	*x = GetUserResponse{}
+	mi := &file_pr_v1_pr_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserResponse) ProtoMessage() {}
+
+func (x *GetUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserResponse.ProtoReflect.Descriptor instead.
+func (*GetUserResponse) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetUserResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type UpdateUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username      *string                `protobuf:"bytes,2,opt,name=username,proto3,oneof" json:"username,omitempty"`
+	IsActive      *bool                  `protobuf:"varint,3,opt,name=is_active,json=isActive,proto3,oneof" json:"is_active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateUserRequest) Reset() {
+	*x = UpdateUserRequest{}
+	mi := &file_pr_v1_pr_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUserRequest) ProtoMessage() {}
+
+func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateUserRequest.ProtoReflect.Descriptor instead.
+func (*UpdateUserRequest) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *UpdateUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetUsername() string {
+	if x != nil && x.Username != nil {
+		return *x.Username
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetIsActive() bool {
+	if x != nil && x.IsActive != nil {
+		return *x.IsActive
+	}
+	return false
+}
+
+type UpdateUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateUserResponse) Reset() {
+	*x = UpdateUserResponse{}
+	mi := &file_pr_v1_pr_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUserResponse) ProtoMessage() {}
+
+func (x *UpdateUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateUserResponse.ProtoReflect.Descriptor instead.
+func (*UpdateUserResponse) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *UpdateUserResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type DeleteUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Mode          UserDeleteMode         `protobuf:"varint,2,opt,name=mode,proto3,enum=pr.v1.UserDeleteMode" json:"mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserRequest) Reset() {
+	*x = DeleteUserRequest{}
+	mi := &file_pr_v1_pr_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserRequest) ProtoMessage() {}
+
+func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserRequest.ProtoReflect.Descriptor instead.
+func (*DeleteUserRequest) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *DeleteUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DeleteUserRequest) GetMode() UserDeleteMode {
+	if x != nil {
+		return x.Mode
+	}
+	return UserDeleteMode_USER_DELETE_MODE_UNSPECIFIED
+}
+
+type DeleteUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        *UserDeletion          `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserResponse) Reset() {
+	*x = DeleteUserResponse{}
+	mi := &file_pr_v1_pr_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserResponse) ProtoMessage() {}
+
+func (x *DeleteUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserResponse.ProtoReflect.Descriptor instead.
+func (*DeleteUserResponse) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *DeleteUserResponse) GetResult() *UserDeletion {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type ListUsersRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TeamName *string                `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3,oneof" json:"team_name,omitempty"`
+	IsActive *bool                  `protobuf:"varint,2,opt,name=is_active,json=isActive,proto3,oneof" json:"is_active,omitempty"`
+	// Подстрока имени без учёта регистра
+	Username       *string `protobuf:"bytes,3,opt,name=username,proto3,oneof" json:"username,omitempty"`
+	IncludeDeleted bool    `protobuf:"varint,4,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	// Размер страницы, 0 — по умолчанию
+	Limit int32 `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Значение next_cursor из предыдущего ответа
+	Cursor        string `protobuf:"bytes,6,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersRequest) Reset() {
+	*x = ListUsersRequest{}
+	mi := &file_pr_v1_pr_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersRequest) ProtoMessage() {}
+
+func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListUsersRequest) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ListUsersRequest) GetTeamName() string {
+	if x != nil && x.TeamName != nil {
+		return *x.TeamName
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetIsActive() bool {
+	if x != nil && x.IsActive != nil {
+		return *x.IsActive
+	}
+	return false
+}
+
+func (x *ListUsersRequest) GetUsername() string {
+	if x != nil && x.Username != nil {
+		return *x.Username
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+func (x *ListUsersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListUsersRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type ListUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersResponse) Reset() {
+	*x = ListUsersResponse{}
+	mi := &file_pr_v1_pr_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersResponse) ProtoMessage() {}
+
+func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
+func (*ListUsersResponse) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ListUsersResponse) GetUsers() []*User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *ListUsersResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type GetUserReviewsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Status PullRequestStatus      `protobuf:"varint,2,opt,name=status,proto3,enum=pr.v1.PullRequestStatus" json:"status,omitempty"`
+	// PR созданы не раньше (включительно)
+	CreatedAfter *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	// PR созданы раньше (не включительно)
+	CreatedBefore *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	Order         SortOrder              `protobuf:"varint,5,opt,name=order,proto3,enum=pr.v1.SortOrder" json:"order,omitempty"`
+	Limit         int32                  `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        string                 `protobuf:"bytes,7,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserReviewsRequest) Reset() {
+	*x = GetUserReviewsRequest{}
+	mi := &file_pr_v1_pr_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserReviewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserReviewsRequest) ProtoMessage() {}
+
+func (x *GetUserReviewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserReviewsRequest.ProtoReflect.Descriptor instead.
+func (*GetUserReviewsRequest) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GetUserReviewsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetUserReviewsRequest) GetStatus() PullRequestStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED
+}
+
+func (x *GetUserReviewsRequest) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *GetUserReviewsRequest) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *GetUserReviewsRequest) GetOrder() SortOrder {
+	if x != nil {
+		return x.Order
+	}
+	return SortOrder_SORT_ORDER_UNSPECIFIED
+}
+
+func (x *GetUserReviewsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetUserReviewsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type GetUserReviewsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PullRequests  []*ReviewAssignment    `protobuf:"bytes,2,rep,name=pull_requests,json=pullRequests,proto3" json:"pull_requests,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserReviewsResponse) Reset() {
+	*x = GetUserReviewsResponse{}
+	mi := &file_pr_v1_pr_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserReviewsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserReviewsResponse) ProtoMessage() {}
+
+func (x *GetUserReviewsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserReviewsResponse.ProtoReflect.Descriptor instead.
+func (*GetUserReviewsResponse) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *GetUserReviewsResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetUserReviewsResponse) GetPullRequests() []*ReviewAssignment {
+	if x != nil {
+		return x.PullRequests
+	}
+	return nil
+}
+
+func (x *GetUserReviewsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type CreatePullRequestRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId   string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	PullRequestName string                 `protobuf:"bytes,2,opt,name=pull_request_name,json=pullRequestName,proto3" json:"pull_request_name,omitempty"`
+	AuthorId        string                 `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreatePullRequestRequest) Reset() {
+	*x = CreatePullRequestRequest{}
+	mi := &file_pr_v1_pr_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePullRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePullRequestRequest) ProtoMessage() {}
+
+func (x *CreatePullRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePullRequestRequest.ProtoReflect.Descriptor instead.
+func (*CreatePullRequestRequest) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *CreatePullRequestRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *CreatePullRequestRequest) GetPullRequestName() string {
+	if x != nil {
+		return x.PullRequestName
+	}
+	return ""
+}
+
+func (x *CreatePullRequestRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+type CreatePullRequestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pr            *PullRequest           `protobuf:"bytes,1,opt,name=pr,proto3" json:"pr,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePullRequestResponse) Reset() {
+	*x = CreatePullRequestResponse{}
+	mi := &file_pr_v1_pr_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePullRequestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePullRequestResponse) ProtoMessage() {}
+
+func (x *CreatePullRequestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePullRequestResponse.ProtoReflect.Descriptor instead.
+func (*CreatePullRequestResponse) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *CreatePullRequestResponse) GetPr() *PullRequest {
+	if x != nil {
+		return x.Pr
+	}
+	return nil
+}
+
+type GetPullRequestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPullRequestRequest) Reset() {
+	*x = GetPullRequestRequest{}
+	mi := &file_pr_v1_pr_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPullRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPullRequestRequest) ProtoMessage() {}
+
+func (x *GetPullRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPullRequestRequest.ProtoReflect.Descriptor instead.
+func (*GetPullRequestRequest) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *GetPullRequestRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+type GetPullRequestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pr            *PullRequest           `protobuf:"bytes,1,opt,name=pr,proto3" json:"pr,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPullRequestResponse) Reset() {
+	*x = GetPullRequestResponse{}
+	mi := &file_pr_v1_pr_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPullRequestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPullRequestResponse) ProtoMessage() {}
+
+func (x *GetPullRequestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPullRequestResponse.ProtoReflect.Descriptor instead.
+func (*GetPullRequestResponse) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *GetPullRequestResponse) GetPr() *PullRequest {
+	if x != nil {
+		return x.Pr
+	}
+	return nil
+}
+
+type ListPullRequestsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AuthorId      *string                `protobuf:"bytes,1,opt,name=author_id,json=authorId,proto3,oneof" json:"author_id,omitempty"`
+	TeamName      *string                `protobuf:"bytes,2,opt,name=team_name,json=teamName,proto3,oneof" json:"team_name,omitempty"`
+	Status        PullRequestStatus      `protobuf:"varint,3,opt,name=status,proto3,enum=pr.v1.PullRequestStatus" json:"status,omitempty"`
+	ReviewerId    *string                `protobuf:"bytes,4,opt,name=reviewer_id,json=reviewerId,proto3,oneof" json:"reviewer_id,omitempty"`
+	Title         *string                `protobuf:"bytes,5,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	CreatedAfter  *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	CreatedBefore *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	Order         SortOrder              `protobuf:"varint,8,opt,name=order,proto3,enum=pr.v1.SortOrder" json:"order,omitempty"`
+	Limit         int32                  `protobuf:"varint,9,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        string                 `protobuf:"bytes,10,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPullRequestsRequest) Reset() {
+	*x = ListPullRequestsRequest{}
+	mi := &file_pr_v1_pr_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPullRequestsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPullRequestsRequest) ProtoMessage() {}
+
+func (x *ListPullRequestsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPullRequestsRequest.ProtoReflect.Descriptor instead.
+func (*ListPullRequestsRequest) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ListPullRequestsRequest) GetAuthorId() string {
+	if x != nil && x.AuthorId != nil {
+		return *x.AuthorId
+	}
+	return ""
+}
+
+func (x *ListPullRequestsRequest) GetTeamName() string {
+	if x != nil && x.TeamName != nil {
+		return *x.TeamName
+	}
+	return ""
+}
+
+func (x *ListPullRequestsRequest) GetStatus() PullRequestStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED
+}
+
+func (x *ListPullRequestsRequest) GetReviewerId() string {
+	if x != nil && x.ReviewerId != nil {
+		return *x.ReviewerId
+	}
+	return ""
+}
+
+func (x *ListPullRequestsRequest) GetTitle() string {
+	if x != nil && x.Title != nil {
+		return *x.Title
+	}
+	return ""
+}
+
+func (x *ListPullRequestsRequest) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *ListPullRequestsRequest) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *ListPullRequestsRequest) GetOrder() SortOrder {
+	if x != nil {
+		return x.Order
+	}
+	return SortOrder_SORT_ORDER_UNSPECIFIED
+}
+
+func (x *ListPullRequestsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListPullRequestsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type ListPullRequestsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequests  []*PullRequest         `protobuf:"bytes,1,rep,name=pull_requests,json=pullRequests,proto3" json:"pull_requests,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPullRequestsResponse) Reset() {
+	*x = ListPullRequestsResponse{}
+	mi := &file_pr_v1_pr_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPullRequestsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPullRequestsResponse) ProtoMessage() {}
+
+func (x *ListPullRequestsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPullRequestsResponse.ProtoReflect.Descriptor instead.
+func (*ListPullRequestsResponse) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ListPullRequestsResponse) GetPullRequests() []*PullRequest {
+	if x != nil {
+		return x.PullRequests
+	}
+	return nil
+}
+
+func (x *ListPullRequestsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type MergePullRequestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergePullRequestRequest) Reset() {
+	*x = MergePullRequestRequest{}
+	mi := &file_pr_v1_pr_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergePullRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergePullRequestRequest) ProtoMessage() {}
+
+func (x *MergePullRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergePullRequestRequest.ProtoReflect.Descriptor instead.
+func (*MergePullRequestRequest) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *MergePullRequestRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+type MergePullRequestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pr            *PullRequest           `protobuf:"bytes,1,opt,name=pr,proto3" json:"pr,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergePullRequestResponse) Reset() {
+	*x = MergePullRequestResponse{}
+	mi := &file_pr_v1_pr_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergePullRequestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergePullRequestResponse) ProtoMessage() {}
+
+func (x *MergePullRequestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergePullRequestResponse.ProtoReflect.Descriptor instead.
+func (*MergePullRequestResponse) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *MergePullRequestResponse) GetPr() *PullRequest {
+	if x != nil {
+		return x.Pr
+	}
+	return nil
+}
+
+type ReassignPullRequestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	OldUserId     string                 `protobuf:"bytes,2,opt,name=old_user_id,json=oldUserId,proto3" json:"old_user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReassignPullRequestRequest) Reset() {
+	*x = ReassignPullRequestRequest{}
+	mi := &file_pr_v1_pr_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReassignPullRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReassignPullRequestRequest) ProtoMessage() {}
+
+func (x *ReassignPullRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReassignPullRequestRequest.ProtoReflect.Descriptor instead.
+func (*ReassignPullRequestRequest) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ReassignPullRequestRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *ReassignPullRequestRequest) GetOldUserId() string {
+	if x != nil {
+		return x.OldUserId
+	}
+	return ""
+}
+
+type ReassignPullRequestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pr            *PullRequest           `protobuf:"bytes,1,opt,name=pr,proto3" json:"pr,omitempty"`
+	ReplacedBy    string                 `protobuf:"bytes,2,opt,name=replaced_by,json=replacedBy,proto3" json:"replaced_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReassignPullRequestResponse) Reset() {
+	*x = ReassignPullRequestResponse{}
+	mi := &file_pr_v1_pr_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReassignPullRequestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReassignPullRequestResponse) ProtoMessage() {}
+
+func (x *ReassignPullRequestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReassignPullRequestResponse.ProtoReflect.Descriptor instead.
+func (*ReassignPullRequestResponse) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ReassignPullRequestResponse) GetPr() *PullRequest {
+	if x != nil {
+		return x.Pr
+	}
+	return nil
+}
+
+func (x *ReassignPullRequestResponse) GetReplacedBy() string {
+	if x != nil {
+		return x.ReplacedBy
+	}
+	return ""
+}
+
+type GetReviewerStatsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Начало временного окна (включительно)
+	From *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	// Конец временного окна (не включительно)
+	To            *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReviewerStatsRequest) Reset() {
+	*x = GetReviewerStatsRequest{}
+	mi := &file_pr_v1_pr_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReviewerStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReviewerStatsRequest) ProtoMessage() {}
+
+func (x *GetReviewerStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReviewerStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetReviewerStatsRequest) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *GetReviewerStatsRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetReviewerStatsRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+type GetReviewerStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reviewers     []*ReviewerStats       `protobuf:"bytes,1,rep,name=reviewers,proto3" json:"reviewers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReviewerStatsResponse) Reset() {
+	*x = GetReviewerStatsResponse{}
+	mi := &file_pr_v1_pr_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReviewerStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReviewerStatsResponse) ProtoMessage() {}
+
+func (x *GetReviewerStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReviewerStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetReviewerStatsResponse) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *GetReviewerStatsResponse) GetReviewers() []*ReviewerStats {
+	if x != nil {
+		return x.Reviewers
+	}
+	return nil
+}
+
+type GetTeamStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTeamStatsRequest) Reset() {
+	*x = GetTeamStatsRequest{}
+	mi := &file_pr_v1_pr_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTeamStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTeamStatsRequest) ProtoMessage() {}
+
+func (x *GetTeamStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTeamStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetTeamStatsRequest) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *GetTeamStatsRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetTeamStatsRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+type GetTeamStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Teams         []*TeamStats           `protobuf:"bytes,1,rep,name=teams,proto3" json:"teams,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTeamStatsResponse) Reset() {
+	*x = GetTeamStatsResponse{}
+	mi := &file_pr_v1_pr_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTeamStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTeamStatsResponse) ProtoMessage() {}
+
+func (x *GetTeamStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTeamStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetTeamStatsResponse) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *GetTeamStatsResponse) GetTeams() []*TeamStats {
+	if x != nil {
+		return x.Teams
+	}
+	return nil
+}
+
+type GetCycleTimeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCycleTimeRequest) Reset() {
+	*x = GetCycleTimeRequest{}
+	mi := &file_pr_v1_pr_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCycleTimeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCycleTimeRequest) ProtoMessage() {}
+
+func (x *GetCycleTimeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCycleTimeRequest.ProtoReflect.Descriptor instead.
+func (*GetCycleTimeRequest) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *GetCycleTimeRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetCycleTimeRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+type GetCycleTimeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Report        *CycleTimeReport       `protobuf:"bytes,1,opt,name=report,proto3" json:"report,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCycleTimeResponse) Reset() {
+	*x = GetCycleTimeResponse{}
+	mi := &file_pr_v1_pr_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCycleTimeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCycleTimeResponse) ProtoMessage() {}
+
+func (x *GetCycleTimeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCycleTimeResponse.ProtoReflect.Descriptor instead.
+func (*GetCycleTimeResponse) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *GetCycleTimeResponse) GetReport() *CycleTimeReport {
+	if x != nil {
+		return x.Report
+	}
+	return nil
+}
+
+type ExportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportRequest) Reset() {
+	*x = ExportRequest{}
+	mi := &file_pr_v1_pr_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportRequest) ProtoMessage() {}
+
+func (x *ExportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportRequest.ProtoReflect.Descriptor instead.
+func (*ExportRequest) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *ExportRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *ExportRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+// ExportRecord — строка таблицы table, как в NDJSON-выгрузке /export
+type ExportRecord struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Table         string                 `protobuf:"bytes,1,opt,name=table,proto3" json:"table,omitempty"`
+	Row           *structpb.Struct       `protobuf:"bytes,2,opt,name=row,proto3" json:"row,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportRecord) Reset() {
+	*x = ExportRecord{}
+	mi := &file_pr_v1_pr_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportRecord) ProtoMessage() {}
+
+func (x *ExportRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportRecord.ProtoReflect.Descriptor instead.
+func (*ExportRecord) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *ExportRecord) GetTable() string {
+	if x != nil {
+		return x.Table
+	}
+	return ""
+}
+
+func (x *ExportRecord) GetRow() *structpb.Struct {
+	if x != nil {
+		return x.Row
+	}
+	return nil
+}
+
+type CreateAPIKeyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Role  APIKeyRole             `protobuf:"varint,2,opt,name=role,proto3,enum=pr.v1.APIKeyRole" json:"role,omitempty"`
+	// Обязателен для роли USER
+	UserId        *string                `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3,oneof" json:"user_id,omitempty"`
+	TeamName      *string                `protobuf:"bytes,4,opt,name=team_name,json=teamName,proto3,oneof" json:"team_name,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAPIKeyRequest) Reset() {
+	*x = CreateAPIKeyRequest{}
+	mi := &file_pr_v1_pr_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAPIKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAPIKeyRequest) ProtoMessage() {}
+
+func (x *CreateAPIKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAPIKeyRequest.ProtoReflect.Descriptor instead.
+func (*CreateAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *CreateAPIKeyRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateAPIKeyRequest) GetRole() APIKeyRole {
+	if x != nil {
+		return x.Role
+	}
+	return APIKeyRole_API_KEY_ROLE_UNSPECIFIED
+}
+
+func (x *CreateAPIKeyRequest) GetUserId() string {
+	if x != nil && x.UserId != nil {
+		return *x.UserId
+	}
+	return ""
+}
+
+func (x *CreateAPIKeyRequest) GetTeamName() string {
+	if x != nil && x.TeamName != nil {
+		return *x.TeamName
+	}
+	return ""
+}
+
+func (x *CreateAPIKeyRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type CreateAPIKeyResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	ApiKey *APIKey                `protobuf:"bytes,1,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	// Секрет ключа, возвращается только при создании
+	Token         string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAPIKeyResponse) Reset() {
+	*x = CreateAPIKeyResponse{}
+	mi := &file_pr_v1_pr_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAPIKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAPIKeyResponse) ProtoMessage() {}
+
+func (x *CreateAPIKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAPIKeyResponse.ProtoReflect.Descriptor instead.
+func (*CreateAPIKeyResponse) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *CreateAPIKeyResponse) GetApiKey() *APIKey {
+	if x != nil {
+		return x.ApiKey
+	}
+	return nil
+}
+
+func (x *CreateAPIKeyResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type ListAPIKeysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAPIKeysRequest) Reset() {
+	*x = ListAPIKeysRequest{}
+	mi := &file_pr_v1_pr_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAPIKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAPIKeysRequest) ProtoMessage() {}
+
+func (x *ListAPIKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAPIKeysRequest.ProtoReflect.Descriptor instead.
+func (*ListAPIKeysRequest) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{62}
+}
+
+type ListAPIKeysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiKeys       []*APIKey              `protobuf:"bytes,1,rep,name=api_keys,json=apiKeys,proto3" json:"api_keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAPIKeysResponse) Reset() {
+	*x = ListAPIKeysResponse{}
+	mi := &file_pr_v1_pr_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAPIKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAPIKeysResponse) ProtoMessage() {}
+
+func (x *ListAPIKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAPIKeysResponse.ProtoReflect.Descriptor instead.
+func (*ListAPIKeysResponse) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ListAPIKeysResponse) GetApiKeys() []*APIKey {
+	if x != nil {
+		return x.ApiKeys
+	}
+	return nil
+}
+
+type RevokeAPIKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeAPIKeyRequest) Reset() {
+	*x = RevokeAPIKeyRequest{}
+	mi := &file_pr_v1_pr_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAPIKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAPIKeyRequest) ProtoMessage() {}
+
+func (x *RevokeAPIKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAPIKeyRequest.ProtoReflect.Descriptor instead.
+func (*RevokeAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *RevokeAPIKeyRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type RevokeAPIKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiKey        *APIKey                `protobuf:"bytes,1,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeAPIKeyResponse) Reset() {
+	*x = RevokeAPIKeyResponse{}
+	mi := &file_pr_v1_pr_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAPIKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAPIKeyResponse) ProtoMessage() {}
+
+func (x *RevokeAPIKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pr_v1_pr_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAPIKeyResponse.ProtoReflect.Descriptor instead.
+func (*RevokeAPIKeyResponse) Descriptor() ([]byte, []int) {
+	return file_pr_v1_pr_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *RevokeAPIKeyResponse) GetApiKey() *APIKey {
+	if x != nil {
+		return x.ApiKey
+	}
+	return nil
+}
+
+var File_pr_v1_pr_proto protoreflect.FileDescriptor
+
+const file_pr_v1_pr_proto_rawDesc = "" +
+	"\n" +
+	"\x0epr/v1/pr.proto\x12\x05pr.v1\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x89\x01\n" +
+	"\n" +
+	"TeamMember\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1b\n" +
+	"\tis_active\x18\x03 \x01(\bR\bisActive\x12)\n" +
+	"\x04role\x18\x04 \x01(\x0e2\x15.pr.v1.TeamMemberRoleR\x04role\"P\n" +
+	"\x04Team\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x12+\n" +
+	"\amembers\x18\x02 \x03(\v2\x11.pr.v1.TeamMemberR\amembers\"\xb0\x01\n" +
+	"\x04User\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1b\n" +
+	"\tteam_name\x18\x03 \x01(\tR\bteamName\x12\x1b\n" +
+	"\tis_active\x18\x04 \x01(\bR\bisActive\x129\n" +
+	"\n" +
+	"deleted_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\"\xd3\x02\n" +
+	"\vPullRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12*\n" +
+	"\x11pull_request_name\x18\x02 \x01(\tR\x0fpullRequestName\x12\x1b\n" +
+	"\tauthor_id\x18\x03 \x01(\tR\bauthorId\x120\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x18.pr.v1.PullRequestStatusR\x06status\x12-\n" +
+	"\x12assigned_reviewers\x18\x05 \x03(\tR\x11assignedReviewers\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x127\n" +
+	"\tmerged_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\bmergedAt\"\xad\x02\n" +
+	"\x10ReviewAssignment\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12*\n" +
+	"\x11pull_request_name\x18\x02 \x01(\tR\x0fpullRequestName\x12\x1b\n" +
+	"\tauthor_id\x18\x03 \x01(\tR\bauthorId\x120\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x18.pr.v1.PullRequestStatusR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12;\n" +
+	"\vassigned_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"assignedAt\"\x8d\x02\n" +
+	"\fTeamSyncDiff\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x12\x18\n" +
+	"\acreated\x18\x02 \x01(\bR\acreated\x12\x14\n" +
+	"\x05added\x18\x03 \x03(\tR\x05added\x12\x18\n" +
+	"\aremoved\x18\x04 \x03(\tR\aremoved\x12\x1c\n" +
+	"\tactivated\x18\x05 \x03(\tR\tactivated\x12 \n" +
+	"\vdeactivated\x18\x06 \x03(\tR\vdeactivated\x12-\n" +
+	"\x12reassigned_reviews\x18\a \x01(\x05R\x11reassignedReviews\x12'\n" +
+	"\x0fremoved_reviews\x18\b \x01(\x05R\x0eremovedReviews\"\x84\x01\n" +
+	"\fImportResult\x12\x14\n" +
+	"\x05teams\x18\x01 \x01(\x05R\x05teams\x12#\n" +
+	"\rteams_created\x18\x02 \x01(\x05R\fteamsCreated\x12\x14\n" +
+	"\x05users\x18\x03 \x01(\x05R\x05users\x12#\n" +
+	"\rmembers_added\x18\x04 \x01(\x05R\fmembersAdded\"\xca\x01\n" +
+	"\fUserDeletion\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12)\n" +
+	"\x04mode\x18\x02 \x01(\x0e2\x15.pr.v1.UserDeleteModeR\x04mode\x12\x1e\n" +
+	"\n" +
+	"anonymized\x18\x03 \x01(\bR\n" +
+	"anonymized\x12-\n" +
+	"\x12reassigned_reviews\x18\x04 \x01(\x05R\x11reassignedReviews\x12'\n" +
+	"\x0fremoved_reviews\x18\x05 \x01(\x05R\x0eremovedReviews\"\xd8\x01\n" +
+	"\rReviewerStats\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1a\n" +
+	"\bassigned\x18\x03 \x01(\x05R\bassigned\x12\x12\n" +
+	"\x04open\x18\x04 \x01(\x05R\x04open\x12\x16\n" +
+	"\x06merged\x18\x05 \x01(\x05R\x06merged\x12#\n" +
+	"\rreassigned_in\x18\x06 \x01(\x05R\freassignedIn\x12%\n" +
+	"\x0ereassigned_out\x18\a \x01(\x05R\rreassignedOut\"\xbc\x01\n" +
+	"\tTeamStats\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x12\x1a\n" +
+	"\bassigned\x18\x02 \x01(\x05R\bassigned\x12\x12\n" +
+	"\x04open\x18\x03 \x01(\x05R\x04open\x12\x16\n" +
+	"\x06merged\x18\x04 \x01(\x05R\x06merged\x12#\n" +
+	"\rreassigned_in\x18\x05 \x01(\x05R\freassignedIn\x12%\n" +
+	"\x0ereassigned_out\x18\x06 \x01(\x05R\rreassignedOut\"\x9b\x01\n" +
+	"\x0eCycleTimeStats\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\x12\x1f\n" +
+	"\vp50_seconds\x18\x03 \x01(\x01R\n" +
+	"p50Seconds\x12\x1f\n" +
+	"\vp90_seconds\x18\x04 \x01(\x01R\n" +
+	"p90Seconds\x12\x1f\n" +
+	"\vp99_seconds\x18\x05 \x01(\x01R\n" +
+	"p99Seconds\"\xc5\x01\n" +
+	"\x0fCycleTimeBucket\x129\n" +
+	"\n" +
+	"week_start\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tweekStart\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\x12\x1f\n" +
+	"\vp50_seconds\x18\x03 \x01(\x01R\n" +
+	"p50Seconds\x12\x1f\n" +
+	"\vp90_seconds\x18\x04 \x01(\x01R\n" +
+	"p90Seconds\x12\x1f\n" +
+	"\vp99_seconds\x18\x05 \x01(\x01R\n" +
+	"p99Seconds\"\xdd\x01\n" +
+	"\x0fCycleTimeReport\x12.\n" +
+	"\aby_team\x18\x01 \x03(\v2\x15.pr.v1.CycleTimeStatsR\x06byTeam\x122\n" +
+	"\tby_author\x18\x02 \x03(\v2\x15.pr.v1.CycleTimeStatsR\bbyAuthor\x126\n" +
+	"\vby_reviewer\x18\x03 \x03(\v2\x15.pr.v1.CycleTimeStatsR\n" +
+	"byReviewer\x12.\n" +
+	"\x06weekly\x18\x04 \x03(\v2\x16.pr.v1.CycleTimeBucketR\x06weekly\"\x9c\x03\n" +
+	"\x06APIKey\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12%\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x11.pr.v1.APIKeyRoleR\x04role\x12\x1c\n" +
+	"\auser_id\x18\x04 \x01(\tH\x00R\x06userId\x88\x01\x01\x12 \n" +
+	"\tteam_name\x18\x05 \x01(\tH\x01R\bteamName\x88\x01\x01\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12<\n" +
+	"\flast_used_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastUsedAt\x129\n" +
+	"\n" +
+	"revoked_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\trevokedAtB\n" +
+	"\n" +
+	"\b_user_idB\f\n" +
+	"\n" +
+	"_team_name\"1\n" +
+	"\x0eAddTeamRequest\x12\x1f\n" +
+	"\x04team\x18\x01 \x01(\v2\v.pr.v1.TeamR\x04team\"2\n" +
+	"\x0fAddTeamResponse\x12\x1f\n" +
+	"\x04team\x18\x01 \x01(\v2\v.pr.v1.TeamR\x04team\"-\n" +
+	"\x0eGetTeamRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\"2\n" +
+	"\x0fGetTeamResponse\x12\x1f\n" +
+	"\x04team\x18\x01 \x01(\v2\v.pr.v1.TeamR\x04team\"a\n" +
+	"\x15AddTeamMembersRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x12+\n" +
+	"\amembers\x18\x02 \x03(\v2\x11.pr.v1.TeamMemberR\amembers\"9\n" +
+	"\x16AddTeamMembersResponse\x12\x1f\n" +
+	"\x04team\x18\x01 \x01(\v2\v.pr.v1.TeamR\x04team\"R\n" +
+	"\x18RemoveTeamMembersRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x12\x19\n" +
+	"\buser_ids\x18\x02 \x03(\tR\auserIds\"\x94\x01\n" +
+	"\x19RemoveTeamMembersResponse\x12\x1f\n" +
+	"\x04team\x18\x01 \x01(\v2\v.pr.v1.TeamR\x04team\x12-\n" +
+	"\x12reassigned_reviews\x18\x02 \x01(\x05R\x11reassignedReviews\x12'\n" +
+	"\x0fremoved_reviews\x18\x03 \x01(\x05R\x0eremovedReviews\"T\n" +
+	"\x11RenameTeamRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x12\"\n" +
+	"\rnew_team_name\x18\x02 \x01(\tR\vnewTeamName\"5\n" +
+	"\x12RenameTeamResponse\x12\x1f\n" +
+	"\x04team\x18\x01 \x01(\v2\v.pr.v1.TeamR\x04team\"0\n" +
+	"\x11DeleteTeamRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\"1\n" +
+	"\x12DeleteTeamResponse\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\"N\n" +
+	"\x10SyncTeamsRequest\x12!\n" +
+	"\x05teams\x18\x01 \x03(\v2\v.pr.v1.TeamR\x05teams\x12\x17\n" +
+	"\adry_run\x18\x02 \x01(\bR\x06dryRun\"W\n" +
+	"\x11SyncTeamsResponse\x12\x17\n" +
+	"\adry_run\x18\x01 \x01(\bR\x06dryRun\x12)\n" +
+	"\x05teams\x18\x02 \x03(\v2\x13.pr.v1.TeamSyncDiffR\x05teams\"7\n" +
+	"\x12ImportTeamsRequest\x12!\n" +
+	"\x05teams\x18\x01 \x03(\v2\v.pr.v1.TeamR\x05teams\"B\n" +
+	"\x13ImportTeamsResponse\x12+\n" +
+	"\x06result\x18\x01 \x01(\v2\x13.pr.v1.ImportResultR\x06result\"N\n" +
+	"\x16SetUserIsActiveRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tis_active\x18\x02 \x01(\bR\bisActive\":\n" +
+	"\x17SetUserIsActiveResponse\x12\x1f\n" +
+	"\x04user\x18\x01 \x01(\v2\v.pr.v1.UserR\x04user\")\n" +
+	"\x0eGetUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"2\n" +
+	"\x0fGetUserResponse\x12\x1f\n" +
+	"\x04user\x18\x01 \x01(\v2\v.pr.v1.UserR\x04user\"\x8a\x01\n" +
+	"\x11UpdateUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
+	"\busername\x18\x02 \x01(\tH\x00R\busername\x88\x01\x01\x12 \n" +
+	"\tis_active\x18\x03 \x01(\bH\x01R\bisActive\x88\x01\x01B\v\n" +
+	"\t_usernameB\f\n" +
+	"\n" +
+	"_is_active\"5\n" +
+	"\x12UpdateUserResponse\x12\x1f\n" +
+	"\x04user\x18\x01 \x01(\v2\v.pr.v1.UserR\x04user\"W\n" +
+	"\x11DeleteUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12)\n" +
+	"\x04mode\x18\x02 \x01(\x0e2\x15.pr.v1.UserDeleteModeR\x04mode\"A\n" +
+	"\x12DeleteUserResponse\x12+\n" +
+	"\x06result\x18\x01 \x01(\v2\x13.pr.v1.UserDeletionR\x06result\"\xf7\x01\n" +
+	"\x10ListUsersRequest\x12 \n" +
+	"\tteam_name\x18\x01 \x01(\tH\x00R\bteamName\x88\x01\x01\x12 \n" +
+	"\tis_active\x18\x02 \x01(\bH\x01R\bisActive\x88\x01\x01\x12\x1f\n" +
+	"\busername\x18\x03 \x01(\tH\x02R\busername\x88\x01\x01\x12'\n" +
+	"\x0finclude_deleted\x18\x04 \x01(\bR\x0eincludeDeleted\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x06 \x01(\tR\x06cursorB\f\n" +
+	"\n" +
+	"_team_nameB\f\n" +
+	"\n" +
+	"_is_activeB\v\n" +
+	"\t_username\"W\n" +
+	"\x11ListUsersResponse\x12!\n" +
+	"\x05users\x18\x01 \x03(\v2\v.pr.v1.UserR\x05users\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"\xbc\x02\n" +
+	"\x15GetUserReviewsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x120\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x18.pr.v1.PullRequestStatusR\x06status\x12?\n" +
+	"\rcreated_after\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12&\n" +
+	"\x05order\x18\x05 \x01(\x0e2\x10.pr.v1.SortOrderR\x05order\x12\x14\n" +
+	"\x05limit\x18\x06 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\a \x01(\tR\x06cursor\"\x90\x01\n" +
+	"\x16GetUserReviewsResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12<\n" +
+	"\rpull_requests\x18\x02 \x03(\v2\x17.pr.v1.ReviewAssignmentR\fpullRequests\x12\x1f\n" +
+	"\vnext_cursor\x18\x03 \x01(\tR\n" +
+	"nextCursor\"\x8b\x01\n" +
+	"\x18CreatePullRequestRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12*\n" +
+	"\x11pull_request_name\x18\x02 \x01(\tR\x0fpullRequestName\x12\x1b\n" +
+	"\tauthor_id\x18\x03 \x01(\tR\bauthorId\"?\n" +
+	"\x19CreatePullRequestResponse\x12\"\n" +
+	"\x02pr\x18\x01 \x01(\v2\x12.pr.v1.PullRequestR\x02pr\"?\n" +
+	"\x15GetPullRequestRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\"<\n" +
+	"\x16GetPullRequestResponse\x12\"\n" +
+	"\x02pr\x18\x01 \x01(\v2\x12.pr.v1.PullRequestR\x02pr\"\xe0\x03\n" +
+	"\x17ListPullRequestsRequest\x12 \n" +
+	"\tauthor_id\x18\x01 \x01(\tH\x00R\bauthorId\x88\x01\x01\x12 \n" +
+	"\tteam_name\x18\x02 \x01(\tH\x01R\bteamName\x88\x01\x01\x120\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x18.pr.v1.PullRequestStatusR\x06status\x12$\n" +
+	"\vreviewer_id\x18\x04 \x01(\tH\x02R\n" +
+	"reviewerId\x88\x01\x01\x12\x19\n" +
+	"\x05title\x18\x05 \x01(\tH\x03R\x05title\x88\x01\x01\x12?\n" +
+	"\rcreated_after\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12&\n" +
+	"\x05order\x18\b \x01(\x0e2\x10.pr.v1.SortOrderR\x05order\x12\x14\n" +
+	"\x05limit\x18\t \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\n" +
+	" \x01(\tR\x06cursorB\f\n" +
+	"\n" +
+	"_author_idB\f\n" +
+	"\n" +
+	"_team_nameB\x0e\n" +
+	"\f_reviewer_idB\b\n" +
+	"\x06_title\"t\n" +
+	"\x18ListPullRequestsResponse\x127\n" +
+	"\rpull_requests\x18\x01 \x03(\v2\x12.pr.v1.PullRequestR\fpullRequests\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"A\n" +
+	"\x17MergePullRequestRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\">\n" +
+	"\x18MergePullRequestResponse\x12\"\n" +
+	"\x02pr\x18\x01 \x01(\v2\x12.pr.v1.PullRequestR\x02pr\"d\n" +
+	"\x1aReassignPullRequestRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12\x1e\n" +
+	"\vold_user_id\x18\x02 \x01(\tR\toldUserId\"b\n" +
+	"\x1bReassignPullRequestResponse\x12\"\n" +
+	"\x02pr\x18\x01 \x01(\v2\x12.pr.v1.PullRequestR\x02pr\x12\x1f\n" +
+	"\vreplaced_by\x18\x02 \x01(\tR\n" +
+	"replacedBy\"u\n" +
+	"\x17GetReviewerStatsRequest\x12.\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\"N\n" +
+	"\x18GetReviewerStatsResponse\x122\n" +
+	"\treviewers\x18\x01 \x03(\v2\x14.pr.v1.ReviewerStatsR\treviewers\"q\n" +
+	"\x13GetTeamStatsRequest\x12.\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\">\n" +
+	"\x14GetTeamStatsResponse\x12&\n" +
+	"\x05teams\x18\x01 \x03(\v2\x10.pr.v1.TeamStatsR\x05teams\"q\n" +
+	"\x13GetCycleTimeRequest\x12.\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\"F\n" +
+	"\x14GetCycleTimeResponse\x12.\n" +
+	"\x06report\x18\x01 \x01(\v2\x16.pr.v1.CycleTimeReportR\x06report\"k\n" +
+	"\rExportRequest\x12.\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\"O\n" +
+	"\fExportRecord\x12\x14\n" +
+	"\x05table\x18\x01 \x01(\tR\x05table\x12)\n" +
+	"\x03row\x18\x02 \x01(\v2\x17.google.protobuf.StructR\x03row\"\xe5\x01\n" +
+	"\x13CreateAPIKeyRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12%\n" +
+	"\x04role\x18\x02 \x01(\x0e2\x11.pr.v1.APIKeyRoleR\x04role\x12\x1c\n" +
+	"\auser_id\x18\x03 \x01(\tH\x00R\x06userId\x88\x01\x01\x12 \n" +
+	"\tteam_name\x18\x04 \x01(\tH\x01R\bteamName\x88\x01\x01\x129\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAtB\n" +
+	"\n" +
+	"\b_user_idB\f\n" +
+	"\n" +
+	"_team_name\"T\n" +
+	"\x14CreateAPIKeyResponse\x12&\n" +
+	"\aapi_key\x18\x01 \x01(\v2\r.pr.v1.APIKeyR\x06apiKey\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\"\x14\n" +
+	"\x12ListAPIKeysRequest\"?\n" +
+	"\x13ListAPIKeysResponse\x12(\n" +
+	"\bapi_keys\x18\x01 \x03(\v2\r.pr.v1.APIKeyR\aapiKeys\"%\n" +
+	"\x13RevokeAPIKeyRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\">\n" +
+	"\x14RevokeAPIKeyResponse\x12&\n" +
+	"\aapi_key\x18\x01 \x01(\v2\r.pr.v1.APIKeyR\x06apiKey*j\n" +
+	"\x0eTeamMemberRole\x12 \n" +
+	"\x1cTEAM_MEMBER_ROLE_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17TEAM_MEMBER_ROLE_MEMBER\x10\x01\x12\x19\n" +
+	"\x15TEAM_MEMBER_ROLE_LEAD\x10\x02*v\n" +
+	"\x11PullRequestStatus\x12#\n" +
+	"\x1fPULL_REQUEST_STATUS_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18PULL_REQUEST_STATUS_OPEN\x10\x01\x12\x1e\n" +
+	"\x1aPULL_REQUEST_STATUS_MERGED\x10\x02*P\n" +
+	"\tSortOrder\x12\x1a\n" +
+	"\x16SORT_ORDER_UNSPECIFIED\x10\x00\x12\x12\n" +
+	"\x0eSORT_ORDER_ASC\x10\x01\x12\x13\n" +
+	"\x0fSORT_ORDER_DESC\x10\x02*\x8d\x01\n" +
+	"\x0eUserDeleteMode\x12 \n" +
+	"\x1cUSER_DELETE_MODE_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16USER_DELETE_MODE_BLOCK\x10\x01\x12\x1d\n" +
+	"\x19USER_DELETE_MODE_REASSIGN\x10\x02\x12\x1e\n" +
+	"\x1aUSER_DELETE_MODE_ANONYMIZE\x10\x03*Y\n" +
+	"\n" +
+	"APIKeyRole\x12\x1c\n" +
+	"\x18API_KEY_ROLE_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12API_KEY_ROLE_ADMIN\x10\x01\x12\x15\n" +
+	"\x11API_KEY_ROLE_USER\x10\x022\xf8\x0e\n" +
+	"\tPRService\x128\n" +
+	"\aAddTeam\x12\x15.pr.v1.AddTeamRequest\x1a\x16.pr.v1.AddTeamResponse\x128\n" +
+	"\aGetTeam\x12\x15.pr.v1.GetTeamRequest\x1a\x16.pr.v1.GetTeamResponse\x12M\n" +
+	"\x0eAddTeamMembers\x12\x1c.pr.v1.AddTeamMembersRequest\x1a\x1d.pr.v1.AddTeamMembersResponse\x12V\n" +
+	"\x11RemoveTeamMembers\x12\x1f.pr.v1.RemoveTeamMembersRequest\x1a .pr.v1.RemoveTeamMembersResponse\x12A\n" +
+	"\n" +
+	"RenameTeam\x12\x18.pr.v1.RenameTeamRequest\x1a\x19.pr.v1.RenameTeamResponse\x12A\n" +
+	"\n" +
+	"DeleteTeam\x12\x18.pr.v1.DeleteTeamRequest\x1a\x19.pr.v1.DeleteTeamResponse\x12>\n" +
+	"\tSyncTeams\x12\x17.pr.v1.SyncTeamsRequest\x1a\x18.pr.v1.SyncTeamsResponse\x12D\n" +
+	"\vImportTeams\x12\x19.pr.v1.ImportTeamsRequest\x1a\x1a.pr.v1.ImportTeamsResponse\x12P\n" +
+	"\x0fSetUserIsActive\x12\x1d.pr.v1.SetUserIsActiveRequest\x1a\x1e.pr.v1.SetUserIsActiveResponse\x128\n" +
+	"\aGetUser\x12\x15.pr.v1.GetUserRequest\x1a\x16.pr.v1.GetUserResponse\x12A\n" +
+	"\n" +
+	"UpdateUser\x12\x18.pr.v1.UpdateUserRequest\x1a\x19.pr.v1.UpdateUserResponse\x12A\n" +
+	"\n" +
+	"DeleteUser\x12\x18.pr.v1.DeleteUserRequest\x1a\x19.pr.v1.DeleteUserResponse\x12>\n" +
+	"\tListUsers\x12\x17.pr.v1.ListUsersRequest\x1a\x18.pr.v1.ListUsersResponse\x12M\n" +
+	"\x0eGetUserReviews\x12\x1c.pr.v1.GetUserReviewsRequest\x1a\x1d.pr.v1.GetUserReviewsResponse\x12V\n" +
+	"\x11CreatePullRequest\x12\x1f.pr.v1.CreatePullRequestRequest\x1a .pr.v1.CreatePullRequestResponse\x12M\n" +
+	"\x0eGetPullRequest\x12\x1c.pr.v1.GetPullRequestRequest\x1a\x1d.pr.v1.GetPullRequestResponse\x12S\n" +
+	"\x10ListPullRequests\x12\x1e.pr.v1.ListPullRequestsRequest\x1a\x1f.pr.v1.ListPullRequestsResponse\x12S\n" +
+	"\x10MergePullRequest\x12\x1e.pr.v1.MergePullRequestRequest\x1a\x1f.pr.v1.MergePullRequestResponse\x12\\\n" +
+	"\x13ReassignPullRequest\x12!.pr.v1.ReassignPullRequestRequest\x1a\".pr.v1.ReassignPullRequestResponse\x12S\n" +
+	"\x10GetReviewerStats\x12\x1e.pr.v1.GetReviewerStatsRequest\x1a\x1f.pr.v1.GetReviewerStatsResponse\x12G\n" +
+	"\fGetTeamStats\x12\x1a.pr.v1.GetTeamStatsRequest\x1a\x1b.pr.v1.GetTeamStatsResponse\x12G\n" +
+	"\fGetCycleTime\x12\x1a.pr.v1.GetCycleTimeRequest\x1a\x1b.pr.v1.GetCycleTimeResponse\x125\n" +
+	"\x06Export\x12\x14.pr.v1.ExportRequest\x1a\x13.pr.v1.ExportRecord0\x01\x12G\n" +
+	"\fCreateAPIKey\x12\x1a.pr.v1.CreateAPIKeyRequest\x1a\x1b.pr.v1.CreateAPIKeyResponse\x12D\n" +
+	"\vListAPIKeys\x12\x19.pr.v1.ListAPIKeysRequest\x1a\x1a.pr.v1.ListAPIKeysResponse\x12G\n" +
+	"\fRevokeAPIKey\x12\x1a.pr.v1.RevokeAPIKeyRequest\x1a\x1b.pr.v1.RevokeAPIKeyResponseB5Z3github.com/chimort/avito_test_task/proto/pr/v1;prv1b\x06proto3"
+
+var (
+	file_pr_v1_pr_proto_rawDescOnce sync.Once
+	file_pr_v1_pr_proto_rawDescData []byte
+)
+
+func file_pr_v1_pr_proto_rawDescGZIP() []byte {
+	file_pr_v1_pr_proto_rawDescOnce.Do(func() {
+		file_pr_v1_pr_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pr_v1_pr_proto_rawDesc), len(file_pr_v1_pr_proto_rawDesc)))
+	})
+	return file_pr_v1_pr_proto_rawDescData
+}
+
+var file_pr_v1_pr_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_pr_v1_pr_proto_msgTypes = make([]protoimpl.MessageInfo, 66)
+var file_pr_v1_pr_proto_goTypes = []any{
+	(TeamMemberRole)(0),                 // 0: pr.v1.TeamMemberRole
+	(PullRequestStatus)(0),              // 1: pr.v1.PullRequestStatus
+	(SortOrder)(0),                      // 2: pr.v1.SortOrder
+	(UserDeleteMode)(0),                 // 3: pr.v1.UserDeleteMode
+	(APIKeyRole)(0),                     // 4: pr.v1.APIKeyRole
+	(*TeamMember)(nil),                  // 5: pr.v1.TeamMember
+	(*Team)(nil),                        // 6: pr.v1.Team
+	(*User)(nil),                        // 7: pr.v1.User
+	(*PullRequest)(nil),                 // 8: pr.v1.PullRequest
+	(*ReviewAssignment)(nil),            // 9: pr.v1.ReviewAssignment
+	(*TeamSyncDiff)(nil),                // 10: pr.v1.TeamSyncDiff
+	(*ImportResult)(nil),                // 11: pr.v1.ImportResult
+	(*UserDeletion)(nil),                // 12: pr.v1.UserDeletion
+	(*ReviewerStats)(nil),               // 13: pr.v1.ReviewerStats
+	(*TeamStats)(nil),                   // 14: pr.v1.TeamStats
+	(*CycleTimeStats)(nil),              // 15: pr.v1.CycleTimeStats
+	(*CycleTimeBucket)(nil),             // 16: pr.v1.CycleTimeBucket
+	(*CycleTimeReport)(nil),             // 17: pr.v1.CycleTimeReport
+	(*APIKey)(nil),                      // 18: pr.v1.APIKey
+	(*AddTeamRequest)(nil),              // 19: pr.v1.AddTeamRequest
+	(*AddTeamResponse)(nil),             // 20: pr.v1.AddTeamResponse
+	(*GetTeamRequest)(nil),              // 21: pr.v1.GetTeamRequest
+	(*GetTeamResponse)(nil),             // 22: pr.v1.GetTeamResponse
+	(*AddTeamMembersRequest)(nil),       // 23: pr.v1.AddTeamMembersRequest
+	(*AddTeamMembersResponse)(nil),      // 24: pr.v1.AddTeamMembersResponse
+	(*RemoveTeamMembersRequest)(nil),    // 25: pr.v1.RemoveTeamMembersRequest
+	(*RemoveTeamMembersResponse)(nil),   // 26: pr.v1.RemoveTeamMembersResponse
+	(*RenameTeamRequest)(nil),           // 27: pr.v1.RenameTeamRequest
+	(*RenameTeamResponse)(nil),          // 28: pr.v1.RenameTeamResponse
+	(*DeleteTeamRequest)(nil),           // 29: pr.v1.DeleteTeamRequest
+	(*DeleteTeamResponse)(nil),          // 30: pr.v1.DeleteTeamResponse
+	(*SyncTeamsRequest)(nil),            // 31: pr.v1.SyncTeamsRequest
+	(*SyncTeamsResponse)(nil),           // 32: pr.v1.SyncTeamsResponse
+	(*ImportTeamsRequest)(nil),          // 33: pr.v1.ImportTeamsRequest
+	(*ImportTeamsResponse)(nil),         // 34: pr.v1.ImportTeamsResponse
+	(*SetUserIsActiveRequest)(nil),      // 35: pr.v1.SetUserIsActiveRequest
+	(*SetUserIsActiveResponse)(nil),     // 36: pr.v1.SetUserIsActiveResponse
+	(*GetUserRequest)(nil),              // 37: pr.v1.GetUserRequest
+	(*GetUserResponse)(nil),             // 38: pr.v1.GetUserResponse
+	(*UpdateUserRequest)(nil),           // 39: pr.v1.UpdateUserRequest
+	(*UpdateUserResponse)(nil),          // 40: pr.v1.UpdateUserResponse
+	(*DeleteUserRequest)(nil),           // 41: pr.v1.DeleteUserRequest
+	(*DeleteUserResponse)(nil),          // 42: pr.v1.DeleteUserResponse
+	(*ListUsersRequest)(nil),            // 43: pr.v1.ListUsersRequest
+	(*ListUsersResponse)(nil),           // 44: pr.v1.ListUsersResponse
+	(*GetUserReviewsRequest)(nil),       // 45: pr.v1.GetUserReviewsRequest
+	(*GetUserReviewsResponse)(nil),      // 46: pr.v1.GetUserReviewsResponse
+	(*CreatePullRequestRequest)(nil),    // 47: pr.v1.CreatePullRequestRequest
+	(*CreatePullRequestResponse)(nil),   // 48: pr.v1.CreatePullRequestResponse
+	(*GetPullRequestRequest)(nil),       // 49: pr.v1.GetPullRequestRequest
+	(*GetPullRequestResponse)(nil),      // 50: pr.v1.GetPullRequestResponse
+	(*ListPullRequestsRequest)(nil),     // 51: pr.v1.ListPullRequestsRequest
+	(*ListPullRequestsResponse)(nil),    // 52: pr.v1.ListPullRequestsResponse
+	(*MergePullRequestRequest)(nil),     // 53: pr.v1.MergePullRequestRequest
+	(*MergePullRequestResponse)(nil),    // 54: pr.v1.MergePullRequestResponse
+	(*ReassignPullRequestRequest)(nil),  // 55: pr.v1.ReassignPullRequestRequest
+	(*ReassignPullRequestResponse)(nil), // 56: pr.v1.ReassignPullRequestResponse
+	(*GetReviewerStatsRequest)(nil),     // 57: pr.v1.GetReviewerStatsRequest
+	(*GetReviewerStatsResponse)(nil),    // 58: pr.v1.GetReviewerStatsResponse
+	(*GetTeamStatsRequest)(nil),         // 59: pr.v1.GetTeamStatsRequest
+	(*GetTeamStatsResponse)(nil),        // 60: pr.v1.GetTeamStatsResponse
+	(*GetCycleTimeRequest)(nil),         // 61: pr.v1.GetCycleTimeRequest
+	(*GetCycleTimeResponse)(nil),        // 62: pr.v1.GetCycleTimeResponse
+	(*ExportRequest)(nil),               // 63: pr.v1.ExportRequest
+	(*ExportRecord)(nil),                // 64: pr.v1.ExportRecord
+	(*CreateAPIKeyRequest)(nil),         // 65: pr.v1.CreateAPIKeyRequest
+	(*CreateAPIKeyResponse)(nil),        // 66: pr.v1.CreateAPIKeyResponse
+	(*ListAPIKeysRequest)(nil),          // 67: pr.v1.ListAPIKeysRequest
+	(*ListAPIKeysResponse)(nil),         // 68: pr.v1.ListAPIKeysResponse
+	(*RevokeAPIKeyRequest)(nil),         // 69: pr.v1.RevokeAPIKeyRequest
+	(*RevokeAPIKeyResponse)(nil),        // 70: pr.v1.RevokeAPIKeyResponse
+	(*timestamppb.Timestamp)(nil),       // 71: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),             // 72: google.protobuf.Struct
+}
+var file_pr_v1_pr_proto_depIdxs = []int32{
+	0,  // 0: pr.v1.TeamMember.role:type_name -> pr.v1.TeamMemberRole
+	5,  // 1: pr.v1.Team.members:type_name -> pr.v1.TeamMember
+	71, // 2: pr.v1.User.deleted_at:type_name -> google.protobuf.Timestamp
+	1,  // 3: pr.v1.PullRequest.status:type_name -> pr.v1.PullRequestStatus
+	71, // 4: pr.v1.PullRequest.created_at:type_name -> google.protobuf.Timestamp
+	71, // 5: pr.v1.PullRequest.merged_at:type_name -> google.protobuf.Timestamp
+	1,  // 6: pr.v1.ReviewAssignment.status:type_name -> pr.v1.PullRequestStatus
+	71, // 7: pr.v1.ReviewAssignment.created_at:type_name -> google.protobuf.Timestamp
+	71, // 8: pr.v1.ReviewAssignment.assigned_at:type_name -> google.protobuf.Timestamp
+	3,  // 9: pr.v1.UserDeletion.mode:type_name -> pr.v1.UserDeleteMode
+	71, // 10: pr.v1.CycleTimeBucket.week_start:type_name -> google.protobuf.Timestamp
+	15, // 11: pr.v1.CycleTimeReport.by_team:type_name -> pr.v1.CycleTimeStats
+	15, // 12: pr.v1.CycleTimeReport.by_author:type_name -> pr.v1.CycleTimeStats
+	15, // 13: pr.v1.CycleTimeReport.by_reviewer:type_name -> pr.v1.CycleTimeStats
+	16, // 14: pr.v1.CycleTimeReport.weekly:type_name -> pr.v1.CycleTimeBucket
+	4,  // 15: pr.v1.APIKey.role:type_name -> pr.v1.APIKeyRole
+	71, // 16: pr.v1.APIKey.created_at:type_name -> google.protobuf.Timestamp
+	71, // 17: pr.v1.APIKey.expires_at:type_name -> google.protobuf.Timestamp
+	71, // 18: pr.v1.APIKey.last_used_at:type_name -> google.protobuf.Timestamp
+	71, // 19: pr.v1.APIKey.revoked_at:type_name -> google.protobuf.Timestamp
+	6,  // 20: pr.v1.AddTeamRequest.team:type_name -> pr.v1.Team
+	6,  // 21: pr.v1.AddTeamResponse.team:type_name -> pr.v1.Team
+	6,  // 22: pr.v1.GetTeamResponse.team:type_name -> pr.v1.Team
+	5,  // 23: pr.v1.AddTeamMembersRequest.members:type_name -> pr.v1.TeamMember
+	6,  // 24: pr.v1.AddTeamMembersResponse.team:type_name -> pr.v1.Team
+	6,  // 25: pr.v1.RemoveTeamMembersResponse.team:type_name -> pr.v1.Team
+	6,  // 26: pr.v1.RenameTeamResponse.team:type_name -> pr.v1.Team
+	6,  // 27: pr.v1.SyncTeamsRequest.teams:type_name -> pr.v1.Team
+	10, // 28: pr.v1.SyncTeamsResponse.teams:type_name -> pr.v1.TeamSyncDiff
+	6,  // 29: pr.v1.ImportTeamsRequest.teams:type_name -> pr.v1.Team
+	11, // 30: pr.v1.ImportTeamsResponse.result:type_name -> pr.v1.ImportResult
+	7,  // 31: pr.v1.SetUserIsActiveResponse.user:type_name -> pr.v1.User
+	7,  // 32: pr.v1.GetUserResponse.user:type_name -> pr.v1.User
+	7,  // 33: pr.v1.UpdateUserResponse.user:type_name -> pr.v1.User
+	3,  // 34: pr.v1.DeleteUserRequest.mode:type_name -> pr.v1.UserDeleteMode
+	12, // 35: pr.v1.DeleteUserResponse.result:type_name -> pr.v1.UserDeletion
+	7,  // 36: pr.v1.ListUsersResponse.users:type_name -> pr.v1.User
+	1,  // 37: pr.v1.GetUserReviewsRequest.status:type_name -> pr.v1.PullRequestStatus
+	71, // 38: pr.v1.GetUserReviewsRequest.created_after:type_name -> google.protobuf.Timestamp
+	71, // 39: pr.v1.GetUserReviewsRequest.created_before:type_name -> google.protobuf.Timestamp
+	2,  // 40: pr.v1.GetUserReviewsRequest.order:type_name -> pr.v1.SortOrder
+	9,  // 41: pr.v1.GetUserReviewsResponse.pull_requests:type_name -> pr.v1.ReviewAssignment
+	8,  // 42: pr.v1.CreatePullRequestResponse.pr:type_name -> pr.v1.PullRequest
+	8,  // 43: pr.v1.GetPullRequestResponse.pr:type_name -> pr.v1.PullRequest
+	1,  // 44: pr.v1.ListPullRequestsRequest.status:type_name -> pr.v1.PullRequestStatus
+	71, // 45: pr.v1.ListPullRequestsRequest.created_after:type_name -> google.protobuf.Timestamp
+	71, // 46: pr.v1.ListPullRequestsRequest.created_before:type_name -> google.protobuf.Timestamp
+	2,  // 47: pr.v1.ListPullRequestsRequest.order:type_name -> pr.v1.SortOrder
+	8,  // 48: pr.v1.ListPullRequestsResponse.pull_requests:type_name -> pr.v1.PullRequest
+	8,  // 49: pr.v1.MergePullRequestResponse.pr:type_name -> pr.v1.PullRequest
+	8,  // 50: pr.v1.ReassignPullRequestResponse.pr:type_name -> pr.v1.PullRequest
+	71, // 51: pr.v1.GetReviewerStatsRequest.from:type_name -> google.protobuf.Timestamp
+	71, // 52: pr.v1.GetReviewerStatsRequest.to:type_name -> google.protobuf.Timestamp
+	13, // 53: pr.v1.GetReviewerStatsResponse.reviewers:type_name -> pr.v1.ReviewerStats
+	71, // 54: pr.v1.GetTeamStatsRequest.from:type_name -> google.protobuf.Timestamp
+	71, // 55: pr.v1.GetTeamStatsRequest.to:type_name -> google.protobuf.Timestamp
+	14, // 56: pr.v1.GetTeamStatsResponse.teams:type_name -> pr.v1.TeamStats
+	71, // 57: pr.v1.GetCycleTimeRequest.from:type_name -> google.protobuf.Timestamp
+	71, // 58: pr.v1.GetCycleTimeRequest.to:type_name -> google.protobuf.Timestamp
+	17, // 59: pr.v1.GetCycleTimeResponse.report:type_name -> pr.v1.CycleTimeReport
+	71, // 60: pr.v1.ExportRequest.from:type_name -> google.protobuf.Timestamp
+	71, // 61: pr.v1.ExportRequest.to:type_name -> google.protobuf.Timestamp
+	72, // 62: pr.v1.ExportRecord.row:type_name -> google.protobuf.Struct
+	4,  // 63: pr.v1.CreateAPIKeyRequest.role:type_name -> pr.v1.APIKeyRole
+	71, // 64: pr.v1.CreateAPIKeyRequest.expires_at:type_name -> google.protobuf.Timestamp
+	18, // 65: pr.v1.CreateAPIKeyResponse.api_key:type_name -> pr.v1.APIKey
+	18, // 66: pr.v1.ListAPIKeysResponse.api_keys:type_name -> pr.v1.APIKey
+	18, // 67: pr.v1.RevokeAPIKeyResponse.api_key:type_name -> pr.v1.APIKey
+	19, // 68: pr.v1.PRService.AddTeam:input_type -> pr.v1.AddTeamRequest
+	21, // 69: pr.v1.PRService.GetTeam:input_type -> pr.v1.GetTeamRequest
+	23, // 70: pr.v1.PRService.AddTeamMembers:input_type -> pr.v1.AddTeamMembersRequest
+	25, // 71: pr.v1.PRService.RemoveTeamMembers:input_type -> pr.v1.RemoveTeamMembersRequest
+	27, // 72: pr.v1.PRService.RenameTeam:input_type -> pr.v1.RenameTeamRequest
+	29, // 73: pr.v1.PRService.DeleteTeam:input_type -> pr.v1.DeleteTeamRequest
+	31, // 74: pr.v1.PRService.SyncTeams:input_type -> pr.v1.SyncTeamsRequest
+	33, // 75: pr.v1.PRService.ImportTeams:input_type -> pr.v1.ImportTeamsRequest
+	35, // 76: pr.v1.PRService.SetUserIsActive:input_type -> pr.v1.SetUserIsActiveRequest
+	37, // 77: pr.v1.PRService.GetUser:input_type -> pr.v1.GetUserRequest
+	39, // 78: pr.v1.PRService.UpdateUser:input_type -> pr.v1.UpdateUserRequest
+	41, // 79: pr.v1.PRService.DeleteUser:input_type -> pr.v1.DeleteUserRequest
+	43, // 80: pr.v1.PRService.ListUsers:input_type -> pr.v1.ListUsersRequest
+	45, // 81: pr.v1.PRService.GetUserReviews:input_type -> pr.v1.GetUserReviewsRequest
+	47, // 82: pr.v1.PRService.CreatePullRequest:input_type -> pr.v1.CreatePullRequestRequest
+	49, // 83: pr.v1.PRService.GetPullRequest:input_type -> pr.v1.GetPullRequestRequest
+	51, // 84: pr.v1.PRService.ListPullRequests:input_type -> pr.v1.ListPullRequestsRequest
+	53, // 85: pr.v1.PRService.MergePullRequest:input_type -> pr.v1.MergePullRequestRequest
+	55, // 86: pr.v1.PRService.ReassignPullRequest:input_type -> pr.v1.ReassignPullRequestRequest
+	57, // 87: pr.v1.PRService.GetReviewerStats:input_type -> pr.v1.GetReviewerStatsRequest
+	59, // 88: pr.v1.PRService.GetTeamStats:input_type -> pr.v1.GetTeamStatsRequest
+	61, // 89: pr.v1.PRService.GetCycleTime:input_type -> pr.v1.GetCycleTimeRequest
+	63, // 90: pr.v1.PRService.Export:input_type -> pr.v1.ExportRequest
+	65, // 91: pr.v1.PRService.CreateAPIKey:input_type -> pr.v1.CreateAPIKeyRequest
+	67, // 92: pr.v1.PRService.ListAPIKeys:input_type -> pr.v1.ListAPIKeysRequest
+	69, // 93: pr.v1.PRService.RevokeAPIKey:input_type -> pr.v1.RevokeAPIKeyRequest
+	20, // 94: pr.v1.PRService.AddTeam:output_type -> pr.v1.AddTeamResponse
+	22, // 95: pr.v1.PRService.GetTeam:output_type -> pr.v1.GetTeamResponse
+	24, // 96: pr.v1.PRService.AddTeamMembers:output_type -> pr.v1.AddTeamMembersResponse
+	26, // 97: pr.v1.PRService.RemoveTeamMembers:output_type -> pr.v1.RemoveTeamMembersResponse
+	28, // 98: pr.v1.PRService.RenameTeam:output_type -> pr.v1.RenameTeamResponse
+	30, // 99: pr.v1.PRService.DeleteTeam:output_type -> pr.v1.DeleteTeamResponse
+	32, // 100: pr.v1.PRService.SyncTeams:output_type -> pr.v1.SyncTeamsResponse
+	34, // 101: pr.v1.PRService.ImportTeams:output_type -> pr.v1.ImportTeamsResponse
+	36, // 102: pr.v1.PRService.SetUserIsActive:output_type -> pr.v1.SetUserIsActiveResponse
+	38, // 103: pr.v1.PRService.GetUser:output_type -> pr.v1.GetUserResponse
+	40, // 104: pr.v1.PRService.UpdateUser:output_type -> pr.v1.UpdateUserResponse
+	42, // 105: pr.v1.PRService.DeleteUser:output_type -> pr.v1.DeleteUserResponse
+	44, // 106: pr.v1.PRService.ListUsers:output_type -> pr.v1.ListUsersResponse
+	46, // 107: pr.v1.PRService.GetUserReviews:output_type -> pr.v1.GetUserReviewsResponse
+	48, // 108: pr.v1.PRService.CreatePullRequest:output_type -> pr.v1.CreatePullRequestResponse
+	50, // 109: pr.v1.PRService.GetPullRequest:output_type -> pr.v1.GetPullRequestResponse
+	52, // 110: pr.v1.PRService.ListPullRequests:output_type -> pr.v1.ListPullRequestsResponse
+	54, // 111: pr.v1.PRService.MergePullRequest:output_type -> pr.v1.MergePullRequestResponse
+	56, // 112: pr.v1.PRService.ReassignPullRequest:output_type -> pr.v1.ReassignPullRequestResponse
+	58, // 113: pr.v1.PRService.GetReviewerStats:output_type -> pr.v1.GetReviewerStatsResponse
+	60, // 114: pr.v1.PRService.GetTeamStats:output_type -> pr.v1.GetTeamStatsResponse
+	62, // 115: pr.v1.PRService.GetCycleTime:output_type -> pr.v1.GetCycleTimeResponse
+	64, // 116: pr.v1.PRService.Export:output_type -> pr.v1.ExportRecord
+	66, // 117: pr.v1.PRService.CreateAPIKey:output_type -> pr.v1.CreateAPIKeyResponse
+	68, // 118: pr.v1.PRService.ListAPIKeys:output_type -> pr.v1.ListAPIKeysResponse
+	70, // 119: pr.v1.PRService.RevokeAPIKey:output_type -> pr.v1.RevokeAPIKeyResponse
+	94, // [94:120] is the sub-list for method output_type
+	68, // [68:94] is the sub-list for method input_type
+	68, // [68:68] is the sub-list for extension type_name
+	68, // [68:68] is the sub-list for extension extendee
+	0,  // [0:68] is the sub-list for field type_name
+}
+
+func init() { file_pr_v1_pr_proto_init() }
+func file_pr_v1_pr_proto_init() {
+	if File_pr_v1_pr_proto != nil {
+		return
+	}
+	file_pr_v1_pr_proto_msgTypes[13].OneofWrappers = []any{}
+	file_pr_v1_pr_proto_msgTypes[34].OneofWrappers = []any{}
+	file_pr_v1_pr_proto_msgTypes[38].OneofWrappers = []any{}
+	file_pr_v1_pr_proto_msgTypes[46].OneofWrappers = []any{}
+	file_pr_v1_pr_proto_msgTypes[60].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pr_v1_pr_proto_rawDesc), len(file_pr_v1_pr_proto_rawDesc)),
+			NumEnums:      5,
+			NumMessages:   66,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pr_v1_pr_proto_goTypes,
+		DependencyIndexes: file_pr_v1_pr_proto_depIdxs,
+		EnumInfos:         file_pr_v1_pr_proto_enumTypes,
+		MessageInfos:      file_pr_v1_pr_proto_msgTypes,
+	}.Build()
+	File_pr_v1_pr_proto = out.File
+	file_pr_v1_pr_proto_goTypes = nil
+	file_pr_v1_pr_proto_depIdxs = nil
+}
@@ -0,0 +1,80 @@
+package app_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/chimort/avito_test_task/iternal/app"
+	"github.com/chimort/avito_test_task/migrations"
+)
+
+func findCheck(resp api.ReadinessResponse, name string) api.ReadinessCheck {
+	for _, c := range resp.Checks {
+		if c.Name == name {
+			return c
+		}
+	}
+	return api.ReadinessCheck{}
+}
+
+func TestReadiness_Ready(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	expected, err := migrations.ExpectedVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mock.ExpectPing()
+	mock.ExpectQuery("select version, dirty from schema_migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(expected, false))
+
+	resp := app.NewReadiness(db).CheckReadiness(context.Background())
+	if resp.Status != api.Ready {
+		t.Errorf("expected ready, got %+v", resp)
+	}
+}
+
+func TestReadiness_NotReady(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectPing()
+	mock.ExpectQuery("select version, dirty from schema_migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(1, false))
+
+	r := app.NewReadiness(db)
+	r.SetDraining(true)
+	resp := r.CheckReadiness(context.Background())
+	if resp.Status != api.NotReady {
+		t.Fatalf("expected not_ready, got %+v", resp)
+	}
+	if c := findCheck(resp, "database"); c.Status != api.ReadinessCheckStatusOk {
+		t.Errorf("expected database ok, got %+v", c)
+	}
+	if c := findCheck(resp, "migrations"); c.Status != api.ReadinessCheckStatusFail {
+		t.Errorf("expected migrations fail, got %+v", c)
+	}
+	if c := findCheck(resp, "draining"); c.Status != api.ReadinessCheckStatusFail {
+		t.Errorf("expected draining fail, got %+v", c)
+	}
+}
+
+func TestExpectedVersion(t *testing.T) {
+	v, err := migrations.ExpectedVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v < 4 {
+		t.Errorf("expected at least version 4, got %d", v)
+	}
+}
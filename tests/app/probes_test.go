@@ -0,0 +1,84 @@
+package app_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/chimort/avito_test_task/iternal/app"
+	"github.com/chimort/avito_test_task/iternal/pkg/logger"
+)
+
+func getReadiness(t *testing.T, url string) (int, api.ReadinessResponse) {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	var body api.ReadinessResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, body
+}
+
+func TestProbeServer(t *testing.T) {
+	probes, err := app.ServeProbes("127.0.0.1:0", logger.NewLogger("app", logger.LevelError))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = probes.Close() }()
+	base := "http://" + probes.Addr().String()
+
+	resp, err := http.Get(base + "/healthz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected healthz 200 during startup, got %d", resp.StatusCode)
+	}
+
+	code, body := getReadiness(t, base+"/readyz")
+	if code != http.StatusServiceUnavailable || body.Status != api.NotReady {
+		t.Fatalf("expected not ready, got %d %+v", code, body)
+	}
+	if c := findCheck(body, "startup"); c.Message == nil || *c.Message != app.StageDBConnecting {
+		t.Errorf("expected %q, got %+v", app.StageDBConnecting, c)
+	}
+
+	probes.SetStage(app.StageMigrationsRunning)
+	if _, body := getReadiness(t, base+"/readyz"); *findCheck(body, "startup").Message != app.StageMigrationsRunning {
+		t.Errorf("expected %q, got %+v", app.StageMigrationsRunning, body)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	lis, err := probes.Handover(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lis.Addr().String() != probes.Addr().String() {
+		t.Errorf("expected the probe listener, got %s", lis.Addr())
+	}
+
+	// The same address is now served by the new server.
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})}
+	go func() { _ = srv.Serve(lis) }()
+	defer func() { _ = srv.Close() }()
+
+	resp, err = http.Get(base + "/readyz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusTeapot {
+		t.Errorf("expected the new server to answer, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,164 @@
+package app_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/chimort/avito_test_task/iternal/app"
+	"github.com/chimort/avito_test_task/iternal/config"
+	"github.com/chimort/avito_test_task/iternal/pkg/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+)
+
+func TestServer_Shutdown(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock.ExpectClose()
+
+	cfg := config.Default()
+	cfg.HTTP.DrainDelay = 300 * time.Millisecond
+	server, err := app.NewServer(logger.NewLogger("app", logger.LevelInfo), db, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	started := make(chan error, 1)
+	go func() { started <- server.Start("127.0.0.1:0") }()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for server.Addr() == nil {
+		if time.Now().After(deadline) {
+			t.Fatal("server did not start")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	resp, err := http.Get("http://" + server.Addr().String() + "/healthz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected 200, got %d", resp.StatusCode)
+	}
+
+	workerStopped := make(chan struct{})
+	server.Go(func(ctx context.Context) {
+		<-ctx.Done()
+		close(workerStopped)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	shutdown := make(chan error, 1)
+	begin := time.Now()
+	go func() { shutdown <- server.Shutdown(ctx) }()
+
+	// Requests are still served during the drain delay.
+	time.Sleep(50 * time.Millisecond)
+	resp, err = http.Get("http://" + server.Addr().String() + "/healthz")
+	if err != nil {
+		t.Fatalf("expected the server to serve while draining: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if err := <-shutdown; err != nil {
+		t.Fatalf("expected clean shutdown, got %v", err)
+	}
+	if elapsed := time.Since(begin); elapsed < cfg.HTTP.DrainDelay {
+		t.Errorf("expected shutdown to wait for the drain delay, took %s", elapsed)
+	}
+
+	if err := <-started; err != nil {
+		t.Errorf("expected Start to return nil, got %v", err)
+	}
+	select {
+	case <-workerStopped:
+	default:
+		t.Errorf("expected worker to be stopped")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expected db to be closed: %v", err)
+	}
+}
+
+func TestServer_GRPC(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock.ExpectClose()
+
+	cfg := config.Default()
+	cfg.HTTP.DrainDelay = 0
+	server, err := app.NewServer(logger.NewLogger("app", logger.LevelError), db, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	started := make(chan error, 1)
+	go func() { started <- server.StartGRPC("127.0.0.1:0") }()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for server.GRPCAddr() == nil {
+		if time.Now().After(deadline) {
+			t.Fatal("gRPC server did not start")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	conn, err := grpc.NewClient(server.GRPCAddr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	// The mock has no schema_migrations, so the server is not ready.
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{Service: "pr.v1.PRService"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("expected NOT_SERVING, got %s", resp.Status)
+	}
+
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	reply, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	services := map[string]bool{}
+	for _, s := range reply.GetListServicesResponse().GetService() {
+		services[s.Name] = true
+	}
+	if !services["pr.v1.PRService"] || !services["grpc.health.v1.Health"] {
+		t.Errorf("unexpected services: %v", services)
+	}
+	_ = stream.CloseSend()
+
+	if err := server.Shutdown(ctx); err != nil {
+		t.Fatalf("expected clean shutdown, got %v", err)
+	}
+	if err := <-started; err != nil {
+		t.Errorf("expected StartGRPC to return nil, got %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/chimort/avito_test_task/iternal/auth"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func writeJWKS(t *testing.T, key *rsa.PrivateKey) string {
+	t.Helper()
+	b64 := base64.RawURLEncoding.EncodeToString
+	jwks := map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "test",
+			"alg": "RS256",
+			"use": "sig",
+			"n":   b64(key.N.Bytes()),
+			"e":   b64(big.NewInt(int64(key.E)).Bytes()),
+		}},
+	}
+	raw, err := json.Marshal(jwks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, raw, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func sign(t *testing.T, key *rsa.PrivateKey, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = "test"
+	s, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestJWTVerifier(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	kf, err := auth.LoadJWKS(context.Background(), writeJWKS(t, key), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := auth.NewJWTVerifier(kf, auth.JWTConfig{
+		Issuer:    "https://sso.example.com",
+		Audience:  "pr-service",
+		UserClaim: "preferred_username",
+		RoleClaim: "realm_access.roles",
+	})
+
+	claims := func(extra jwt.MapClaims) jwt.MapClaims {
+		c := jwt.MapClaims{
+			"iss":                "https://sso.example.com",
+			"aud":                "pr-service",
+			"sub":                "b7f1",
+			"exp":                time.Now().Add(time.Hour).Unix(),
+			"preferred_username": "u1",
+		}
+		for k, val := range extra {
+			c[k] = val
+		}
+		return c
+	}
+
+	tests := []struct {
+		name   string
+		token  string
+		role   auth.Role
+		userID string
+	}{
+		{"admin from group list", sign(t, key, claims(jwt.MapClaims{"realm_access": map[string]any{"roles": []string{"dev", "admin"}}})), auth.RoleAdmin, "u1"},
+		{"user without role claim", sign(t, key, claims(nil)), auth.RoleUser, "u1"},
+		{"wrong issuer", sign(t, key, claims(jwt.MapClaims{"iss": "https://evil.example.com"})), "", ""},
+		{"wrong audience", sign(t, key, claims(jwt.MapClaims{"aud": "other"})), "", ""},
+		{"expired", sign(t, key, claims(jwt.MapClaims{"exp": time.Now().Add(-time.Hour).Unix()})), "", ""},
+		{"no expiry", sign(t, key, claims(jwt.MapClaims{"exp": nil})), "", ""},
+		{"unknown key", sign(t, otherKey, claims(nil)), "", ""},
+		{"user without user claim", sign(t, key, claims(jwt.MapClaims{"preferred_username": nil})), "", ""},
+		{"not a jwt", "prs_abc", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := v.Authenticate(context.Background(), tt.token)
+			if tt.role == "" {
+				if !errors.Is(err, auth.ErrInvalidToken) {
+					t.Fatalf("expected ErrInvalidToken, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if p.Role != tt.role || p.UserID != tt.userID {
+				t.Errorf("unexpected principal: %+v", p)
+			}
+		})
+	}
+}
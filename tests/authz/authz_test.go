@@ -0,0 +1,109 @@
+package authz_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/chimort/avito_test_task/iternal/auth"
+	"github.com/chimort/avito_test_task/iternal/authz"
+)
+
+// fakeLookup knows users u1 and u2 in backend, led by u1, and u9 in
+// frontend.
+type fakeLookup struct{}
+
+var teams = map[string]string{"u1": "backend", "u2": "backend", "u9": "frontend"}
+
+func (fakeLookup) UserInTeam(ctx context.Context, userID, teamName string) (bool, error) {
+	return teams[userID] == teamName, nil
+}
+
+func (fakeLookup) PullRequestInTeam(ctx context.Context, prID, teamName string) (bool, error) {
+	return teamName == "backend", nil
+}
+
+func (fakeLookup) IsTeamLead(ctx context.Context, userID, teamName string) (bool, error) {
+	return userID == "u1" && teamName == "backend", nil
+}
+
+func (fakeLookup) UsersOutsideTeam(ctx context.Context, teamName string, userIDs []string) ([]string, error) {
+	var outside []string
+	for _, id := range userIDs {
+		if team, ok := teams[id]; ok && team != teamName {
+			outside = append(outside, id)
+		}
+	}
+	return outside, nil
+}
+
+func (fakeLookup) LeadsUser(ctx context.Context, leadID, userID string) (bool, error) {
+	return leadID == "u1" && teams[userID] == "backend", nil
+}
+
+func (fakeLookup) LeadsPullRequest(ctx context.Context, leadID, prID string) (bool, error) {
+	return leadID == "u1", nil
+}
+
+func as(p *auth.Principal) context.Context {
+	if p == nil {
+		return context.Background()
+	}
+	return auth.WithPrincipal(context.Background(), p)
+}
+
+func TestAddMembers(t *testing.T) {
+	lead := api.Lead
+	newUser := api.TeamMember{UserId: "u5", Username: "Eve"}
+	tests := []struct {
+		name    string
+		caller  *auth.Principal
+		members []api.TeamMember
+		reason  string
+	}{
+		{"no auth", nil, []api.TeamMember{{UserId: "u9", Role: &lead}}, ""},
+		{"admin pulls in another team's user", &auth.Principal{Role: auth.RoleAdmin}, []api.TeamMember{{UserId: "u9"}}, ""},
+		{"lead adds a new user", &auth.Principal{Role: auth.RoleUser, UserID: "u1"}, []api.TeamMember{newUser}, ""},
+		{"member is not a lead", &auth.Principal{Role: auth.RoleUser, UserID: "u2"}, []api.TeamMember{newUser}, authz.ErrNotLead.Reason},
+		{"lead sets a role", &auth.Principal{Role: auth.RoleUser, UserID: "u1"}, []api.TeamMember{{UserId: "u5", Role: &lead}}, "only admins can set member roles"},
+		{"lead pulls in another team's user", &auth.Principal{Role: auth.RoleUser, UserID: "u1"}, []api.TeamMember{{UserId: "u9"}}, "only admins can add users from other teams"},
+		{"scoped admin pulls in another team's user", &auth.Principal{Role: auth.RoleAdmin, Team: "backend"}, []api.TeamMember{{UserId: "u9"}}, "only admins can add users from other teams"},
+		{"scoped admin on another team", &auth.Principal{Role: auth.RoleAdmin, Team: "frontend"}, []api.TeamMember{newUser}, authz.ErrOutOfScope.Reason},
+	}
+	c := authz.NewChecker(fakeLookup{})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := c.AddMembers(as(tt.caller), "backend", tt.members)
+			if tt.reason == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+			var denied *authz.Denied
+			if !errors.As(err, &denied) || denied.Reason != tt.reason {
+				t.Errorf("expected denial %q, got %v", tt.reason, err)
+			}
+		})
+	}
+}
+
+func TestScope(t *testing.T) {
+	c := authz.NewChecker(fakeLookup{})
+	scoped := as(&auth.Principal{Role: auth.RoleAdmin, Team: "backend"})
+
+	if !authz.TeamInScope(scoped, "backend") || authz.TeamInScope(scoped, "frontend") {
+		t.Error("expected only backend in scope")
+	}
+	if ok, _ := c.UserInScope(scoped, "u9"); ok {
+		t.Error("expected u9 out of scope")
+	}
+	if ok, _ := c.UserInScope(as(&auth.Principal{Role: auth.RoleAdmin}), "u9"); !ok {
+		t.Error("expected an unscoped admin to reach u9")
+	}
+	if !authz.ActsAs(as(&auth.Principal{Role: auth.RoleUser, UserID: "u2"}), "u2") ||
+		authz.ActsAs(as(&auth.Principal{Role: auth.RoleUser, UserID: "u2"}), "u1") {
+		t.Error("expected users to act only for themselves")
+	}
+}
@@ -0,0 +1,148 @@
+package backup_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chimort/avito_test_task/iternal/backup"
+)
+
+func writeArchive(t *testing.T, schemaVersion uint, tables map[string][][]any, order []string, columns map[string][]string) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	w := backup.NewWriter(&buf)
+	if err := w.Begin(schemaVersion); err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	for _, table := range order {
+		if err := w.Table(table, columns[table]); err != nil {
+			t.Fatalf("table: %v", err)
+		}
+		for _, row := range tables[table] {
+			if err := w.Row(row); err != nil {
+				t.Fatalf("row: %v", err)
+			}
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	return &buf
+}
+
+func TestRoundTrip(t *testing.T) {
+	created := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	buf := writeArchive(t, 10,
+		map[string][][]any{
+			"team":          {{"backend"}},
+			"users":         {{"u1", "Alice", true, nil}},
+			"user_teams":    {{"u1", "backend", "lead"}},
+			"pull_requests": {{"pr-1", "Fix", "u1", "OPEN", created, nil}},
+			"api_keys":      {{int64(7), "ci", "hash", "admin", nil, nil, nil, created, nil, nil}},
+		},
+		[]string{"team", "users", "user_teams", "pull_requests", "api_keys"},
+		map[string][]string{
+			"team":          {"name"},
+			"users":         {"id", "name", "is_active", "deleted_at"},
+			"user_teams":    {"user_id", "team_name", "role"},
+			"pull_requests": {"id", "title", "author_id", "status", "created_at", "merged_at"},
+			"api_keys":      {"id", "name", "key_hash", "role", "user_id", "team_name", "expires_at", "created_at", "last_used_at", "revoked_at"},
+		})
+
+	r, err := backup.NewReader(buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	m := r.Manifest()
+	if m.Format != backup.Format || m.Version != backup.Version || m.SchemaVersion != 10 || m.CreatedAt.IsZero() {
+		t.Errorf("unexpected manifest: %+v", m)
+	}
+
+	var tables []string
+	for {
+		table, row, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		tables = append(tables, table)
+		switch table {
+		case "pull_requests":
+			if row["created_at"] != "2025-01-02T03:04:05Z" || row["merged_at"] != nil {
+				t.Errorf("unexpected pull request row: %v", row)
+			}
+		case "api_keys":
+			if row["id"] != json.Number("7") {
+				t.Errorf("expected id to stay a number, got %#v", row["id"])
+			}
+		}
+	}
+	if got := strings.Join(tables, ","); got != "team,users,user_teams,pull_requests,api_keys" {
+		t.Errorf("unexpected tables: %s", got)
+	}
+}
+
+func TestReaderReferences(t *testing.T) {
+	buf := writeArchive(t, 10,
+		map[string][][]any{
+			"team":       {{"backend"}},
+			"users":      {{"u1", "Alice", true, nil}},
+			"user_teams": {{"u1", "backend"}, {"u2", "backend"}},
+		},
+		[]string{"team", "users", "user_teams"},
+		map[string][]string{
+			"team":       {"name"},
+			"users":      {"id", "name", "is_active", "deleted_at"},
+			"user_teams": {"user_id", "team_name"},
+		})
+
+	r, err := backup.NewReader(buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if _, _, err := r.Next(); err != nil {
+			t.Fatalf("row %d: unexpected error: %v", i+1, err)
+		}
+	}
+	_, _, err = r.Next()
+	if err == nil || !strings.Contains(err.Error(), `line 5: user_teams.user_id "u2" references a missing users row`) {
+		t.Errorf("expected reference error, got %v", err)
+	}
+}
+
+func TestReaderRejectsOtherFiles(t *testing.T) {
+	if _, err := backup.NewReader(strings.NewReader("team,user_id\n")); err == nil {
+		t.Error("expected error for plain text")
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, _ = gz.Write([]byte(`{"format":"something-else","version":1}` + "\n"))
+	_ = gz.Close()
+	if _, err := backup.NewReader(&buf); err == nil {
+		t.Error("expected error for foreign format")
+	}
+}
+
+func TestManifestCheck(t *testing.T) {
+	m := backup.Manifest{Format: backup.Format, Version: backup.Version, SchemaVersion: 9}
+	if err := m.Check(10); err != nil {
+		t.Errorf("older schema should be accepted: %v", err)
+	}
+	if err := m.Check(8); err == nil {
+		t.Error("newer schema should be rejected")
+	}
+	m.Version = backup.Version + 1
+	if err := m.Check(10); err == nil {
+		t.Error("unknown archive version should be rejected")
+	}
+}
@@ -0,0 +1,244 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/chimort/avito_test_task/client"
+)
+
+var fastRetry = client.RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+
+func newClient(t *testing.T, h http.HandlerFunc, cfg client.Config) *client.ClientWithResponses {
+	t.Helper()
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	cfg.Server = srv.URL
+	c, err := client.New(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return c
+}
+
+func writeError(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(`{"error":{"code":"` + code + `","message":"boom"}}`))
+}
+
+func TestTypedErrors(t *testing.T) {
+	tests := []struct {
+		status int
+		code   string
+		check  func(error) bool
+	}{
+		{http.StatusNotFound, "NOT_FOUND", func(err error) bool { var e *client.NotFoundError; return errors.As(err, &e) }},
+		{http.StatusBadRequest, "TEAM_EXISTS", func(err error) bool { var e *client.TeamExistsError; return errors.As(err, &e) }},
+		{http.StatusConflict, "PR_MERGED", func(err error) bool { var e *client.PRMergedError; return errors.As(err, &e) }},
+		{http.StatusForbidden, "FORBIDDEN", func(err error) bool { var e *client.ForbiddenError; return errors.As(err, &e) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+				writeError(w, tt.status, tt.code)
+			}, client.Config{Retry: fastRetry})
+
+			_, err := c.GetTeamGetWithResponse(context.Background(), &client.GetTeamGetParams{TeamName: "backend"})
+			if !tt.check(err) {
+				t.Fatalf("unexpected error type %T: %v", err, err)
+			}
+			var apiErr *client.APIError
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.status || string(apiErr.Code) != tt.code || apiErr.Message != "boom" {
+				t.Errorf("unexpected APIError: %+v", apiErr)
+			}
+		})
+	}
+}
+
+func TestUnknownErrorBody(t *testing.T) {
+	c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad gateway", http.StatusBadGateway)
+	}, client.Config{Retry: client.RetryPolicy{MaxAttempts: 1}})
+
+	_, err := c.GetTeamGetWithResponse(context.Background(), &client.GetTeamGetParams{TeamName: "backend"})
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadGateway || apiErr.Code != "" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestRetries(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		status   int
+		idemKey  bool
+		attempts int32
+	}{
+		{"GET on 503", http.MethodGet, http.StatusServiceUnavailable, false, 3},
+		{"POST on 429", http.MethodPost, http.StatusTooManyRequests, false, 3},
+		{"POST on 500", http.MethodPost, http.StatusInternalServerError, false, 1},
+		{"POST on 500 with idempotency key", http.MethodPost, http.StatusInternalServerError, true, 3},
+		{"GET on 404", http.MethodGet, http.StatusNotFound, false, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			var bodies []string
+			c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+				attempts.Add(1)
+				body, _ := io.ReadAll(r.Body)
+				bodies = append(bodies, string(body))
+				writeError(w, tt.status, "NOT_FOUND")
+			}, client.Config{Retry: fastRetry})
+
+			ctx := context.Background()
+			var err error
+			if tt.method == http.MethodGet {
+				_, err = c.GetTeamGetWithResponse(ctx, &client.GetTeamGetParams{TeamName: "backend"})
+			} else {
+				var editors []client.RequestEditorFn
+				if tt.idemKey {
+					editors = append(editors, func(ctx context.Context, req *http.Request) error {
+						req.Header.Set(client.HeaderIdempotencyKey, "k1")
+						return nil
+					})
+				}
+				_, err = c.PostPullRequestMergeWithResponse(ctx, client.PostPullRequestMergeJSONRequestBody{PullRequestId: "pr-1"}, editors...)
+			}
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if got := attempts.Load(); got != tt.attempts {
+				t.Errorf("expected %d attempts, got %d", tt.attempts, got)
+			}
+			for _, b := range bodies {
+				if b != bodies[0] {
+					t.Errorf("body changed between attempts: %q vs %q", bodies[0], b)
+				}
+			}
+		})
+	}
+}
+
+func TestRetrySucceeds(t *testing.T) {
+	var attempts atomic.Int32
+	c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			writeError(w, http.StatusTooManyRequests, "RATE_LIMITED")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"team_name":"backend","members":[]}`))
+	}, client.Config{Retry: fastRetry})
+
+	resp, err := c.GetTeamGetWithResponse(context.Background(), &client.GetTeamGetParams{TeamName: "backend"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.JSON200 == nil || resp.JSON200.TeamName != "backend" {
+		t.Errorf("unexpected response: %s", resp.Body)
+	}
+}
+
+func TestRetryStopsOnCancel(t *testing.T) {
+	c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		writeError(w, http.StatusTooManyRequests, "RATE_LIMITED")
+	}, client.Config{Retry: client.RetryPolicy{MaxAttempts: 5, MaxBackoff: time.Minute}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	_, err := c.GetTeamGetWithResponse(ctx, &client.GetTeamGetParams{TeamName: "backend"})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context canceled, got %v", err)
+	}
+}
+
+func TestRetryAfterTooLong(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  client.RetryPolicy
+		timeout time.Duration
+	}{
+		{"exceeds max backoff", client.RetryPolicy{MaxAttempts: 5, MaxBackoff: 30 * time.Second}, time.Minute},
+		{"exceeds deadline", client.RetryPolicy{MaxAttempts: 5, MaxBackoff: 2 * time.Minute}, 5 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+				attempts.Add(1)
+				w.Header().Set("Retry-After", "60")
+				writeError(w, http.StatusTooManyRequests, "RATE_LIMITED")
+			}, client.Config{Retry: tt.policy})
+
+			ctx, cancel := context.WithTimeout(context.Background(), tt.timeout)
+			defer cancel()
+			start := time.Now()
+			_, err := c.GetTeamGetWithResponse(ctx, &client.GetTeamGetParams{TeamName: "backend"})
+			var rateLimited *client.RateLimitedError
+			if !errors.As(err, &rateLimited) || rateLimited.RetryAfter != time.Minute {
+				t.Fatalf("expected RateLimitedError with Retry-After, got %T: %v", err, err)
+			}
+			if n := attempts.Load(); n != 1 {
+				t.Errorf("expected 1 attempt, got %d", n)
+			}
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("expected an immediate error, waited %s", elapsed)
+			}
+		})
+	}
+}
+
+type countingTokens struct{ n atomic.Int32 }
+
+func (s *countingTokens) Token(ctx context.Context) (string, error) {
+	if s.n.Add(1) == 1 {
+		return "stale", nil
+	}
+	return "fresh", nil
+}
+
+func TestAuth(t *testing.T) {
+	var seen []string
+	c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.Header.Get("Authorization"))
+		if r.Header.Get("Authorization") != "Bearer fresh" {
+			writeError(w, http.StatusServiceUnavailable, "UNAUTHORIZED")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"team_name":"backend","members":[]}`))
+	}, client.Config{Retry: fastRetry, Auth: client.BearerTokenSource(&countingTokens{})})
+
+	if _, err := c.GetTeamGetWithResponse(context.Background(), &client.GetTeamGetParams{TeamName: "backend"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(seen) != 2 || seen[0] != "Bearer stale" || seen[1] != "Bearer fresh" {
+		t.Errorf("expected the token to be asked for on every attempt, got %v", seen)
+	}
+
+	c = newClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Api-Key") != "k" {
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"team_name":"backend","members":[]}`))
+	}, client.Config{Auth: client.AuthFunc(func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("X-Api-Key", "k")
+		return nil
+	})})
+	if _, err := c.GetTeamGetWithResponse(context.Background(), &client.GetTeamGetParams{TeamName: "backend"}); err != nil {
+		t.Errorf("custom auth: unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,191 @@
+package config_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chimort/avito_test_task/iternal/config"
+)
+
+func requiredEnv(t *testing.T) {
+	t.Setenv("DB_USER", "pr_user")
+	t.Setenv("DB_NAME", "pr_db")
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	requiredEnv(t)
+	cfg, err := config.Load(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.HTTP.Addr != ":8080" || cfg.Assignment.ReviewersPerPR != 2 || cfg.DB.Port != 5432 {
+		t.Errorf("unexpected defaults: %+v", cfg)
+	}
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	requiredEnv(t)
+	path := filepath.Join(t.TempDir(), "config.yml")
+	err := os.WriteFile(path, []byte(`
+http:
+  addr: ":9000"
+  shutdown_timeout: 5s
+db:
+  host: file-host
+  max_open_conns: 50
+log:
+  level: debug
+`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("DB_HOST", "env-host")
+	t.Setenv("LOG_LEVEL", "warn")
+
+	cfg, err := config.Load([]string{"--config", path, "--log.level", "error"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.HTTP.Addr != ":9000" || cfg.HTTP.ShutdownTimeout != 5*time.Second {
+		t.Errorf("expected file values, got %+v", cfg.HTTP)
+	}
+	if cfg.DB.MaxOpenConns != 50 {
+		t.Errorf("expected max_open_conns from file, got %d", cfg.DB.MaxOpenConns)
+	}
+	if cfg.DB.Host != "env-host" {
+		t.Errorf("expected env to override file, got %s", cfg.DB.Host)
+	}
+	if cfg.Log.Level != "error" {
+		t.Errorf("expected flag to override env, got %s", cfg.Log.Level)
+	}
+}
+
+func TestLoad_UnknownFileKey(t *testing.T) {
+	requiredEnv(t)
+	path := filepath.Join(t.TempDir(), "config.yml")
+	if err := os.WriteFile(path, []byte("http:\n  adr: \":9000\"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := config.Load([]string{"--config", path}); err == nil {
+		t.Errorf("expected error for unknown key")
+	}
+}
+
+func TestLoad_Validation(t *testing.T) {
+	requiredEnv(t)
+	_, err := config.Load([]string{"--log.level", "verbose", "--db.max_idle_conns", "100", "--db.max_open_conns", "10"})
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+	for _, want := range []string{"log.level", "db.max_idle_conns"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in error, got %v", want, err)
+		}
+	}
+
+	if _, err := config.Load([]string{"--http.read_timeout", "soon"}); err == nil {
+		t.Errorf("expected parse error for bad duration")
+	}
+}
+
+func TestDump_RedactsSecrets(t *testing.T) {
+	requiredEnv(t)
+	t.Setenv("DB_PASSWORD", "s3cret")
+	cfg, err := config.Load([]string{"--print-config"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.PrintConfig {
+		t.Errorf("expected PrintConfig to be set")
+	}
+
+	var buf bytes.Buffer
+	if err := cfg.Dump(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(buf.String(), "s3cret") || !strings.Contains(buf.String(), "<redacted>") {
+		t.Errorf("expected password to be redacted:\n%s", buf.String())
+	}
+	if cfg.DB.Password != "s3cret" {
+		t.Errorf("Dump must not modify the config")
+	}
+	if !strings.Contains(buf.String(), "shutdown_timeout: 15s") {
+		t.Errorf("expected durations as strings:\n%s", buf.String())
+	}
+}
+
+func TestLoad_AuthTokens(t *testing.T) {
+	requiredEnv(t)
+	path := filepath.Join(t.TempDir(), "config.yml")
+	err := os.WriteFile(path, []byte(`
+auth:
+  enabled: true
+  tokens:
+    - name: ops
+      token: admin-token-0123456789
+      role: admin
+    - name: bot
+      token: user-token-0123456789
+      role: user
+`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = config.Load([]string{"--config", path})
+	if err == nil || !strings.Contains(err.Error(), "auth.tokens[1].user_id") {
+		t.Fatalf("expected user_id error, got %v", err)
+	}
+
+	_, err = config.Load([]string{"--auth.enabled", "true"})
+	if err == nil || !strings.Contains(err.Error(), "auth.tokens must not be empty") {
+		t.Errorf("expected empty tokens error, got %v", err)
+	}
+}
+
+func TestDump_RedactsTokens(t *testing.T) {
+	requiredEnv(t)
+	cfg, err := config.Load(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Auth.Tokens = []config.Token{{Name: "ops", Token: "admin-token-0123456789", Role: "admin"}}
+
+	var buf bytes.Buffer
+	if err := cfg.Dump(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(buf.String(), "admin-token-0123456789") || !strings.Contains(buf.String(), "name: ops") {
+		t.Errorf("expected token to be redacted:\n%s", buf.String())
+	}
+	if cfg.Auth.Tokens[0].Token != "admin-token-0123456789" {
+		t.Errorf("Dump must not modify the config")
+	}
+}
+
+func TestLoad_AuthJWT(t *testing.T) {
+	requiredEnv(t)
+	t.Setenv("AUTH_ENABLED", "true")
+	t.Setenv("AUTH_JWT_ENABLED", "true")
+
+	_, err := config.Load(nil)
+	if err == nil || !strings.Contains(err.Error(), "auth.jwt.jwks_file") || !strings.Contains(err.Error(), "auth.jwt.issuer") {
+		t.Fatalf("expected jwt validation errors, got %v", err)
+	}
+
+	cfg, err := config.Load([]string{
+		"--auth.jwt.jwks_file", "jwks.json",
+		"--auth.jwt.issuer", "https://sso.example.com",
+		"--auth.jwt.audience", "pr-service",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Auth.JWT.UserClaim != "sub" || cfg.Auth.JWT.Leeway != 30*time.Second {
+		t.Errorf("expected jwt defaults, got %+v", cfg.Auth.JWT)
+	}
+}
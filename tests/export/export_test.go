@@ -0,0 +1,126 @@
+package export_test
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/chimort/avito_test_task/iternal/export"
+)
+
+func writeTables(t *testing.T, w export.Writer) {
+	t.Helper()
+	created := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	steps := []func() error{
+		func() error { return w.Table("team", []string{"name"}) },
+		func() error { return w.Row([]any{"backend"}) },
+		func() error { return w.Table("pull_requests", []string{"id", "title", "created_at", "merged_at"}) },
+		func() error { return w.Row([]any{"pr-1", []byte("Fix, \"quotes\""), created, nil}) },
+		func() error { return w.Table("pr_reviewers", []string{"pr_id", "reviewer_id"}) },
+		w.Close,
+	}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for in, want := range map[string]export.Format{"": export.NDJSON, "ndjson": export.NDJSON, "csv": export.CSV} {
+		got, err := export.ParseFormat(in)
+		if err != nil || got != want {
+			t.Errorf("ParseFormat(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := export.ParseFormat("xml"); err == nil {
+		t.Error("expected error for xml")
+	}
+}
+
+func TestNDJSONWriter(t *testing.T) {
+	var buf bytes.Buffer
+	writeTables(t, export.NewWriter(&buf, export.NDJSON))
+
+	var lines []export.Line
+	sc := bufio.NewScanner(&buf)
+	for sc.Scan() {
+		var l export.Line
+		if err := json.Unmarshal(sc.Bytes(), &l); err != nil {
+			t.Fatalf("invalid line %q: %v", sc.Text(), err)
+		}
+		lines = append(lines, l)
+	}
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %d", len(lines))
+	}
+	if lines[0].Table != "team" || lines[0].Row["name"] != "backend" {
+		t.Errorf("unexpected first line: %+v", lines[0])
+	}
+	pr := lines[1].Row
+	if pr["title"] != `Fix, "quotes"` || pr["created_at"] != "2025-01-02T03:04:05Z" || pr["merged_at"] != nil {
+		t.Errorf("unexpected pull request row: %+v", pr)
+	}
+	if end := lines[2]; end.Table != export.EndTable || end.Row["rows"] != float64(2) {
+		t.Errorf("expected end trailer with 2 rows, got %+v", end)
+	}
+}
+
+func TestNDJSONWriter_Abort(t *testing.T) {
+	var buf bytes.Buffer
+	w := export.NewWriter(&buf, export.NDJSON)
+	if err := w.Table("team", []string{"name"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Row([]any{"backend"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Abort(); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	var last export.Line
+	if err := json.Unmarshal(lines[len(lines)-1], &last); err != nil {
+		t.Fatal(err)
+	}
+	if last.Table != export.ErrorTable {
+		t.Errorf("expected error line last, got %+v", last)
+	}
+	if bytes.Contains(buf.Bytes(), []byte(export.EndTable)) {
+		t.Error("aborted export must not have the end trailer")
+	}
+}
+
+func TestCSVWriter(t *testing.T) {
+	var buf bytes.Buffer
+	writeTables(t, export.NewWriter(&buf, export.CSV))
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("invalid zip: %v", err)
+	}
+	want := map[string]string{
+		"team.csv":          "name\nbackend\n",
+		"pull_requests.csv": "id,title,created_at,merged_at\npr-1,\"Fix, \"\"quotes\"\"\",2025-01-02T03:04:05Z,\n",
+		"pr_reviewers.csv":  "pr_id,reviewer_id\n",
+	}
+	if len(zr.File) != len(want) {
+		t.Fatalf("expected %d files, got %d", len(want), len(zr.File))
+	}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", f.Name, err)
+		}
+		got, _ := io.ReadAll(rc)
+		_ = rc.Close()
+		if string(got) != want[f.Name] {
+			t.Errorf("%s: expected %q, got %q", f.Name, want[f.Name], got)
+		}
+	}
+}
@@ -0,0 +1,423 @@
+package grpcapi_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/chimort/avito_test_task/iternal/auth"
+	"github.com/chimort/avito_test_task/iternal/grpcapi"
+	"github.com/chimort/avito_test_task/iternal/middleware"
+	"github.com/chimort/avito_test_task/iternal/pkg/logger"
+	"github.com/chimort/avito_test_task/iternal/repository"
+	"github.com/chimort/avito_test_task/iternal/service"
+	prv1 "github.com/chimort/avito_test_task/proto/pr/v1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// fakeService answers the calls the tests make. Other methods fall through
+// to the nil embedded interface and panic.
+type fakeService struct {
+	service.UserServiceInterface
+}
+
+func (f *fakeService) GetTeam(ctx context.Context, teamName string) (*api.Team, error) {
+	switch teamName {
+	case "notfound":
+		return nil, repository.ErrTeamNotFound
+	case "broken":
+		return nil, errors.New("connection reset")
+	}
+	lead := api.Lead
+	return &api.Team{TeamName: teamName, Members: []api.TeamMember{
+		{UserId: "u1", Username: "Alice", IsActive: true, Role: &lead},
+		{UserId: "u2", Username: "Bob"},
+	}}, nil
+}
+
+func (f *fakeService) SetIsActive(ctx context.Context, userID string, isActive bool) (*api.User, error) {
+	if userID == "notfound" {
+		return nil, repository.ErrUserNotFound
+	}
+	return &api.User{UserId: userID, IsActive: isActive}, nil
+}
+
+func (f *fakeService) PullRequestCreate(ctx context.Context, prID, prName, authorID string) (*api.PullRequest, error) {
+	if prID == "pr-existing" {
+		return nil, repository.ErrPRExists
+	}
+	return &api.PullRequest{PullRequestId: prID, PullRequestName: prName, AuthorId: authorID, Status: api.PullRequestStatusOPEN}, nil
+}
+
+func (f *fakeService) PullRequestReassign(ctx context.Context, prID, oldUserID string) (*api.PullRequest, string, error) {
+	switch prID {
+	case "pr-merged":
+		return nil, "", repository.ErrPRMerged
+	case "pr-limited":
+		return nil, "", &repository.ReassignLimitError{RetryAfter: 90 * time.Second}
+	}
+	return &api.PullRequest{PullRequestId: prID, Status: api.PullRequestStatusOPEN, AssignedReviewers: []string{"u5"}}, "u5", nil
+}
+
+func (f *fakeService) GetPRsByReviewer(ctx context.Context, reviewerID string, params api.GetUsersGetReviewParams) ([]api.ReviewAssignment, string, error) {
+	return []api.ReviewAssignment{{PullRequestId: "pr-1", Status: api.ReviewAssignmentStatusMERGED}}, "", nil
+}
+
+func (f *fakeService) ListUsers(ctx context.Context, params api.GetUsersListParams) ([]api.User, string, error) {
+	if params.Cursor != nil && *params.Cursor == "bad" {
+		return nil, "", service.ErrInvalidCursor
+	}
+	return []api.User{{UserId: "u1", TeamName: *params.TeamName}}, "", nil
+}
+
+func (f *fakeService) UserInTeam(ctx context.Context, userID, teamName string) (bool, error) {
+	return teamName == "payments" && userID == "u1", nil
+}
+
+func (f *fakeService) UsersOutsideTeam(ctx context.Context, teamName string, userIDs []string) ([]string, error) {
+	var outside []string
+	for _, id := range userIDs {
+		if in, _ := f.UserInTeam(ctx, id, teamName); !in {
+			outside = append(outside, id)
+		}
+	}
+	return outside, nil
+}
+
+func (f *fakeService) Export(ctx context.Context, from, to *time.Time, sink repository.ExportSink) error {
+	if err := sink.Table("team", []string{"name"}); err != nil {
+		return err
+	}
+	if err := sink.Row([]any{[]byte("backend")}); err != nil {
+		return err
+	}
+	if err := sink.Table("users", []string{"id", "is_active", "created_at", "deleted_at"}); err != nil {
+		return err
+	}
+	return sink.Row([]any{"u1", true, time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC), nil})
+}
+
+const (
+	adminToken = "admin-token-0123456789"
+	userToken  = "user-token-0123456789"
+	scopeToken = "scope-token-0123456789"
+)
+
+// dial serves a Server over an in-memory listener. With authn set, calls
+// go through the auth interceptors as in the app.
+func dial(t *testing.T, authn auth.Authenticator) *grpc.ClientConn {
+	t.Helper()
+	return dialLimited(t, authn, nil, nil)
+}
+
+// dialLimited is dial with the failed authentication limit before auth
+// when authFailures is set, and the rate limit after it when limiter is.
+func dialLimited(t *testing.T, authn auth.Authenticator, authFailures, limiter grpcapi.RateLimiter) *grpc.ClientConn {
+	t.Helper()
+	log := logger.NewLogger("test", logger.LevelError)
+	var (
+		unary  []grpc.UnaryServerInterceptor
+		stream []grpc.StreamServerInterceptor
+	)
+	if authFailures != nil {
+		unary = append(unary, grpcapi.UnaryFailedAuthLimit(authFailures, log))
+		stream = append(stream, grpcapi.StreamFailedAuthLimit(authFailures, log))
+	}
+	if authn != nil {
+		unary = append(unary, grpcapi.UnaryAuth(authn, log))
+		stream = append(stream, grpcapi.StreamAuth(authn, log))
+	}
+	if limiter != nil {
+		unary = append(unary, grpcapi.UnaryRateLimit(limiter, log))
+		stream = append(stream, grpcapi.StreamRateLimit(limiter, log))
+	}
+	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(unary...), grpc.ChainStreamInterceptor(stream...))
+	grpcapi.NewServer(&fakeService{}, nil, log).Register(srv)
+	hs := health.NewServer()
+	healthpb.RegisterHealthServer(srv, hs)
+
+	lis := bufconn.Listen(1 << 20)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+func withToken(token string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+func errorReason(t *testing.T, err error) string {
+	t.Helper()
+	for _, d := range status.Convert(err).Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok {
+			if info.Domain != grpcapi.ErrorDomain {
+				t.Errorf("unexpected ErrorInfo domain %q", info.Domain)
+			}
+			return info.Reason
+		}
+	}
+	return ""
+}
+
+func TestGetTeam(t *testing.T) {
+	c := prv1.NewPRServiceClient(dial(t, nil))
+
+	resp, err := c.GetTeam(context.Background(), &prv1.GetTeamRequest{TeamName: "backend"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	members := resp.GetTeam().GetMembers()
+	if len(members) != 2 || members[0].GetRole() != prv1.TeamMemberRole_TEAM_MEMBER_ROLE_LEAD || members[1].GetRole() != prv1.TeamMemberRole_TEAM_MEMBER_ROLE_MEMBER {
+		t.Errorf("unexpected members: %v", members)
+	}
+}
+
+func TestErrorStatuses(t *testing.T) {
+	c := prv1.NewPRServiceClient(dial(t, nil))
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		call   func() error
+		code   codes.Code
+		reason string
+	}{
+		{"team not found", func() error {
+			_, err := c.GetTeam(ctx, &prv1.GetTeamRequest{TeamName: "notfound"})
+			return err
+		}, codes.NotFound, "NOT_FOUND"},
+		{"missing team name", func() error {
+			_, err := c.GetTeam(ctx, &prv1.GetTeamRequest{})
+			return err
+		}, codes.InvalidArgument, "BAD_REQUEST"},
+		{"user not found", func() error {
+			_, err := c.SetUserIsActive(ctx, &prv1.SetUserIsActiveRequest{UserId: "notfound"})
+			return err
+		}, codes.NotFound, "NOT_FOUND"},
+		{"pr exists", func() error {
+			_, err := c.CreatePullRequest(ctx, &prv1.CreatePullRequestRequest{PullRequestId: "pr-existing", PullRequestName: "x", AuthorId: "u1"})
+			return err
+		}, codes.AlreadyExists, "PR_EXISTS"},
+		{"pr merged", func() error {
+			_, err := c.ReassignPullRequest(ctx, &prv1.ReassignPullRequestRequest{PullRequestId: "pr-merged", OldUserId: "u2"})
+			return err
+		}, codes.FailedPrecondition, "PR_MERGED"},
+		{"invalid cursor", func() error {
+			_, err := c.ListUsers(ctx, &prv1.ListUsersRequest{TeamName: new(string), Cursor: "bad"})
+			return err
+		}, codes.InvalidArgument, "BAD_REQUEST"},
+		{"invalid limit", func() error {
+			_, err := c.ListUsers(ctx, &prv1.ListUsersRequest{Limit: 500})
+			return err
+		}, codes.InvalidArgument, "BAD_REQUEST"},
+		{"unknown sort order", func() error {
+			_, err := c.GetUserReviews(ctx, &prv1.GetUserReviewsRequest{UserId: "u1", Order: prv1.SortOrder(7)})
+			return err
+		}, codes.InvalidArgument, "BAD_REQUEST"},
+		{"internal error", func() error {
+			_, err := c.GetTeam(ctx, &prv1.GetTeamRequest{TeamName: "broken"})
+			return err
+		}, codes.Internal, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			if got := status.Code(err); got != tt.code {
+				t.Fatalf("expected %s, got %s: %v", tt.code, got, err)
+			}
+			if got := errorReason(t, err); got != tt.reason {
+				t.Errorf("expected reason %q, got %q", tt.reason, got)
+			}
+		})
+	}
+
+	_, err := c.GetTeam(ctx, &prv1.GetTeamRequest{TeamName: "broken"})
+	if msg := status.Convert(err).Message(); msg != "failed to get team" {
+		t.Errorf("internal error leaked: %q", msg)
+	}
+}
+
+func TestReassignLimit(t *testing.T) {
+	c := prv1.NewPRServiceClient(dial(t, nil))
+
+	_, err := c.ReassignPullRequest(context.Background(), &prv1.ReassignPullRequestRequest{PullRequestId: "pr-limited", OldUserId: "u2"})
+	if status.Code(err) != codes.ResourceExhausted || errorReason(t, err) != "RATE_LIMITED" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var retry *errdetails.RetryInfo
+	for _, d := range status.Convert(err).Details() {
+		if r, ok := d.(*errdetails.RetryInfo); ok {
+			retry = r
+		}
+	}
+	if retry == nil || retry.GetRetryDelay().AsDuration() != 90*time.Second {
+		t.Errorf("expected RetryInfo of 90s, got %v", retry)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	authn := auth.NewStaticTokens()
+	authn.Add(userToken, auth.Principal{Name: "bob", Role: auth.RoleUser, UserID: "u2"})
+	limiter := middleware.NewRateLimiter(middleware.RateLimit{RequestsPerMinute: 1, Burst: 1}, nil)
+	conn := dialLimited(t, authn, nil, limiter)
+	c := prv1.NewPRServiceClient(conn)
+
+	if _, err := c.GetUserReviews(withToken(userToken), &prv1.GetUserReviewsRequest{UserId: "u2"}); err != nil {
+		t.Fatalf("first call: unexpected error: %v", err)
+	}
+	_, err := c.GetUserReviews(withToken(userToken), &prv1.GetUserReviewsRequest{UserId: "u2"})
+	if status.Code(err) != codes.ResourceExhausted || errorReason(t, err) != "RATE_LIMITED" {
+		t.Fatalf("expected ResourceExhausted, got %v", err)
+	}
+	var retry *errdetails.RetryInfo
+	for _, d := range status.Convert(err).Details() {
+		if r, ok := d.(*errdetails.RetryInfo); ok {
+			retry = r
+		}
+	}
+	if retry == nil || retry.GetRetryDelay().AsDuration() <= 0 {
+		t.Errorf("expected a positive RetryInfo, got %v", retry)
+	}
+
+	// The HTTP API spends the same bucket for the mirrored route.
+	p, err := authn.Authenticate(context.Background(), userToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := auth.WithPrincipal(context.Background(), p)
+	if wait := limiter.Reserve(middleware.ClientKey(ctx, "192.0.2.1"), "GET /users/getReview"); wait == 0 {
+		t.Error("expected the HTTP route to share the exhausted bucket")
+	}
+
+	// Health is not limited.
+	for i := 0; i < 3; i++ {
+		if _, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{}); err != nil {
+			t.Fatalf("health: unexpected error: %v", err)
+		}
+	}
+}
+
+func TestFailedAuthLimit(t *testing.T) {
+	authn := auth.NewStaticTokens()
+	authn.Add(userToken, auth.Principal{Name: "bob", Role: auth.RoleUser, UserID: "u2"})
+	authFailures := middleware.NewRateLimiter(middleware.RateLimit{RequestsPerMinute: 1, Burst: 2}, nil)
+	c := prv1.NewPRServiceClient(dialLimited(t, authn, authFailures, nil))
+
+	// Valid tokens are not charged.
+	for i := 0; i < 3; i++ {
+		if _, err := c.GetUserReviews(withToken(userToken), &prv1.GetUserReviewsRequest{UserId: "u2"}); err != nil {
+			t.Fatalf("call %d: unexpected error: %v", i, err)
+		}
+	}
+	for i := 0; i < 2; i++ {
+		_, err := c.GetUserReviews(withToken("guess-"+strconv.Itoa(i)), &prv1.GetUserReviewsRequest{UserId: "u2"})
+		if status.Code(err) != codes.Unauthenticated {
+			t.Fatalf("guess %d: expected Unauthenticated, got %v", i, err)
+		}
+	}
+	_, err := c.GetUserReviews(withToken("guess-2"), &prv1.GetUserReviewsRequest{UserId: "u2"})
+	if status.Code(err) != codes.ResourceExhausted || errorReason(t, err) != "RATE_LIMITED" {
+		t.Fatalf("expected ResourceExhausted after failed guesses, got %v", err)
+	}
+}
+
+func TestAuth(t *testing.T) {
+	authn := auth.NewStaticTokens()
+	authn.Add(adminToken, auth.Principal{Name: "admin", Role: auth.RoleAdmin})
+	authn.Add(userToken, auth.Principal{Name: "bob", Role: auth.RoleUser, UserID: "u2"})
+	authn.Add(scopeToken, auth.Principal{Name: "payments", Role: auth.RoleAdmin, Team: "payments"})
+	conn := dial(t, authn)
+	c := prv1.NewPRServiceClient(conn)
+
+	if _, err := c.GetTeam(context.Background(), &prv1.GetTeamRequest{TeamName: "backend"}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected Unauthenticated without a token, got %v", err)
+	}
+	if _, err := c.GetTeam(withToken("wrong-token-0123456789"), &prv1.GetTeamRequest{TeamName: "backend"}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected Unauthenticated for a bad token, got %v", err)
+	}
+	if _, err := c.GetTeam(withToken(adminToken), &prv1.GetTeamRequest{TeamName: "backend"}); err != nil {
+		t.Errorf("admin: unexpected error: %v", err)
+	}
+	if _, err := c.GetTeam(withToken(userToken), &prv1.GetTeamRequest{TeamName: "backend"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected PermissionDenied for the user role, got %v", err)
+	}
+	if _, err := c.GetUserReviews(withToken(userToken), &prv1.GetUserReviewsRequest{UserId: "u2"}); err != nil {
+		t.Errorf("own reviews: unexpected error: %v", err)
+	}
+	if _, err := c.GetUserReviews(withToken(userToken), &prv1.GetUserReviewsRequest{UserId: "u1"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected PermissionDenied for someone else's reviews, got %v", err)
+	}
+
+	resp, err := c.ListUsers(withToken(scopeToken), &prv1.ListUsersRequest{})
+	if err != nil || resp.GetUsers()[0].GetTeamName() != "payments" {
+		t.Errorf("expected the list to be limited to the scope, got %v, %v", resp, err)
+	}
+	if _, err := c.GetTeam(withToken(scopeToken), &prv1.GetTeamRequest{TeamName: "backend"}); status.Code(err) != codes.PermissionDenied || errorReason(t, err) != "FORBIDDEN" {
+		t.Errorf("expected PermissionDenied outside of the scope, got %v", err)
+	}
+	sync := &prv1.SyncTeamsRequest{Teams: []*prv1.Team{{TeamName: "payments", Members: []*prv1.TeamMember{{UserId: "u2", Username: "Bob"}}}}}
+	if _, err := c.SyncTeams(withToken(scopeToken), sync); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected PermissionDenied for syncing another team's user, got %v", err)
+	}
+	if _, err := c.ImportTeams(withToken(scopeToken), &prv1.ImportTeamsRequest{Teams: sync.Teams}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected PermissionDenied for importing another team's user, got %v", err)
+	}
+
+	// Health is served without a token.
+	if _, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{}); err != nil {
+		t.Errorf("health: unexpected error: %v", err)
+	}
+}
+
+func TestExport(t *testing.T) {
+	c := prv1.NewPRServiceClient(dial(t, nil))
+
+	stream, err := c.Export(context.Background(), &prv1.ExportRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var records []*prv1.ExportRecord
+	for {
+		rec, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		records = append(records, rec)
+	}
+
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+	if records[0].GetTable() != "team" || records[0].GetRow().AsMap()["name"] != "backend" {
+		t.Errorf("unexpected team record: %v", records[0])
+	}
+	row := records[1].GetRow().AsMap()
+	if records[1].GetTable() != "users" || row["is_active"] != true || row["created_at"] != "2025-01-02T03:04:05Z" || row["deleted_at"] != nil {
+		t.Errorf("unexpected users record: %v", row)
+	}
+}
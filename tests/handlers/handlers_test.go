@@ -2,7 +2,7 @@ package handlers_test
 
 import (
 	"context"
-	"database/sql"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -10,14 +10,34 @@ import (
 	"time"
 
 	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/chimort/avito_test_task/iternal/auth"
 	"github.com/chimort/avito_test_task/iternal/handlers"
 	"github.com/chimort/avito_test_task/iternal/pkg/logger"
 	"github.com/chimort/avito_test_task/iternal/repository"
+	"github.com/chimort/avito_test_task/iternal/service"
 	"github.com/labstack/echo/v4"
 )
 
 type mockUserService struct{}
 
+type mockReadiness struct {
+	ready bool
+}
+
+func (m *mockReadiness) CheckReadiness(ctx context.Context) api.ReadinessResponse {
+	if !m.ready {
+		msg := "server is shutting down"
+		return api.ReadinessResponse{
+			Status: api.NotReady,
+			Checks: []api.ReadinessCheck{{Name: "draining", Status: api.ReadinessCheckStatusFail, Message: &msg}},
+		}
+	}
+	return api.ReadinessResponse{
+		Status: api.Ready,
+		Checks: []api.ReadinessCheck{{Name: "draining", Status: api.ReadinessCheckStatusOk}},
+	}
+}
+
 var t = time.Now()
 
 func (m *mockUserService) TeamAdd(ctx context.Context, teamName string, members []api.TeamMember) (*api.Team, error) {
@@ -30,9 +50,88 @@ func (m *mockUserService) TeamAdd(ctx context.Context, teamName string, members
 	}, nil
 }
 
+func (m *mockUserService) AddMembers(ctx context.Context, teamName string, members []api.TeamMember) (*api.Team, error) {
+	if teamName == "notfound" {
+		return nil, repository.ErrTeamNotFound
+	}
+	return &api.Team{TeamName: teamName, Members: members}, nil
+}
+
+func (m *mockUserService) RemoveMembers(ctx context.Context, teamName string, userIDs []string) (*repository.MembersRemoval, error) {
+	if teamName == "notfound" {
+		return nil, repository.ErrTeamNotFound
+	}
+	for _, id := range userIDs {
+		if id == "stranger" {
+			return nil, repository.ErrUserNotFound
+		}
+	}
+	return &repository.MembersRemoval{
+		Team:              &api.Team{TeamName: teamName, Members: []api.TeamMember{}},
+		ReassignedReviews: len(userIDs),
+	}, nil
+}
+
+func (m *mockUserService) RenameTeam(ctx context.Context, teamName, newTeamName string) (*api.Team, error) {
+	if teamName == "notfound" {
+		return nil, repository.ErrTeamNotFound
+	}
+	if newTeamName == "existing" {
+		return nil, repository.ErrTeamExists
+	}
+	return &api.Team{TeamName: newTeamName, Members: []api.TeamMember{}}, nil
+}
+
+func (m *mockUserService) DeleteTeam(ctx context.Context, teamName string) error {
+	if teamName == "notfound" {
+		return repository.ErrTeamNotFound
+	}
+	return nil
+}
+
+func (m *mockUserService) SyncTeams(ctx context.Context, teams []api.Team, dryRun bool) ([]api.TeamSyncDiff, error) {
+	diffs := make([]api.TeamSyncDiff, 0, len(teams))
+	for _, team := range teams {
+		diff := api.TeamSyncDiff{TeamName: team.TeamName, Removed: []string{}, Activated: []string{}, Deactivated: []string{}}
+		for _, m := range team.Members {
+			diff.Added = append(diff.Added, m.UserId)
+		}
+		diffs = append(diffs, diff)
+	}
+	return diffs, nil
+}
+
+func (m *mockUserService) ImportTeams(ctx context.Context, teams []api.Team) (*api.ImportResult, error) {
+	res := &api.ImportResult{Teams: len(teams), TeamsCreated: len(teams)}
+	for _, team := range teams {
+		res.Users += len(team.Members)
+		res.MembersAdded += len(team.Members)
+	}
+	return res, nil
+}
+
+func (m *mockUserService) Export(ctx context.Context, from, to *time.Time, sink repository.ExportSink) error {
+	if from != nil && from.Year() < 2000 {
+		return errors.New("db error")
+	}
+	if err := sink.Table("team", []string{"name"}); err != nil {
+		return err
+	}
+	if err := sink.Row([]any{"backend"}); err != nil {
+		return err
+	}
+	if to != nil && to.Year() < 2000 {
+		return errors.New("connection reset")
+	}
+	if err := sink.Table("users", []string{"id", "name", "is_active", "deleted_at"}); err != nil {
+		return err
+	}
+	return sink.Row([]any{"u1", "Alice", true, nil})
+}
+
 func (m *mockUserService) SetIsActive(ctx context.Context, userID string, isActive bool) (*api.User, error) {
 	if userID == "notfound" {
-		return nil, sql.ErrNoRows
+		return nil, repository.ErrUserNotFound
 	}
 	return &api.User{
 		UserId:   userID,
@@ -93,6 +192,9 @@ func (m *mockUserService) PullRequestReassign(ctx context.Context, prID, oldUser
 	if prID == "pr-nocandidate" {
 		return nil, "", repository.ErrNoCandidates
 	}
+	if prID == "pr-limited" {
+		return nil, "", &repository.ReassignLimitError{RetryAfter: 90 * time.Second}
+	}
 	return &api.PullRequest{
 		PullRequestId:   prID,
 		PullRequestName: "PR Name",
@@ -102,31 +204,175 @@ func (m *mockUserService) PullRequestReassign(ctx context.Context, prID, oldUser
 	}, "u5", nil
 }
 
-func (m *mockUserService) GetPRsByReviewer(ctx context.Context, reviewerID string) ([]*api.PullRequestShort, error) {
+func (m *mockUserService) GetPullRequest(ctx context.Context, prID string) (*api.PullRequest, error) {
+	if prID == "pr-notfound" {
+		return nil, repository.ErrPRNotFound
+	}
+	return &api.PullRequest{
+		PullRequestId:     prID,
+		PullRequestName:   "PR Name",
+		AuthorId:          "u1",
+		Status:            "OPEN",
+		AssignedReviewers: []string{"u2", "u3"},
+		CreatedAt:         &t,
+	}, nil
+}
+
+func (m *mockUserService) ListPullRequests(ctx context.Context, params api.GetPullRequestListParams) ([]api.PullRequest, string, error) {
+	if params.Cursor != nil && *params.Cursor == "bad" {
+		return nil, "", service.ErrInvalidCursor
+	}
+	team := "any"
+	if params.TeamName != nil {
+		team = *params.TeamName
+	}
+	return []api.PullRequest{
+		{PullRequestId: "pr-1", PullRequestName: "PR for " + team, AuthorId: "u1", Status: "OPEN", CreatedAt: &t},
+	}, "", nil
+}
+
+func (m *mockUserService) GetUser(ctx context.Context, userID string) (*api.User, error) {
+	if userID == "notfound" {
+		return nil, repository.ErrUserNotFound
+	}
+	return &api.User{UserId: userID, Username: "Alice", TeamName: "payments", IsActive: true}, nil
+}
+
+func (m *mockUserService) UpdateUser(ctx context.Context, userID string, username *string, isActive *bool) (*api.User, error) {
+	if userID == "notfound" {
+		return nil, repository.ErrUserNotFound
+	}
+	user := &api.User{UserId: userID, Username: "Alice", TeamName: "payments", IsActive: true}
+	if username != nil {
+		user.Username = *username
+	}
+	if isActive != nil {
+		user.IsActive = *isActive
+	}
+	return user, nil
+}
+
+func (m *mockUserService) DeleteUser(ctx context.Context, userID string, mode api.UserDeleteMode) (*api.UserDeletion, error) {
+	if userID == "notfound" {
+		return nil, repository.ErrUserNotFound
+	}
+	if userID == "u1" && mode == api.Block {
+		return nil, repository.ErrUserHasActivity
+	}
+	return &api.UserDeletion{UserId: userID, Mode: mode, Anonymized: userID == "u1"}, nil
+}
+
+func (m *mockUserService) ListUsers(ctx context.Context, params api.GetUsersListParams) ([]api.User, string, error) {
+	if params.Cursor != nil && *params.Cursor == "bad" {
+		return nil, "", service.ErrInvalidCursor
+	}
+	team := "any"
+	if params.TeamName != nil {
+		team = *params.TeamName
+	}
+	return []api.User{{UserId: "u1", Username: "Alice", TeamName: team, IsActive: true}}, "", nil
+}
+
+func (m *mockUserService) GetPRsByReviewer(ctx context.Context, reviewerID string, params api.GetUsersGetReviewParams) ([]api.ReviewAssignment, string, error) {
 	if reviewerID == "empty" {
-		return []*api.PullRequestShort{}, nil
+		return []api.ReviewAssignment{}, "", nil
+	}
+	if params.Cursor != nil && *params.Cursor == "bad" {
+		return nil, "", service.ErrInvalidCursor
 	}
-	return []*api.PullRequestShort{
+	return []api.ReviewAssignment{
 		{
 			PullRequestId:   "pr-1",
 			PullRequestName: "Add feature X",
 			AuthorId:        "u1",
 			Status:          "OPEN",
+			CreatedAt:       t,
+			AssignedAt:      t,
 		},
-		{
-			PullRequestId:   "pr-2",
-			PullRequestName: "Fix bug Y",
-			AuthorId:        "u1",
-			Status:          "OPEN",
-		},
+	}, "next-page", nil
+}
+
+func (m *mockUserService) GetReviewerStats(ctx context.Context, from, to *time.Time) ([]api.ReviewerStats, error) {
+	return []api.ReviewerStats{
+		{UserId: "u2", Username: "Bob", Assigned: 3, Open: 1, Merged: 2, ReassignedOut: 1},
+	}, nil
+}
+
+func (m *mockUserService) GetTeamStats(ctx context.Context, from, to *time.Time) ([]api.TeamStats, error) {
+	return []api.TeamStats{
+		{TeamName: "backend", Assigned: 3, Open: 1, Merged: 2, ReassignedOut: 1, ReassignedIn: 1},
 	}, nil
 }
 
+func (m *mockUserService) GetCycleTimeReport(ctx context.Context, from, to *time.Time) (*api.CycleTimeReport, error) {
+	return &api.CycleTimeReport{
+		ByTeam:     []api.CycleTimeStats{{Key: "backend", Count: 2, P50Seconds: 3600, P90Seconds: 7200, P99Seconds: 7200}},
+		ByAuthor:   []api.CycleTimeStats{{Key: "u1", Count: 2, P50Seconds: 3600, P90Seconds: 7200, P99Seconds: 7200}},
+		ByReviewer: []api.CycleTimeStats{{Key: "u2", Count: 1, P50Seconds: 1800, P90Seconds: 1800, P99Seconds: 1800}},
+		Weekly:     []api.CycleTimeBucket{{WeekStart: time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), Count: 2, P50Seconds: 3600}},
+	}, nil
+}
+
+func (m *mockUserService) UserInTeam(ctx context.Context, userID, teamName string) (bool, error) {
+	return teamName == "payments" && (userID == "u1" || userID == "u3"), nil
+}
+
+func (m *mockUserService) PullRequestInTeam(ctx context.Context, prID, teamName string) (bool, error) {
+	return teamName == "payments" && prID == "pr-1", nil
+}
+
+// u1 leads payments, which also has u3 and PR pr-1.
+func (m *mockUserService) IsTeamLead(ctx context.Context, userID, teamName string) (bool, error) {
+	return userID == "u1" && teamName == "payments", nil
+}
+
+// u1, u2 and u3 exist; u2 is not in payments.
+func (m *mockUserService) UsersOutsideTeam(ctx context.Context, teamName string, userIDs []string) ([]string, error) {
+	var outside []string
+	for _, id := range userIDs {
+		inTeam, _ := m.UserInTeam(ctx, id, teamName)
+		if (id == "u1" || id == "u2" || id == "u3") && !inTeam {
+			outside = append(outside, id)
+		}
+	}
+	return outside, nil
+}
+
+func (m *mockUserService) LeadsUser(ctx context.Context, leadID, userID string) (bool, error) {
+	return leadID == "u1" && (userID == "u1" || userID == "u3"), nil
+}
+
+func (m *mockUserService) LeadsPullRequest(ctx context.Context, leadID, prID string) (bool, error) {
+	return leadID == "u1" && prID == "pr-1", nil
+}
+
+type mockAPIKeyService struct{}
+
+func (m *mockAPIKeyService) CreateAPIKey(ctx context.Context, key api.ApiKey) (*api.ApiKey, string, error) {
+	if key.TeamName != nil && *key.TeamName == "notfound" {
+		return nil, "", repository.ErrTeamNotFound
+	}
+	key.Id = 1
+	key.CreatedAt = t
+	return &key, "prs_secret", nil
+}
+
+func (m *mockAPIKeyService) ListAPIKeys(ctx context.Context, teamName *string) ([]api.ApiKey, error) {
+	return []api.ApiKey{{Id: 1, Name: "ci", Role: api.ApiKeyRoleAdmin, CreatedAt: t}}, nil
+}
+
+func (m *mockAPIKeyService) RevokeAPIKey(ctx context.Context, id int64, teamName *string) (*api.ApiKey, error) {
+	if id != 1 {
+		return nil, repository.ErrAPIKeyNotFound
+	}
+	return &api.ApiKey{Id: 1, Name: "ci", Role: api.ApiKeyRoleAdmin, CreatedAt: t, RevokedAt: &t}, nil
+}
+
 func TestPostUsersSetIsActive(t *testing.T) {
 	e := echo.New()
 	us := &mockUserService{}
 	log := logger.NewLogger("app", logger.LevelInfo)
-	h := handlers.NewHandlers(us, log)
+	h := handlers.NewHandlers(us, &mockAPIKeyService{}, &mockReadiness{ready: true}, log)
 
 	e.POST("/users/setIsActive", h.PostUsersSetIsActive)
 
@@ -153,7 +399,7 @@ func TestPostTeamAdd(t *testing.T) {
 	e := echo.New()
 	us := &mockUserService{}
 	log := logger.NewLogger("app", logger.LevelInfo)
-	h := handlers.NewHandlers(us, log)
+	h := handlers.NewHandlers(us, &mockAPIKeyService{}, &mockReadiness{ready: true}, log)
 
 	e.POST("/team/add", h.PostTeamAdd)
 
@@ -180,7 +426,7 @@ func TestGetTeamGet(t *testing.T) {
 	e := echo.New()
 	us := &mockUserService{}
 	log := logger.NewLogger("app", logger.LevelInfo)
-	h := handlers.NewHandlers(us, log)
+	h := handlers.NewHandlers(us, &mockAPIKeyService{}, &mockReadiness{ready: true}, log)
 
 	e.GET("/team/get", func(c echo.Context) error {
 		params := api.GetTeamGetParams{
@@ -208,7 +454,7 @@ func TestPostPullRequestCreate(t *testing.T) {
 	e := echo.New()
 	us := &mockUserService{}
 	log := logger.NewLogger("app", logger.LevelInfo)
-	h := handlers.NewHandlers(us, log)
+	h := handlers.NewHandlers(us, &mockAPIKeyService{}, &mockReadiness{ready: true}, log)
 
 	e.POST("/pullRequest/create", h.PostPullRequestCreate)
 
@@ -235,7 +481,7 @@ func TestPostPullRequestMerge(t *testing.T) {
 	e := echo.New()
 	us := &mockUserService{}
 	log := logger.NewLogger("app", logger.LevelInfo)
-	h := handlers.NewHandlers(us, log)
+	h := handlers.NewHandlers(us, &mockAPIKeyService{}, &mockReadiness{ready: true}, log)
 
 	e.POST("/pullRequest/merge", h.PostPullRequestMerge)
 
@@ -262,7 +508,7 @@ func TestPostPullRequestReassign(t *testing.T) {
 	e := echo.New()
 	us := &mockUserService{}
 	log := logger.NewLogger("app", logger.LevelInfo)
-	h := handlers.NewHandlers(us, log)
+	h := handlers.NewHandlers(us, &mockAPIKeyService{}, &mockReadiness{ready: true}, log)
 
 	e.POST("/pullRequest/reassign", h.PostPullRequestReassign)
 
@@ -283,13 +529,279 @@ func TestPostPullRequestReassign(t *testing.T) {
 	if rec.Code != http.StatusNotFound {
 		t.Errorf("expected 404, got %d", rec.Code)
 	}
+
+	body = `{"pull_request_id":"pr-limited","old_user_id":"u3"}`
+	req = httptest.NewRequest(http.MethodPost, "/pullRequest/reassign", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	if rec.Code != http.StatusTooManyRequests {
+		t.Errorf("expected 429, got %d", rec.Code)
+	}
+	if got := rec.Header().Get("Retry-After"); got != "90" {
+		t.Errorf("expected Retry-After 90, got %q", got)
+	}
+}
+
+func TestGetPullRequestGet(t *testing.T) {
+	e := echo.New()
+	us := &mockUserService{}
+	log := logger.NewLogger("app", logger.LevelInfo)
+	h := handlers.NewHandlers(us, &mockAPIKeyService{}, &mockReadiness{ready: true}, log)
+
+	api.RegisterHandlers(e, h)
+
+	req := httptest.NewRequest(http.MethodGet, "/pullRequest/get?pull_request_id=pr-1", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), `"assigned_reviewers":["u2","u3"]`) {
+		t.Errorf("unexpected body: %s", rec.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/pullRequest/get?pull_request_id=pr-notfound", nil)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", rec.Code)
+	}
+}
+
+func TestGetPullRequestList(t *testing.T) {
+	e := echo.New()
+	us := &mockUserService{}
+	log := logger.NewLogger("app", logger.LevelInfo)
+	h := handlers.NewHandlers(us, &mockAPIKeyService{}, &mockReadiness{ready: true}, log)
+
+	api.RegisterHandlers(e, h)
+
+	scoped := &auth.Principal{Name: "ci-payments", Role: auth.RoleAdmin, Team: "payments"}
+
+	tests := []struct {
+		name      string
+		query     string
+		principal *auth.Principal
+		code      int
+		body      string
+	}{
+		{"all filters", "author_id=u1&team_name=backend&status=MERGED&reviewer_id=u2&title=fix&order=asc&limit=10", nil, http.StatusOK, "PR for backend"},
+		{"bad status", "status=DRAFT", nil, http.StatusBadRequest, "status must be"},
+		{"bad cursor", "cursor=bad", nil, http.StatusBadRequest, "invalid cursor"},
+		{"scoped defaults to own team", "", scoped, http.StatusOK, "PR for payments"},
+		{"scoped other team", "team_name=backend", scoped, http.StatusForbidden, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/pullRequest/list?"+tt.query, nil)
+			if tt.principal != nil {
+				req = req.WithContext(auth.WithPrincipal(req.Context(), tt.principal))
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			if rec.Code != tt.code {
+				t.Fatalf("expected %d, got %d: %s", tt.code, rec.Code, rec.Body.String())
+			}
+			if !strings.Contains(rec.Body.String(), tt.body) {
+				t.Errorf("expected %q in body: %s", tt.body, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestUserManagement(t *testing.T) {
+	e := echo.New()
+	us := &mockUserService{}
+	log := logger.NewLogger("app", logger.LevelInfo)
+	h := handlers.NewHandlers(us, &mockAPIKeyService{}, &mockReadiness{ready: true}, log)
+
+	api.RegisterHandlers(e, h)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		code   int
+		want   string
+	}{
+		{"get", http.MethodGet, "/users/get?user_id=u1", "", http.StatusOK, `"username":"Alice"`},
+		{"get missing", http.MethodGet, "/users/get?user_id=notfound", "", http.StatusNotFound, ""},
+		{"rename", http.MethodPost, "/users/update", `{"user_id":"u1","username":"Alicia"}`, http.StatusOK, `"username":"Alicia"`},
+		{"deactivate", http.MethodPost, "/users/update", `{"user_id":"u1","is_active":false}`, http.StatusOK, `"is_active":false`},
+		{"nothing to update", http.MethodPost, "/users/update", `{"user_id":"u1"}`, http.StatusBadRequest, ""},
+		{"empty name", http.MethodPost, "/users/update", `{"user_id":"u1","username":""}`, http.StatusBadRequest, ""},
+		{"update missing", http.MethodPost, "/users/update", `{"user_id":"notfound","is_active":true}`, http.StatusNotFound, ""},
+		{"delete blocked", http.MethodPost, "/users/delete", `{"user_id":"u1"}`, http.StatusConflict, "USER_HAS_ACTIVITY"},
+		{"delete anonymize", http.MethodPost, "/users/delete", `{"user_id":"u1","mode":"anonymize"}`, http.StatusOK, `"anonymized":true`},
+		{"delete unused", http.MethodPost, "/users/delete", `{"user_id":"u9","mode":"block"}`, http.StatusOK, `"anonymized":false`},
+		{"delete bad mode", http.MethodPost, "/users/delete", `{"user_id":"u1","mode":"purge"}`, http.StatusBadRequest, ""},
+		{"delete missing", http.MethodPost, "/users/delete", `{"user_id":"notfound","mode":"reassign"}`, http.StatusNotFound, ""},
+		{"list", http.MethodGet, "/users/list?team_name=backend&is_active=true&username=al&limit=5", "", http.StatusOK, `"team_name":"backend"`},
+		{"list bad limit", http.MethodGet, "/users/list?limit=500", "", http.StatusBadRequest, ""},
+		{"list bad cursor", http.MethodGet, "/users/list?cursor=bad", "", http.StatusBadRequest, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			if rec.Code != tt.code {
+				t.Fatalf("expected %d, got %d: %s", tt.code, rec.Code, rec.Body.String())
+			}
+			if !strings.Contains(rec.Body.String(), tt.want) {
+				t.Errorf("expected %q in body: %s", tt.want, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestTeamManagement(t *testing.T) {
+	e := echo.New()
+	us := &mockUserService{}
+	log := logger.NewLogger("app", logger.LevelInfo)
+	h := handlers.NewHandlers(us, &mockAPIKeyService{}, &mockReadiness{ready: true}, log)
+
+	api.RegisterHandlers(e, h)
+
+	tests := []struct {
+		name string
+		path string
+		body string
+		code int
+		want string
+	}{
+		{"add members", "/team/addMembers", `{"team_name":"backend","members":[{"user_id":"u5","username":"Eve","is_active":true}]}`, http.StatusOK, `"user_id":"u5"`},
+		{"add no members", "/team/addMembers", `{"team_name":"backend","members":[]}`, http.StatusBadRequest, ""},
+		{"add no username", "/team/addMembers", `{"team_name":"backend","members":[{"user_id":"u5","is_active":true}]}`, http.StatusBadRequest, ""},
+		{"add unknown role", "/team/addMembers", `{"team_name":"backend","members":[{"user_id":"u5","username":"Eve","is_active":true,"role":"owner"}]}`, http.StatusBadRequest, "role must be lead or member"},
+		{"create with unknown role", "/team/add", `{"team_name":"platform","members":[{"user_id":"u5","username":"Eve","is_active":true,"role":"owner"}]}`, http.StatusBadRequest, "role must be lead or member"},
+		{"add to missing team", "/team/addMembers", `{"team_name":"notfound","members":[{"user_id":"u5","username":"Eve","is_active":true}]}`, http.StatusNotFound, ""},
+		{"remove members", "/team/removeMembers", `{"team_name":"backend","user_ids":["u1","u2"]}`, http.StatusOK, `"reassigned_reviews":2`},
+		{"remove nobody", "/team/removeMembers", `{"team_name":"backend","user_ids":[]}`, http.StatusBadRequest, ""},
+		{"remove stranger", "/team/removeMembers", `{"team_name":"backend","user_ids":["stranger"]}`, http.StatusNotFound, ""},
+		{"rename", "/team/rename", `{"team_name":"backend","new_team_name":"platform"}`, http.StatusOK, `"team_name":"platform"`},
+		{"rename taken", "/team/rename", `{"team_name":"backend","new_team_name":"existing"}`, http.StatusBadRequest, "TEAM_EXISTS"},
+		{"rename missing", "/team/rename", `{"team_name":"notfound","new_team_name":"platform"}`, http.StatusNotFound, ""},
+		{"rename no name", "/team/rename", `{"team_name":"backend"}`, http.StatusBadRequest, ""},
+		{"delete", "/team/delete", `{"team_name":"backend"}`, http.StatusOK, `"team_name":"backend"`},
+		{"delete missing", "/team/delete", `{"team_name":"notfound"}`, http.StatusNotFound, ""},
+		{"sync dry run", "/team/sync", `{"dry_run":true,"teams":[{"team_name":"backend","members":[{"user_id":"u1","username":"Alice","is_active":true}]}]}`, http.StatusOK, `"dry_run":true`},
+		{"sync", "/team/sync", `{"teams":[{"team_name":"backend","members":[{"user_id":"u1","username":"Alice","is_active":true}]}]}`, http.StatusOK, `"added":["u1"]`},
+		{"sync nothing", "/team/sync", `{"teams":[]}`, http.StatusBadRequest, ""},
+		{"sync duplicate team", "/team/sync", `{"teams":[{"team_name":"a","members":[]},{"team_name":"a","members":[]}]}`, http.StatusBadRequest, "duplicate team"},
+		{"sync duplicate user", "/team/sync", `{"teams":[{"team_name":"a","members":[{"user_id":"u1","username":"A","is_active":true},{"user_id":"u1","username":"A","is_active":true}]}]}`, http.StatusBadRequest, "duplicate user"},
+		{"sync conflicting flag", "/team/sync", `{"teams":[{"team_name":"a","members":[{"user_id":"u1","username":"A","is_active":true}]},{"team_name":"b","members":[{"user_id":"u1","username":"A","is_active":false}]}]}`, http.StatusBadRequest, "conflicting is_active"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			if rec.Code != tt.code {
+				t.Fatalf("expected %d, got %d: %s", tt.code, rec.Code, rec.Body.String())
+			}
+			if !strings.Contains(rec.Body.String(), tt.want) {
+				t.Errorf("expected %q in body: %s", tt.want, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestPostTeamImport(t *testing.T) {
+	e := echo.New()
+	us := &mockUserService{}
+	log := logger.NewLogger("app", logger.LevelInfo)
+	h := handlers.NewHandlers(us, &mockAPIKeyService{}, &mockReadiness{ready: true}, log)
+
+	api.RegisterHandlers(e, h)
+
+	scoped := &auth.Principal{Name: "ci-payments", Role: auth.RoleAdmin, Team: "payments"}
+
+	tests := []struct {
+		name        string
+		principal   *auth.Principal
+		contentType string
+		body        string
+		code        int
+		want        string
+	}{
+		{"csv", nil, "text/csv", "team,user_id,username,is_active\nbackend,u1,Alice,true\nbackend,u2,Bob,false\n", http.StatusOK, `"members_added":2`},
+		{"yaml", nil, "application/yaml", "teams:\n  - team_name: backend\n    members:\n      - {user_id: u1, username: Alice}\n", http.StatusOK, `"teams_created":1`},
+		{"csv errors", nil, "text/csv", "backend,u1,Alice,yes please\nbackend,,Bob,true\n", http.StatusBadRequest, `"line":2`},
+		{"json", nil, "application/json", `{"teams":[]}`, http.StatusUnsupportedMediaType, ""},
+		{"scoped own team", scoped, "text/csv", "payments,u1,Alice,true\n", http.StatusOK, `"members_added":1`},
+		{"scoped other team", scoped, "text/csv", "backend,u4,Dan,true\n", http.StatusForbidden, ""},
+		{"scoped other team's user", scoped, "text/csv", "payments,u2,Bob,false\n", http.StatusForbidden, "other teams"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/team/import", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, tt.contentType)
+			if tt.principal != nil {
+				req = req.WithContext(auth.WithPrincipal(req.Context(), tt.principal))
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			if rec.Code != tt.code {
+				t.Fatalf("expected %d, got %d: %s", tt.code, rec.Code, rec.Body.String())
+			}
+			if !strings.Contains(rec.Body.String(), tt.want) {
+				t.Errorf("expected %q in body: %s", tt.want, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestGetExport(t *testing.T) {
+	e := echo.New()
+	us := &mockUserService{}
+	log := logger.NewLogger("app", logger.LevelInfo)
+	h := handlers.NewHandlers(us, &mockAPIKeyService{}, &mockReadiness{ready: true}, log)
+
+	api.RegisterHandlers(e, h)
+
+	tests := []struct {
+		name        string
+		query       string
+		code        int
+		contentType string
+		want        string
+	}{
+		{"ndjson", "", http.StatusOK, "application/x-ndjson", `{"table":"users","row":{"deleted_at":null,"id":"u1","is_active":true,"name":"Alice"}}`},
+		{"csv", "?format=csv", http.StatusOK, "application/zip", "PK"},
+		{"bad format", "?format=xml", http.StatusBadRequest, echo.MIMEApplicationJSON, "unsupported format"},
+		{"bad window", "?from=2025-02-01T00:00:00Z&to=2025-01-01T00:00:00Z", http.StatusBadRequest, echo.MIMEApplicationJSON, "from must be before to"},
+		{"end trailer", "", http.StatusOK, "application/x-ndjson", `{"table":"_end","row":{"rows":2}}`},
+		{"db error", "?from=1999-01-01T00:00:00Z", http.StatusInternalServerError, echo.MIMEApplicationJSON, "failed to export data"},
+		{"db error midway", "?to=1999-01-01T00:00:00Z", http.StatusOK, "application/x-ndjson", `{"table":"_error","row":{"message":"export failed"}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/export"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			if rec.Code != tt.code {
+				t.Fatalf("expected %d, got %d: %s", tt.code, rec.Code, rec.Body.String())
+			}
+			if ct := rec.Header().Get(echo.HeaderContentType); !strings.HasPrefix(ct, tt.contentType) {
+				t.Errorf("expected content type %q, got %q", tt.contentType, ct)
+			}
+			if !strings.Contains(rec.Body.String(), tt.want) {
+				t.Errorf("expected %q in body: %s", tt.want, rec.Body.String())
+			}
+		})
+	}
 }
 
 func TestGetUsersGetReview(t *testing.T) {
 	e := echo.New()
 	us := &mockUserService{}
 	log := logger.NewLogger("app", logger.LevelInfo)
-	h := handlers.NewHandlers(us, log)
+	h := handlers.NewHandlers(us, &mockAPIKeyService{}, &mockReadiness{ready: true}, log)
 
 	e.GET("/users/getReview", func(c echo.Context) error {
 		params := api.GetUsersGetReviewParams{
@@ -312,3 +824,330 @@ func TestGetUsersGetReview(t *testing.T) {
 		t.Errorf("expected 200, got %d", rec.Code)
 	}
 }
+
+func TestGetUsersGetReview_Pagination(t *testing.T) {
+	e := echo.New()
+	us := &mockUserService{}
+	log := logger.NewLogger("app", logger.LevelInfo)
+	h := handlers.NewHandlers(us, &mockAPIKeyService{}, &mockReadiness{ready: true}, log)
+
+	api.RegisterHandlers(e, h)
+
+	tests := []struct {
+		name  string
+		query string
+		code  int
+		body  string
+	}{
+		{"first page", "user_id=u3&status=OPEN&order=asc&limit=1", http.StatusOK, `"next_cursor":"next-page"`},
+		{"last page", "user_id=empty", http.StatusOK, `"pull_requests":[]`},
+		{"bad status", "user_id=u3&status=CLOSED", http.StatusBadRequest, "status must be"},
+		{"bad limit", "user_id=u3&limit=0", http.StatusBadRequest, "limit must be"},
+		{"bad window", "user_id=u3&created_after=2025-02-01T00:00:00Z&created_before=2025-01-01T00:00:00Z", http.StatusBadRequest, "created_after"},
+		{"bad cursor", "user_id=u3&cursor=bad", http.StatusBadRequest, "invalid cursor"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/users/getReview?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			if rec.Code != tt.code {
+				t.Fatalf("expected %d, got %d: %s", tt.code, rec.Code, rec.Body.String())
+			}
+			if !strings.Contains(rec.Body.String(), tt.body) {
+				t.Errorf("expected %q in body: %s", tt.body, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestGetUsersGetReview_OtherUser(t *testing.T) {
+	e := echo.New()
+	us := &mockUserService{}
+	log := logger.NewLogger("app", logger.LevelInfo)
+	h := handlers.NewHandlers(us, &mockAPIKeyService{}, &mockReadiness{ready: true}, log)
+
+	e.GET("/users/getReview", func(c echo.Context) error {
+		params := api.GetUsersGetReviewParams{
+			UserId: c.QueryParam("user_id"),
+		}
+		return h.GetUsersGetReview(c, params)
+	})
+
+	principal := &auth.Principal{Name: "bot", Role: auth.RoleUser, UserID: "u3"}
+
+	req := httptest.NewRequest(http.MethodGet, "/users/getReview?user_id=u3", nil)
+	req = req.WithContext(auth.WithPrincipal(req.Context(), principal))
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", rec.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/users/getReview?user_id=u1", nil)
+	req = req.WithContext(auth.WithPrincipal(req.Context(), principal))
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("expected 403, got %d", rec.Code)
+	}
+}
+
+func TestGetStatsReviewers(t *testing.T) {
+	e := echo.New()
+	us := &mockUserService{}
+	log := logger.NewLogger("app", logger.LevelInfo)
+	h := handlers.NewHandlers(us, &mockAPIKeyService{}, &mockReadiness{ready: true}, log)
+
+	api.RegisterHandlers(e, h)
+
+	req := httptest.NewRequest(http.MethodGet, "/stats/reviewers?from=2025-01-01T00:00:00Z&to=2025-02-01T00:00:00Z", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", rec.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/stats/reviewers?from=2025-02-01T00:00:00Z&to=2025-01-01T00:00:00Z", nil)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", rec.Code)
+	}
+}
+
+func TestGetStatsTeams(t *testing.T) {
+	e := echo.New()
+	us := &mockUserService{}
+	log := logger.NewLogger("app", logger.LevelInfo)
+	h := handlers.NewHandlers(us, &mockAPIKeyService{}, &mockReadiness{ready: true}, log)
+
+	api.RegisterHandlers(e, h)
+
+	req := httptest.NewRequest(http.MethodGet, "/stats/teams", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), `"team_name":"backend"`) {
+		t.Errorf("unexpected body: %s", rec.Body.String())
+	}
+}
+
+func TestGetAnalyticsCycleTime(t *testing.T) {
+	e := echo.New()
+	us := &mockUserService{}
+	log := logger.NewLogger("app", logger.LevelInfo)
+	h := handlers.NewHandlers(us, &mockAPIKeyService{}, &mockReadiness{ready: true}, log)
+
+	api.RegisterHandlers(e, h)
+
+	req := httptest.NewRequest(http.MethodGet, "/analytics/cycleTime", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), `"by_team"`) {
+		t.Errorf("unexpected body: %s", rec.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/analytics/cycleTime?format=csv", nil)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", rec.Code)
+	}
+	if !strings.HasPrefix(rec.Header().Get(echo.HeaderContentType), "text/csv") {
+		t.Errorf("expected text/csv, got %s", rec.Header().Get(echo.HeaderContentType))
+	}
+	if !strings.Contains(rec.Body.String(), "team,backend,2,3600,7200,7200") ||
+		!strings.Contains(rec.Body.String(), "week,2025-01-06T00:00:00Z,2,3600,0,0") {
+		t.Errorf("unexpected csv: %s", rec.Body.String())
+	}
+}
+
+func TestGetHealthz(t *testing.T) {
+	e := echo.New()
+	log := logger.NewLogger("app", logger.LevelInfo)
+	h := handlers.NewHandlers(&mockUserService{}, &mockAPIKeyService{}, &mockReadiness{}, log)
+
+	api.RegisterHandlers(e, h)
+
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", rec.Code)
+	}
+}
+
+func TestGetReadyz(t *testing.T) {
+	log := logger.NewLogger("app", logger.LevelInfo)
+
+	e := echo.New()
+	api.RegisterHandlers(e, handlers.NewHandlers(&mockUserService{}, &mockAPIKeyService{}, &mockReadiness{ready: true}, log))
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", rec.Code)
+	}
+
+	e = echo.New()
+	api.RegisterHandlers(e, handlers.NewHandlers(&mockUserService{}, &mockAPIKeyService{}, &mockReadiness{ready: false}, log))
+	req = httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503, got %d", rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), `"not_ready"`) {
+		t.Errorf("unexpected body: %s", rec.Body.String())
+	}
+}
+
+func TestPostApiKeysCreate(t *testing.T) {
+	e := echo.New()
+	us := &mockUserService{}
+	log := logger.NewLogger("app", logger.LevelInfo)
+	h := handlers.NewHandlers(us, &mockAPIKeyService{}, &mockReadiness{ready: true}, log)
+
+	e.POST("/apiKeys/create", h.PostApiKeysCreate)
+
+	tests := []struct {
+		name string
+		body string
+		code int
+	}{
+		{"admin key", `{"name":"ci","role":"admin"}`, http.StatusCreated},
+		{"user key without user", `{"name":"bot","role":"user"}`, http.StatusBadRequest},
+		{"unknown role", `{"name":"ci","role":"root"}`, http.StatusBadRequest},
+		{"expired", `{"name":"ci","role":"admin","expires_at":"2020-01-01T00:00:00Z"}`, http.StatusBadRequest},
+		{"unknown team", `{"name":"ci","role":"admin","team_name":"notfound"}`, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/apiKeys/create", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			if rec.Code != tt.code {
+				t.Errorf("expected %d, got %d: %s", tt.code, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestPostApiKeysRevoke(t *testing.T) {
+	e := echo.New()
+	us := &mockUserService{}
+	log := logger.NewLogger("app", logger.LevelInfo)
+	h := handlers.NewHandlers(us, &mockAPIKeyService{}, &mockReadiness{ready: true}, log)
+
+	e.POST("/apiKeys/revoke", h.PostApiKeysRevoke)
+
+	req := httptest.NewRequest(http.MethodPost, "/apiKeys/revoke", strings.NewReader(`{"id":1}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "revoked_at") {
+		t.Errorf("expected 200 with revoked_at, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/apiKeys/revoke", strings.NewReader(`{"id":2}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", rec.Code)
+	}
+}
+
+func TestTeamScope(t *testing.T) {
+	e := echo.New()
+	us := &mockUserService{}
+	log := logger.NewLogger("app", logger.LevelInfo)
+	h := handlers.NewHandlers(us, &mockAPIKeyService{}, &mockReadiness{ready: true}, log)
+
+	e.POST("/users/setIsActive", h.PostUsersSetIsActive)
+	e.POST("/pullRequest/merge", h.PostPullRequestMerge)
+	e.POST("/apiKeys/create", h.PostApiKeysCreate)
+	e.POST("/team/sync", h.PostTeamSync)
+
+	principal := &auth.Principal{Name: "ci-payments", Role: auth.RoleAdmin, Team: "payments"}
+
+	tests := []struct {
+		name string
+		path string
+		body string
+		code int
+	}{
+		{"user in team", "/users/setIsActive", `{"user_id":"u1","is_active":false}`, http.StatusOK},
+		{"user outside team", "/users/setIsActive", `{"user_id":"u2","is_active":false}`, http.StatusForbidden},
+		{"pr in team", "/pullRequest/merge", `{"pull_request_id":"pr-1"}`, http.StatusOK},
+		{"pr outside team", "/pullRequest/merge", `{"pull_request_id":"pr-2"}`, http.StatusForbidden},
+		{"unscoped key", "/apiKeys/create", `{"name":"ci","role":"admin"}`, http.StatusForbidden},
+		{"key for own team", "/apiKeys/create", `{"name":"ci","role":"admin","team_name":"payments"}`, http.StatusCreated},
+		{"sync own team", "/team/sync", `{"teams":[{"team_name":"payments","members":[{"user_id":"u1","username":"Alice","is_active":true}]}]}`, http.StatusOK},
+		{"sync other team", "/team/sync", `{"teams":[{"team_name":"backend","members":[{"user_id":"u4","username":"Dan","is_active":true}]}]}`, http.StatusForbidden},
+		{"sync pulls in other team's user", "/team/sync", `{"teams":[{"team_name":"payments","members":[{"user_id":"u2","username":"Bob","is_active":false}]}]}`, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req = req.WithContext(auth.WithPrincipal(req.Context(), principal))
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			if rec.Code != tt.code {
+				t.Errorf("expected %d, got %d: %s", tt.code, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestTeamLead(t *testing.T) {
+	e := echo.New()
+	us := &mockUserService{}
+	log := logger.NewLogger("app", logger.LevelInfo)
+	h := handlers.NewHandlers(us, &mockAPIKeyService{}, &mockReadiness{ready: true}, log)
+
+	e.POST("/team/addMembers", h.PostTeamAddMembers)
+	e.POST("/users/setIsActive", h.PostUsersSetIsActive)
+	e.POST("/pullRequest/reassign", h.PostPullRequestReassign)
+
+	lead := &auth.Principal{Name: "alice", Role: auth.RoleUser, UserID: "u1"}
+	member := &auth.Principal{Name: "bob", Role: auth.RoleUser, UserID: "u2"}
+
+	tests := []struct {
+		name      string
+		principal *auth.Principal
+		path      string
+		body      string
+		code      int
+	}{
+		{"lead adds new hire", lead, "/team/addMembers", `{"team_name":"payments","members":[{"user_id":"u4","username":"Dan","is_active":true}]}`, http.StatusOK},
+		{"lead pulls in other team's user", lead, "/team/addMembers", `{"team_name":"payments","members":[{"user_id":"u2","username":"Bob","is_active":false}]}`, http.StatusForbidden},
+		{"lead sets role", lead, "/team/addMembers", `{"team_name":"payments","members":[{"user_id":"u4","username":"Dan","is_active":true,"role":"lead"}]}`, http.StatusForbidden},
+		{"lead adds to other team", lead, "/team/addMembers", `{"team_name":"backend","members":[{"user_id":"u4","username":"Dan","is_active":true}]}`, http.StatusForbidden},
+		{"lead deactivates member", lead, "/users/setIsActive", `{"user_id":"u3","is_active":false}`, http.StatusOK},
+		{"lead deactivates outsider", lead, "/users/setIsActive", `{"user_id":"u2","is_active":false}`, http.StatusForbidden},
+		{"member deactivates", member, "/users/setIsActive", `{"user_id":"u3","is_active":false}`, http.StatusForbidden},
+		{"lead forces reassign", lead, "/pullRequest/reassign", `{"pull_request_id":"pr-1","old_user_id":"u3"}`, http.StatusOK},
+		{"member forces reassign", member, "/pullRequest/reassign", `{"pull_request_id":"pr-1","old_user_id":"u3"}`, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req = req.WithContext(auth.WithPrincipal(req.Context(), tt.principal))
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			if rec.Code != tt.code {
+				t.Errorf("expected %d, got %d: %s", tt.code, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
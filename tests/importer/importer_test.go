@@ -0,0 +1,113 @@
+package importer_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/chimort/avito_test_task/iternal/importer"
+)
+
+func TestParseCSV(t *testing.T) {
+	input := `team,user_id,username,is_active
+backend,u1,Alice,true
+backend,u2,Bob,false
+frontend,u1,Alice,true
+`
+	teams, err := importer.Parse(strings.NewReader(input), importer.CSV)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(teams) != 2 || teams[0].TeamName != "backend" || len(teams[0].Members) != 2 {
+		t.Fatalf("unexpected teams: %+v", teams)
+	}
+	if teams[0].Members[1].IsActive {
+		t.Errorf("expected u2 to be inactive")
+	}
+}
+
+func TestParseCSV_Errors(t *testing.T) {
+	input := `team,user_id,username,is_active
+backend,u1,Alice,true
+backend,u2,Bob
+backend,u3,Carol,maybe
+,u4,Dan,true
+backend,u1,Alice,true
+frontend,u1,Alicia,true
+`
+	_, err := importer.Parse(strings.NewReader(input), importer.CSV)
+	var errs importer.Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected importer.Errors, got %v", err)
+	}
+	var lines []int
+	for _, le := range errs {
+		lines = append(lines, le.Line)
+	}
+	want := []int{3, 4, 5, 6, 7}
+	if len(lines) != len(want) {
+		t.Fatalf("expected errors on lines %v, got %v", want, errs)
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Fatalf("expected errors on lines %v, got %v", want, errs)
+		}
+	}
+	if !strings.Contains(errs[3].Message, "line 2") {
+		t.Errorf("expected duplicate to point at line 2, got %q", errs[3].Message)
+	}
+}
+
+func TestParseYAML(t *testing.T) {
+	input := `teams:
+  - team_name: backend
+    members:
+      - {user_id: u1, username: Alice, role: lead}
+      - user_id: u2
+        username: Bob
+        is_active: false
+`
+	teams, err := importer.Parse(strings.NewReader(input), importer.YAML)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(teams) != 1 || len(teams[0].Members) != 2 {
+		t.Fatalf("unexpected teams: %+v", teams)
+	}
+	m := teams[0].Members
+	if !m[0].IsActive || m[0].Role == nil || *m[0].Role != api.Lead || m[1].IsActive {
+		t.Errorf("unexpected members: %+v", m)
+	}
+}
+
+func TestParseYAML_Errors(t *testing.T) {
+	input := `teams:
+  - team_name: backend
+    members:
+      - {user_id: u1, username: Alice, role: boss}
+      - user_id: u2
+        is_active: nope
+      - {user_id: u3}
+`
+	_, err := importer.Parse(strings.NewReader(input), importer.YAML)
+	var errs importer.Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected importer.Errors, got %v", err)
+	}
+	if len(errs) != 3 || errs[0].Line != 4 || errs[1].Line != 6 || errs[2].Line != 7 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	if f, err := importer.FormatFromPath("teams.YAML"); err != nil || f != importer.YAML {
+		t.Errorf("expected yaml, got %q, %v", f, err)
+	}
+	if f, err := importer.FormatFromContentType("text/csv; charset=utf-8"); err != nil || f != importer.CSV {
+		t.Errorf("expected csv, got %q, %v", f, err)
+	}
+	if _, err := importer.FormatFromPath("teams.json"); err == nil {
+		t.Error("expected an error for .json")
+	}
+}
@@ -0,0 +1,114 @@
+package metrics_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/chimort/avito_test_task/iternal/config"
+	"github.com/chimort/avito_test_task/iternal/pkg/logger"
+	"github.com/chimort/avito_test_task/iternal/pkg/metrics"
+	"github.com/chimort/avito_test_task/iternal/repository"
+	"github.com/chimort/avito_test_task/iternal/service"
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMiddleware_RecordsRouteTemplate(t *testing.T) {
+	e := echo.New()
+	e.Use(metrics.Middleware())
+	e.GET("/team/get", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+	e.GET("/metrics", echo.WrapHandler(metrics.Handler()))
+
+	req := httptest.NewRequest(http.MethodGet, "/team/get?team_name=backend", nil)
+	e.ServeHTTP(httptest.NewRecorder(), req)
+	req = httptest.NewRequest(http.MethodGet, "/missing", nil)
+	e.ServeHTTP(httptest.NewRecorder(), req)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	body, _ := io.ReadAll(rec.Body)
+	for _, want := range []string{
+		`pr_service_http_requests_total{method="GET",route="/team/get",status="200"} 1`,
+		`pr_service_http_requests_total{method="GET",route="unmatched",status="404"} 1`,
+		`pr_service_http_request_duration_seconds_bucket{method="GET",route="/team/get"`,
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("expected %q in metrics output", want)
+		}
+	}
+}
+
+func TestUnaryServerInterceptor_RecordsMethodAndCode(t *testing.T) {
+	intercept := metrics.UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/pr.v1.PRService/GetTeam"}
+	_, _ = intercept(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		return nil, nil
+	})
+	_, _ = intercept(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		return nil, status.Error(codes.NotFound, "team not found")
+	})
+
+	rec := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, _ := io.ReadAll(rec.Body)
+	for _, want := range []string{
+		`pr_service_grpc_requests_total{code="OK",method="/pr.v1.PRService/GetTeam"} 1`,
+		`pr_service_grpc_requests_total{code="NotFound",method="/pr.v1.PRService/GetTeam"} 1`,
+		`pr_service_grpc_request_duration_seconds_count{method="/pr.v1.PRService/GetTeam"} 2`,
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("expected %q in metrics output", want)
+		}
+	}
+}
+
+type noCandidateRepo struct {
+	repository.UserRepo
+}
+
+func (noCandidateRepo) PullRequestReassign(ctx context.Context, prID, oldUserID string, maxPerHour int) (*api.PullRequest, string, error) {
+	return nil, "", repository.ErrNoCandidates
+}
+
+func TestNoCandidateFailuresCounter(t *testing.T) {
+	svc := service.NewUserService(noCandidateRepo{}, config.Default().Assignment, logger.NewLogger("app", logger.LevelInfo))
+	before := testutil.ToFloat64(metrics.NoCandidateFailures)
+
+	_, _, _ = svc.PullRequestReassign(context.Background(), "pr-1", "u2")
+
+	if got := testutil.ToFloat64(metrics.NoCandidateFailures) - before; got != 1 {
+		t.Errorf("expected counter to grow by 1, got %v", got)
+	}
+}
+
+// mergeRepo reports a fresh merge only for pr-open.
+type mergeRepo struct {
+	repository.UserRepo
+}
+
+func (mergeRepo) PullRequestMerge(ctx context.Context, prID string) (*api.PullRequest, bool, error) {
+	return &api.PullRequest{PullRequestId: prID, Status: api.PullRequestStatusMERGED}, prID == "pr-open", nil
+}
+
+func TestPRsMergedCounter_SkipsRepeatedMerges(t *testing.T) {
+	svc := service.NewUserService(mergeRepo{}, config.Default().Assignment, logger.NewLogger("app", logger.LevelInfo))
+	before := testutil.ToFloat64(metrics.PRsMerged)
+
+	_, _ = svc.PullRequestMerge(context.Background(), "pr-open")
+	_, _ = svc.PullRequestMerge(context.Background(), "pr-merged")
+
+	if got := testutil.ToFloat64(metrics.PRsMerged) - before; got != 1 {
+		t.Errorf("expected counter to grow by 1, got %v", got)
+	}
+}
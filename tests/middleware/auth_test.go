@@ -0,0 +1,81 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chimort/avito_test_task/iternal/auth"
+	"github.com/chimort/avito_test_task/iternal/middleware"
+	"github.com/chimort/avito_test_task/iternal/pkg/logger"
+	"github.com/labstack/echo/v4"
+)
+
+func newServer() *echo.Echo {
+	tokens := auth.NewStaticTokens()
+	tokens.Add("admin-token", auth.Principal{Name: "ops", Role: auth.RoleAdmin})
+	tokens.Add("user-token", auth.Principal{Name: "bot", Role: auth.RoleUser, UserID: "u1"})
+
+	e := echo.New()
+	e.Use(middleware.Auth(tokens, logger.NewLogger("app", logger.LevelInfo)))
+
+	ok := func(c echo.Context) error {
+		p, _ := auth.FromContext(c.Request().Context())
+		if p == nil {
+			return c.String(http.StatusOK, "")
+		}
+		return c.String(http.StatusOK, p.Name)
+	}
+	e.GET("/healthz", ok)
+	e.POST("/team/add", ok)
+	e.POST("/team/addMembers", ok)
+	e.POST("/pullRequest/create", ok)
+	e.GET("/users/getReview", ok)
+	return e
+}
+
+func do(e *echo.Echo, method, path, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	if token != "" {
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestAuth(t *testing.T) {
+	e := newServer()
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		code   int
+		body   string
+	}{
+		{"public route", http.MethodGet, "/healthz", "", http.StatusOK, ""},
+		{"missing token", http.MethodPost, "/team/add", "", http.StatusUnauthorized, ""},
+		{"invalid token", http.MethodPost, "/team/add", "wrong", http.StatusUnauthorized, ""},
+		{"admin", http.MethodPost, "/team/add", "admin-token", http.StatusOK, "ops"},
+		{"user on admin route", http.MethodPost, "/pullRequest/create", "user-token", http.StatusForbidden, ""},
+		{"user creates team", http.MethodPost, "/team/add", "user-token", http.StatusForbidden, ""},
+		{"user on lead route", http.MethodPost, "/team/addMembers", "user-token", http.StatusOK, "bot"},
+		{"user on user route", http.MethodGet, "/users/getReview", "user-token", http.StatusOK, "bot"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := do(e, tt.method, tt.path, tt.token)
+			if rec.Code != tt.code {
+				t.Fatalf("expected %d, got %d", tt.code, rec.Code)
+			}
+			if tt.body != "" && rec.Body.String() != tt.body {
+				t.Errorf("expected principal %q, got %q", tt.body, rec.Body.String())
+			}
+			if rec.Code == http.StatusUnauthorized && rec.Header().Get(echo.HeaderWWWAuthenticate) == "" {
+				t.Errorf("expected WWW-Authenticate header")
+			}
+		})
+	}
+}
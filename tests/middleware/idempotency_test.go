@@ -0,0 +1,211 @@
+package middleware_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/chimort/avito_test_task/iternal/auth"
+	"github.com/chimort/avito_test_task/iternal/middleware"
+	"github.com/chimort/avito_test_task/iternal/pkg/logger"
+	"github.com/chimort/avito_test_task/iternal/repository"
+	"github.com/labstack/echo/v4"
+)
+
+type memoryStore struct {
+	mu      sync.Mutex
+	records map[string]repository.IdempotencyRecord
+}
+
+func (m *memoryStore) Reserve(ctx context.Context, rec repository.IdempotencyRecord) (*repository.IdempotencyRecord, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if stored, ok := m.records[rec.Scope+"/"+rec.Key]; ok {
+		if stored.StatusCode != 0 || stored.LockedUntil.After(time.Now()) {
+			return &stored, false, nil
+		}
+	}
+	m.records[rec.Scope+"/"+rec.Key] = rec
+	return &rec, true, nil
+}
+
+func (m *memoryStore) Complete(ctx context.Context, scope, key string, status int, contentType string, body []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	rec := m.records[scope+"/"+key]
+	rec.StatusCode, rec.ContentType, rec.Body = status, contentType, body
+	m.records[scope+"/"+key] = rec
+	return nil
+}
+
+func (m *memoryStore) Release(ctx context.Context, scope, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.records, scope+"/"+key)
+	return nil
+}
+
+func newIdempotentServer(store *memoryStore, calls *int) *echo.Echo {
+	e := echo.New()
+	e.Use(middleware.Idempotency(store, time.Hour, time.Minute, logger.NewLogger("app", logger.LevelInfo)))
+	e.POST("/pullRequest/create", func(c echo.Context) error {
+		*calls++
+		body, _ := io.ReadAll(c.Request().Body)
+		if strings.Contains(string(body), "panic") {
+			panic("boom")
+		}
+		if strings.Contains(string(body), "fail") {
+			return c.JSON(http.StatusInternalServerError, map[string]string{"error": "boom"})
+		}
+		return c.JSON(http.StatusCreated, map[string]interface{}{"call": *calls})
+	})
+	return e
+}
+
+func post(e *echo.Echo, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/create", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	if key != "" {
+		req.Header.Set(middleware.HeaderIdempotencyKey, key)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestIdempotency_Replay(t *testing.T) {
+	store := &memoryStore{records: map[string]repository.IdempotencyRecord{}}
+	calls := 0
+	e := newIdempotentServer(store, &calls)
+
+	first := post(e, "k1", `{"pull_request_id":"pr-1"}`)
+	second := post(e, "k1", `{"pull_request_id":"pr-1"}`)
+	if calls != 1 {
+		t.Fatalf("expected handler to run once, ran %d times", calls)
+	}
+	if second.Code != first.Code || second.Body.String() != first.Body.String() {
+		t.Errorf("expected replay of %d %s, got %d %s", first.Code, first.Body, second.Code, second.Body)
+	}
+	if second.Header().Get(middleware.HeaderReplayed) != "true" {
+		t.Errorf("expected %s header on replay", middleware.HeaderReplayed)
+	}
+
+	if rec := post(e, "k1", `{"pull_request_id":"pr-2"}`); rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("expected 422 for a different body, got %d", rec.Code)
+	}
+
+	post(e, "", `{"pull_request_id":"pr-1"}`)
+	post(e, "", `{"pull_request_id":"pr-1"}`)
+	if calls != 3 {
+		t.Errorf("expected requests without a key to pass through, handler ran %d times", calls)
+	}
+}
+
+func TestIdempotency_ScopedByPrincipalID(t *testing.T) {
+	store := &memoryStore{records: map[string]repository.IdempotencyRecord{}}
+	calls := 0
+	e := echo.New()
+	// Both callers carry the same name, as two API keys named alike would.
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			p := &auth.Principal{Name: "ci", ID: c.Request().Header.Get("X-Caller"), Role: auth.RoleAdmin}
+			c.SetRequest(c.Request().WithContext(auth.WithPrincipal(c.Request().Context(), p)))
+			return next(c)
+		}
+	})
+	e.Use(middleware.Idempotency(store, time.Hour, time.Minute, logger.NewLogger("app", logger.LevelInfo)))
+	e.POST("/pullRequest/create", func(c echo.Context) error {
+		calls++
+		return c.JSON(http.StatusCreated, map[string]interface{}{"caller": c.Request().Header.Get("X-Caller")})
+	})
+
+	for _, caller := range []string{"apikey:1", "apikey:2"} {
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/create", strings.NewReader(`{"pull_request_id":"pr-1"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(middleware.HeaderIdempotencyKey, "k1")
+		req.Header.Set("X-Caller", caller)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		if rec.Header().Get(middleware.HeaderReplayed) != "" || !strings.Contains(rec.Body.String(), caller) {
+			t.Errorf("expected %s to get its own response, got %s", caller, rec.Body)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("expected each caller's request to run, handler ran %d times", calls)
+	}
+}
+
+func TestIdempotency_InProgress(t *testing.T) {
+	store := &memoryStore{records: map[string]repository.IdempotencyRecord{}}
+	calls := 0
+	e := newIdempotentServer(store, &calls)
+
+	body := `{"pull_request_id":"pr-1"}`
+	post(e, "k1", body)
+
+	// Pretend the first request is still running.
+	rec := store.records["/k1"]
+	rec.StatusCode = 0
+	store.records["/k1"] = rec
+
+	if second := post(e, "k1", body); second.Code != http.StatusConflict {
+		t.Errorf("expected 409 while the first request is in progress, got %d", second.Code)
+	}
+}
+
+func TestIdempotency_ServerErrorNotStored(t *testing.T) {
+	store := &memoryStore{records: map[string]repository.IdempotencyRecord{}}
+	calls := 0
+	e := newIdempotentServer(store, &calls)
+
+	post(e, "k1", `{"fail":true}`)
+	post(e, "k1", `{"fail":true}`)
+	if calls != 2 {
+		t.Errorf("expected 5xx to be retried, handler ran %d times", calls)
+	}
+}
+
+func TestIdempotency_StaleLeaseTakenOver(t *testing.T) {
+	store := &memoryStore{records: map[string]repository.IdempotencyRecord{}}
+	calls := 0
+	e := newIdempotentServer(store, &calls)
+
+	body := `{"pull_request_id":"pr-1"}`
+	post(e, "k1", body)
+
+	// Pretend the first request died without a response and its lease ran out.
+	rec := store.records["/k1"]
+	rec.StatusCode = 0
+	rec.LockedUntil = time.Now().Add(-time.Second)
+	store.records["/k1"] = rec
+
+	if second := post(e, "k1", body); second.Code != http.StatusCreated {
+		t.Errorf("expected the stale reservation to be taken over, got %d", second.Code)
+	}
+	if calls != 2 {
+		t.Errorf("expected handler to run again, ran %d times", calls)
+	}
+}
+
+func TestIdempotency_PanicReleasesKey(t *testing.T) {
+	store := &memoryStore{records: map[string]repository.IdempotencyRecord{}}
+	calls := 0
+	e := newIdempotentServer(store, &calls)
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected the panic to propagate")
+			}
+		}()
+		post(e, "k1", `{"panic":true}`)
+	}()
+	if _, ok := store.records["/k1"]; ok {
+		t.Error("expected the key to be released after a panic")
+	}
+}
@@ -0,0 +1,132 @@
+package middleware_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/chimort/avito_test_task/iternal/auth"
+	"github.com/chimort/avito_test_task/iternal/middleware"
+	"github.com/chimort/avito_test_task/iternal/pkg/logger"
+	"github.com/labstack/echo/v4"
+)
+
+// newRateLimitedServer limits requests the way the app does. With authn
+// set, Auth runs first and the limiter keys on its principal.
+func newRateLimitedServer(authn auth.Authenticator) *echo.Echo {
+	limiter := middleware.NewRateLimiter(
+		middleware.RateLimit{RequestsPerMinute: 60, Burst: 2},
+		map[string]middleware.RateLimit{
+			"POST /pullRequest/reassign": {RequestsPerMinute: 1, Burst: 1},
+		},
+	)
+
+	log := logger.NewLogger("app", logger.LevelInfo)
+	e := echo.New()
+	if authn != nil {
+		e.Use(middleware.Auth(authn, log))
+	}
+	e.Use(limiter.Middleware(log))
+
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusOK) }
+	e.GET("/healthz", ok)
+	e.POST("/team/add", ok)
+	e.POST("/pullRequest/reassign", ok)
+	return e
+}
+
+func TestRateLimit(t *testing.T) {
+	tokens := auth.NewStaticTokens()
+	tokens.Add("a", auth.Principal{Name: "a", Role: auth.RoleAdmin})
+	tokens.Add("b", auth.Principal{Name: "b", Role: auth.RoleAdmin})
+	e := newRateLimitedServer(tokens)
+
+	for i := 0; i < 2; i++ {
+		if rec := do(e, http.MethodPost, "/team/add", "a"); rec.Code != http.StatusOK {
+			t.Fatalf("request %d: expected 200, got %d", i, rec.Code)
+		}
+	}
+	rec := do(e, http.MethodPost, "/team/add", "a")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429, got %d", rec.Code)
+	}
+	if got := rec.Header().Get("Retry-After"); got != "1" {
+		t.Errorf("expected Retry-After 1, got %q", got)
+	}
+
+	if rec := do(e, http.MethodPost, "/team/add", "b"); rec.Code != http.StatusOK {
+		t.Errorf("other client: expected 200, got %d", rec.Code)
+	}
+
+	if rec := do(e, http.MethodPost, "/pullRequest/reassign", "a"); rec.Code != http.StatusOK {
+		t.Errorf("other route: expected 200, got %d", rec.Code)
+	}
+	rec = do(e, http.MethodPost, "/pullRequest/reassign", "a")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Errorf("route override: expected 429, got %d", rec.Code)
+	}
+	if got := rec.Header().Get("Retry-After"); got != "60" {
+		t.Errorf("expected Retry-After 60, got %q", got)
+	}
+
+	for i := 0; i < 5; i++ {
+		if rec := do(e, http.MethodGet, "/healthz", ""); rec.Code != http.StatusOK {
+			t.Fatalf("public route: expected 200, got %d", rec.Code)
+		}
+	}
+}
+
+func TestRateLimit_IgnoresUnverifiedTokens(t *testing.T) {
+	e := newRateLimitedServer(nil)
+
+	// Without auth every made-up token comes from the same IP and shares
+	// its bucket.
+	for i := 0; i < 2; i++ {
+		if rec := do(e, http.MethodPost, "/team/add", fmt.Sprintf("random-%d", i)); rec.Code != http.StatusOK {
+			t.Fatalf("request %d: expected 200, got %d", i, rec.Code)
+		}
+	}
+	if rec := do(e, http.MethodPost, "/team/add", "random-2"); rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429, got %d", rec.Code)
+	}
+	if rec := do(e, http.MethodPost, "/pullRequest/reassign", "random-3"); rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	if rec := do(e, http.MethodPost, "/pullRequest/reassign", "random-4"); rec.Code != http.StatusTooManyRequests {
+		t.Errorf("route override: expected 429, got %d", rec.Code)
+	}
+}
+
+func TestFailedAuthLimit(t *testing.T) {
+	tokens := auth.NewStaticTokens()
+	tokens.Add("a", auth.Principal{Name: "a", Role: auth.RoleAdmin})
+	log := logger.NewLogger("app", logger.LevelInfo)
+	e := echo.New()
+	e.Use(middleware.FailedAuthLimit(middleware.NewRateLimiter(middleware.RateLimit{RequestsPerMinute: 1, Burst: 2}, nil), log))
+	e.Use(middleware.Auth(tokens, log))
+	e.POST("/team/add", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+	e.GET("/healthz", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+
+	// Valid tokens are not charged.
+	for i := 0; i < 3; i++ {
+		if rec := do(e, http.MethodPost, "/team/add", "a"); rec.Code != http.StatusOK {
+			t.Fatalf("request %d: expected 200, got %d", i, rec.Code)
+		}
+	}
+	if rec := do(e, http.MethodPost, "/team/add", ""); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("missing token: expected 401, got %d", rec.Code)
+	}
+	if rec := do(e, http.MethodPost, "/team/add", "guess-1"); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("bad token: expected 401, got %d", rec.Code)
+	}
+	rec := do(e, http.MethodPost, "/team/add", "guess-2")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429 after failed attempts, got %d", rec.Code)
+	}
+	if got := rec.Header().Get("Retry-After"); got != "60" {
+		t.Errorf("expected Retry-After 60, got %q", got)
+	}
+	if rec := do(e, http.MethodGet, "/healthz", ""); rec.Code != http.StatusOK {
+		t.Errorf("public route: expected 200, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,162 @@
+package prctl_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chimort/avito_test_task/iternal/prctl"
+)
+
+func run(t *testing.T, args ...string) (int, string, string) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	code := prctl.Run(context.Background(), args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /team/get", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":{"code":"UNAUTHORIZED","message":"missing token"}}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("team_name") != "backend" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"code":"NOT_FOUND","message":"team not found"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"team_name":"backend","members":[{"user_id":"u1","username":"Alice","is_active":true,"role":"lead"},{"user_id":"u2","username":"Bob","is_active":false}]}`))
+	})
+	mux.HandleFunc("POST /pullRequest/create", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]any{"pr": map[string]any{
+			"pull_request_id":    body["pull_request_id"],
+			"pull_request_name":  body["pull_request_name"],
+			"author_id":          body["author_id"],
+			"status":             "OPEN",
+			"assigned_reviewers": []string{"u2"},
+		}})
+	})
+	mux.HandleFunc("GET /users/getReview", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("cursor") == "" {
+			_, _ = w.Write([]byte(`{"user_id":"u2","next_cursor":"c1","pull_requests":[{"pull_request_id":"pr-1","pull_request_name":"One","author_id":"u1","status":"OPEN","created_at":"2025-01-01T00:00:00Z","assigned_at":"2025-01-01T00:00:00Z"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"user_id":"u2","pull_requests":[{"pull_request_id":"pr-2","pull_request_name":"Two","author_id":"u1","status":"MERGED","created_at":"2025-01-02T00:00:00Z","assigned_at":"2025-01-02T00:00:00Z"}]}`))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestProfiles(t *testing.T) {
+	srv := newServer(t)
+	cfg := filepath.Join(t.TempDir(), "config.yaml")
+
+	if code, _, stderr := run(t, "-config", cfg, "profile", "set", "-server", srv.URL, "-token", "secret", "dev"); code != 0 {
+		t.Fatalf("profile set: exit %d: %s", code, stderr)
+	}
+	if code, _, stderr := run(t, "-config", cfg, "profile", "set", "-server", "http://prod.invalid", "prod"); code != 0 {
+		t.Fatalf("profile set: exit %d: %s", code, stderr)
+	}
+
+	_, out, _ := run(t, "-config", cfg, "profile", "list")
+	if !strings.Contains(out, "*        dev") || !strings.Contains(out, "prod") {
+		t.Errorf("expected dev to be current: %s", out)
+	}
+
+	code, out, stderr := run(t, "-config", cfg, "team", "get", "backend")
+	if code != 0 {
+		t.Fatalf("team get: exit %d: %s", code, stderr)
+	}
+	for _, want := range []string{"team backend", "u1       Alice     true    lead", "u2       Bob       false   member"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+
+	if code, _, _ := run(t, "-config", cfg, "profile", "use", "staging"); code != 1 {
+		t.Errorf("expected exit 1 for unknown profile, got %d", code)
+	}
+}
+
+func TestAPIErrors(t *testing.T) {
+	srv := newServer(t)
+	cfg := filepath.Join(t.TempDir(), "config.yaml")
+
+	code, _, stderr := run(t, "-config", cfg, "-server", srv.URL, "-token", "secret", "team", "get", "payments")
+	if code != 1 || !strings.Contains(stderr, "404 NOT_FOUND: team not found") {
+		t.Errorf("expected not found error, got %d: %s", code, stderr)
+	}
+
+	code, _, stderr = run(t, "-config", cfg, "-server", srv.URL, "team", "get", "backend")
+	if code != 1 || !strings.Contains(stderr, "UNAUTHORIZED") {
+		t.Errorf("expected unauthorized error, got %d: %s", code, stderr)
+	}
+
+	if code, _, _ := run(t, "-config", cfg, "-server", srv.URL, "team", "get"); code != 2 {
+		t.Errorf("expected usage error, got %d", code)
+	}
+	if code, _, _ := run(t, "-config", cfg, "team", "get", "backend"); code != 1 {
+		t.Errorf("expected error without a server, got %d", code)
+	}
+}
+
+func TestPullRequestCreateJSON(t *testing.T) {
+	srv := newServer(t)
+	cfg := filepath.Join(t.TempDir(), "config.yaml")
+
+	code, out, stderr := run(t, "-config", cfg, "-server", srv.URL, "-o", "json", "pr", "create", "pr-1", "u1", "Fix", "login")
+	if code != 0 {
+		t.Fatalf("exit %d: %s", code, stderr)
+	}
+	var pr struct {
+		ID        string   `json:"pull_request_id"`
+		Name      string   `json:"pull_request_name"`
+		Reviewers []string `json:"assigned_reviewers"`
+	}
+	if err := json.Unmarshal([]byte(out), &pr); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, out)
+	}
+	if pr.ID != "pr-1" || pr.Name != "Fix login" || len(pr.Reviewers) != 1 {
+		t.Errorf("unexpected pull request: %+v", pr)
+	}
+}
+
+func TestReviewsListAll(t *testing.T) {
+	srv := newServer(t)
+	cfg := filepath.Join(t.TempDir(), "config.yaml")
+
+	_, out, _ := run(t, "-config", cfg, "-server", srv.URL, "reviews", "list", "u2")
+	if !strings.Contains(out, "pr-1") || strings.Contains(out, "pr-2") {
+		t.Errorf("expected only the first page:\n%s", out)
+	}
+	_, out, _ = run(t, "-config", cfg, "-server", srv.URL, "reviews", "list", "-all", "u2")
+	if !strings.Contains(out, "pr-1") || !strings.Contains(out, "pr-2") {
+		t.Errorf("expected both pages:\n%s", out)
+	}
+}
+
+func TestCompletion(t *testing.T) {
+	for _, shell := range []string{"bash", "zsh", "fish"} {
+		code, out, _ := run(t, "completion", shell)
+		if code != 0 || !strings.Contains(out, "reviews") || !strings.Contains(out, "set-active") {
+			t.Errorf("%s: unexpected completion script (exit %d):\n%s", shell, code, out)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/chimort/avito_test_task/iternal/repository"
+	"github.com/lib/pq"
+)
+
+var apiKeyCols = []string{"id", "name", "role", "user_id", "team_name", "expires_at", "created_at", "last_used_at", "revoked_at"}
+
+func TestAPIKeyRepository_CreateAPIKey(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	repo := repository.NewAPIKeyRepository(db)
+	ctx := context.Background()
+
+	team := "payments"
+	mock.ExpectQuery("insert into api_keys").
+		WithArgs("ci", "hash", "admin", nil, &team, nil).
+		WillReturnRows(sqlmock.NewRows(apiKeyCols).AddRow(1, "ci", "admin", nil, "payments", nil, globalTime, nil, nil))
+
+	key, err := repo.CreateAPIKey(ctx, api.ApiKey{Name: "ci", Role: api.ApiKeyRoleAdmin, TeamName: &team}, "hash")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if key.Id != 1 || key.TeamName == nil || *key.TeamName != "payments" || key.UserId != nil {
+		t.Errorf("unexpected key: %+v", key)
+	}
+
+	mock.ExpectQuery("insert into api_keys").
+		WillReturnError(&pq.Error{Code: "23503", Constraint: "api_keys_team_name_fkey"})
+	_, err = repo.CreateAPIKey(ctx, api.ApiKey{Name: "ci", Role: api.ApiKeyRoleAdmin, TeamName: &team}, "hash2")
+	if !errors.Is(err, repository.ErrTeamNotFound) {
+		t.Errorf("expected ErrTeamNotFound, got %v", err)
+	}
+}
+
+func TestAPIKeyRepository_UseAPIKey(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	repo := repository.NewAPIKeyRepository(db)
+	ctx := context.Background()
+
+	mock.ExpectQuery("update api_keys set last_used_at = now()").
+		WithArgs("hash").
+		WillReturnRows(sqlmock.NewRows(apiKeyCols).AddRow(1, "bot", "user", "u1", nil, nil, globalTime, globalTime, nil))
+
+	key, err := repo.UseAPIKey(ctx, "hash")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if key.UserId == nil || *key.UserId != "u1" || key.LastUsedAt == nil {
+		t.Errorf("unexpected key: %+v", key)
+	}
+
+	mock.ExpectQuery("update api_keys set last_used_at = now()").
+		WithArgs("revoked").
+		WillReturnRows(sqlmock.NewRows(apiKeyCols))
+	if _, err := repo.UseAPIKey(ctx, "revoked"); !errors.Is(err, repository.ErrAPIKeyNotFound) {
+		t.Errorf("expected ErrAPIKeyNotFound, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
@@ -0,0 +1,160 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/chimort/avito_test_task/iternal/repository"
+)
+
+type versionedSink struct {
+	recordingSink
+	version uint
+}
+
+func (s *versionedSink) Begin(schemaVersion uint) error {
+	s.version = schemaVersion
+	return nil
+}
+
+func TestUserRepository_Backup(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("select version, dirty from schema_migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(10, false))
+	for _, table := range repository.BackupTables {
+		rows := sqlmock.NewRows(table.Columns)
+		if table.Name == "team" {
+			rows.AddRow("backend")
+		}
+		mock.ExpectExec("declare export_" + table.Name + " no scroll cursor for select").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery("fetch forward 500 from export_" + table.Name).WillReturnRows(rows)
+		mock.ExpectExec("close export_" + table.Name).WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+	mock.ExpectCommit()
+
+	sink := &versionedSink{recordingSink: recordingSink{rows: map[string][][]any{}}}
+	if err := repo.Backup(ctx, sink); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sink.version != 10 {
+		t.Errorf("expected schema version 10, got %d", sink.version)
+	}
+	if len(sink.tables) != len(repository.BackupTables) || len(sink.rows["team"]) != 1 {
+		t.Errorf("unexpected backup: %v %v", sink.tables, sink.rows)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestUserRepository_BackupDirtySchema(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("select version, dirty from schema_migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(10, true))
+	mock.ExpectRollback()
+
+	sink := &versionedSink{recordingSink: recordingSink{rows: map[string][][]any{}}}
+	if err := repo.Backup(context.Background(), sink); !errors.Is(err, repository.ErrSchemaDirty) {
+		t.Errorf("expected ErrSchemaDirty, got %v", err)
+	}
+}
+
+type sliceSource struct {
+	tables []string
+	rows   []map[string]any
+}
+
+func (s *sliceSource) Next() (string, map[string]any, error) {
+	if len(s.rows) == 0 {
+		return "", nil, io.EOF
+	}
+	table, row := s.tables[0], s.rows[0]
+	s.tables, s.rows = s.tables[1:], s.rows[1:]
+	return table, row, nil
+}
+
+func TestUserRepository_Restore(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	src := &sliceSource{
+		tables: []string{"team", "users", "users", "user_teams"},
+		rows: []map[string]any{
+			{"name": "backend"},
+			{"id": "u1", "name": "Alice", "is_active": true, "deleted_at": nil},
+			{"id": "u2", "name": "Bob", "is_active": false, "deleted_at": nil},
+			{"user_id": "u1", "team_name": "backend", "role": "lead"},
+		},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("lock table team, users").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("select \\(select count\\(\\*\\) from team where name <> 'backend'\\)").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectExec("delete from user_teams; delete from users; delete from team").WillReturnResult(sqlmock.NewResult(0, 3))
+	team := mock.ExpectPrepare("insert into team \\(\"name\"\\) values \\(\\$1\\)")
+	team.ExpectExec().WithArgs("backend").WillReturnResult(sqlmock.NewResult(0, 1))
+	users := mock.ExpectPrepare("insert into users \\(\"deleted_at\", \"id\", \"is_active\", \"name\"\\) values \\(\\$1, \\$2, \\$3, \\$4\\)")
+	users.ExpectExec().WithArgs(nil, "u1", true, "Alice").WillReturnResult(sqlmock.NewResult(0, 1))
+	users.ExpectExec().WithArgs(nil, "u2", false, "Bob").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectPrepare("insert into user_teams").
+		ExpectExec().WithArgs("lead", "backend", "u1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("select setval\\(pg_get_serial_sequence\\('pr_reassignments', 'id'\\)").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("select setval\\(pg_get_serial_sequence\\('api_keys', 'id'\\)").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	counts, err := repo.Restore(ctx, src)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if counts["team"] != 1 || counts["users"] != 2 || counts["user_teams"] != 1 {
+		t.Errorf("unexpected counts: %v", counts)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestUserRepository_RestoreNotEmpty(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("lock table").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("select \\(select count").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectRollback()
+
+	if _, err := repo.Restore(context.Background(), &sliceSource{}); !errors.Is(err, repository.ErrDatabaseNotEmpty) {
+		t.Errorf("expected ErrDatabaseNotEmpty, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestUserRepository_RestoreUnknownColumn(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("lock table").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("select \\(select count").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectExec("delete from user_teams").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	src := &sliceSource{tables: []string{"users"}, rows: []map[string]any{{"id": "u1", "password": "x"}}}
+	if _, err := repo.Restore(context.Background(), src); err == nil {
+		t.Error("expected error for unknown column")
+	}
+}
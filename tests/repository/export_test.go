@@ -0,0 +1,82 @@
+package repository_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+type recordingSink struct {
+	tables []string
+	rows   map[string][][]any
+	table  string
+}
+
+func (s *recordingSink) Table(name string, columns []string) error {
+	s.tables = append(s.tables, name)
+	s.table = name
+	return nil
+}
+
+func (s *recordingSink) Row(values []any) error {
+	s.rows[s.table] = append(s.rows[s.table], append([]any(nil), values...))
+	return nil
+}
+
+func TestUserRepository_Export(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	created := from.Add(time.Hour)
+
+	mock.ExpectBegin()
+	fetch := func(table string, rows *sqlmock.Rows) {
+		mock.ExpectExec("declare export_" + table + " no scroll cursor for").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery("fetch forward 500 from export_" + table).WillReturnRows(rows)
+		mock.ExpectExec("close export_" + table).WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+	fetch("team", sqlmock.NewRows([]string{"name"}).AddRow("backend"))
+	fetch("users", sqlmock.NewRows([]string{"id", "name", "is_active", "deleted_at"}).
+		AddRow("u1", "Alice", true, nil).
+		AddRow("u2", "Bob", false, nil))
+	fetch("user_teams", sqlmock.NewRows([]string{"user_id", "team_name", "role"}).AddRow("u1", "backend", "lead"))
+	mock.ExpectExec(regexp.QuoteMeta("declare export_pull_requests no scroll cursor for") +
+		".*" + regexp.QuoteMeta("pr.created_at >= '2025-01-01T00:00:00Z'::timestamptz")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("fetch forward 500 from export_pull_requests").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "author_id", "status", "created_at", "merged_at"}).
+			AddRow("pr-1", "Fix", "u1", "OPEN", created, nil))
+	mock.ExpectExec("close export_pull_requests").WillReturnResult(sqlmock.NewResult(0, 0))
+	fetch("pr_reviewers", sqlmock.NewRows([]string{"pr_id", "reviewer_id", "assigned_at"}))
+	mock.ExpectCommit()
+
+	sink := &recordingSink{rows: map[string][][]any{}}
+	if err := repo.Export(ctx, &from, nil, sink); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"team", "users", "user_teams", "pull_requests", "pr_reviewers"}
+	if len(sink.tables) != len(want) {
+		t.Fatalf("expected tables %v, got %v", want, sink.tables)
+	}
+	for i := range want {
+		if sink.tables[i] != want[i] {
+			t.Errorf("expected tables %v, got %v", want, sink.tables)
+			break
+		}
+	}
+	if len(sink.rows["users"]) != 2 || len(sink.rows["pr_reviewers"]) != 0 {
+		t.Errorf("unexpected rows: %v", sink.rows)
+	}
+	if pr := sink.rows["pull_requests"]; len(pr) != 1 || pr[0][0] != "pr-1" {
+		t.Errorf("unexpected pull requests: %v", pr)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
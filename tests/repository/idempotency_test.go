@@ -0,0 +1,49 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/chimort/avito_test_task/iternal/repository"
+)
+
+func TestIdempotencyRepository_Reserve(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	repo := repository.NewIdempotencyRepository(db)
+	ctx := context.Background()
+	rec := repository.IdempotencyRecord{Scope: "ci", Key: "k1", RequestHash: "h1", ExpiresAt: globalTime, LockedUntil: globalTime}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`delete from idempotency_keys .*status_code is null and locked_until <= now\(\)`).WithArgs("ci", "k1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("insert into idempotency_keys").WithArgs("ci", "k1", "h1", globalTime, globalTime).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	_, reserved, err := repo.Reserve(ctx, rec)
+	if err != nil || !reserved {
+		t.Fatalf("expected reservation, got %v %v", reserved, err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("delete from idempotency_keys").WithArgs("ci", "k1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("insert into idempotency_keys").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("select scope, key, request_hash").WithArgs("ci", "k1").
+		WillReturnRows(sqlmock.NewRows([]string{"scope", "key", "request_hash", "status_code", "content_type", "response_body", "expires_at"}).
+			AddRow("ci", "k1", "h1", 201, "application/json", []byte(`{"pr":{}}`), globalTime))
+	mock.ExpectCommit()
+
+	stored, reserved, err := repo.Reserve(ctx, rec)
+	if err != nil || reserved {
+		t.Fatalf("expected stored record, got %v %v", reserved, err)
+	}
+	if stored.StatusCode != 201 || string(stored.Body) != `{"pr":{}}` {
+		t.Errorf("unexpected record: %+v", stored)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
@@ -0,0 +1,81 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/chimort/avito_test_task/iternal/repository"
+	"github.com/lib/pq"
+)
+
+func TestUserRepository_ImportTeams(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	lead := api.Lead
+	teams := []api.Team{
+		{TeamName: "backend", Members: []api.TeamMember{
+			{UserId: "u1", Username: "Alice", IsActive: true, Role: &lead},
+			{UserId: "u2", Username: "Bob", IsActive: false},
+		}},
+		{TeamName: "frontend", Members: []api.TeamMember{
+			{UserId: "u1", Username: "Alice", IsActive: true},
+		}},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("insert into team .* unnest").
+		WithArgs(pq.Array([]string{"backend", "frontend"})).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("insert into users .* unnest").
+		WithArgs(pq.Array([]string{"u1", "u2"}), pq.Array([]string{"Alice", "Bob"}), pq.Array([]bool{true, false})).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("insert into user_teams .* unnest").
+		WithArgs(
+			pq.Array([]string{"u1", "u2", "u1"}),
+			pq.Array([]string{"backend", "backend", "frontend"}),
+			pq.Array([]string{"lead", "member", "member"})).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	res, err := repo.ImportTeams(ctx, teams)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	want := api.ImportResult{Teams: 2, TeamsCreated: 1, Users: 2, MembersAdded: 2}
+	if *res != want {
+		t.Errorf("expected %+v, got %+v", want, *res)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUserRepository_ImportTeams_DeletedUser(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("insert into team .* unnest").WillReturnResult(sqlmock.NewResult(0, 0))
+	// u2 was anonymized, so the upsert skips it.
+	mock.ExpectExec("insert into users .* on conflict \\(id\\) do update set is_active = EXCLUDED.is_active where users.deleted_at is null").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectRollback()
+
+	_, err := repo.ImportTeams(ctx, []api.Team{{TeamName: "backend", Members: []api.TeamMember{
+		{UserId: "u1", Username: "Alice", IsActive: true},
+		{UserId: "u2", Username: "Bob", IsActive: true},
+	}}})
+	if !errors.Is(err, repository.ErrUserNotFound) {
+		t.Fatalf("expected ErrUserNotFound, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -41,17 +41,12 @@ func TestUserRepository_TeamAdd(t *testing.T) {
 			WithArgs(teamName).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
-		for _, m := range members {
-			mock.ExpectExec("(?i)INSERT INTO users").
-				WithArgs(m.UserId, m.Username, m.IsActive).
-				WillReturnResult(sqlmock.NewResult(1, 1))
-		}
-
-		for _, m := range members {
-			mock.ExpectExec("(?i)INSERT INTO user_teams").
-				WithArgs(m.UserId, teamName).
-				WillReturnResult(sqlmock.NewResult(1, 1))
-		}
+		mock.ExpectExec("(?i)INSERT INTO users .* unnest").
+			WithArgs(pq.Array([]string{"u1", "u2"}), pq.Array([]string{"Alice", "Bob"}), pq.Array([]bool{true, true})).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec("(?i)INSERT INTO user_teams .* unnest").
+			WithArgs(pq.Array([]string{"u1", "u2"}), pq.Array([]string{teamName, teamName}), pq.Array([]string{"member", "member"})).
+			WillReturnResult(sqlmock.NewResult(0, 2))
 		mock.ExpectCommit()
 
 		team, err := repo.TeamAdd(ctx, teamName, members)
@@ -90,8 +85,10 @@ func TestUserRepository_UpdateActive(t *testing.T) {
 
 		rows := sqlmock.NewRows([]string{"id", "name", "is_active", "team_name"}).
 			AddRow(userID, "testuser", false, "devteam")
+		mock.ExpectBegin()
 		mock.ExpectQuery("SELECT .* FROM users u").WithArgs(userID).WillReturnRows(rows)
 		mock.ExpectExec("UPDATE users SET is_active").WithArgs(isActive, userID).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
 
 		user, err := repo.UpdateActive(ctx, userID, isActive)
 		if err != nil {
@@ -104,10 +101,27 @@ func TestUserRepository_UpdateActive(t *testing.T) {
 
 	t.Run("not found", func(t *testing.T) {
 		userID := "notfound"
+		mock.ExpectBegin()
 		mock.ExpectQuery("SELECT .* FROM users u").WithArgs(userID).WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
 		_, err := repo.UpdateActive(ctx, userID, true)
-		if err != sql.ErrNoRows {
-			t.Fatalf("expected sql.ErrNoRows, got %v", err)
+		if !errors.Is(err, repository.ErrUserNotFound) {
+			t.Fatalf("expected ErrUserNotFound, got %v", err)
+		}
+	})
+
+	t.Run("deleted after select", func(t *testing.T) {
+		userID := "123"
+		rows := sqlmock.NewRows([]string{"id", "name", "is_active", "team_name"}).
+			AddRow(userID, "testuser", false, "devteam")
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT .* FROM users u").WithArgs(userID).WillReturnRows(rows)
+		mock.ExpectExec("UPDATE users SET is_active = \\$1 WHERE id = \\$2 AND deleted_at IS NULL").
+			WithArgs(true, userID).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+		_, err := repo.UpdateActive(ctx, userID, true)
+		if !errors.Is(err, repository.ErrUserNotFound) {
+			t.Fatalf("expected ErrUserNotFound, got %v", err)
 		}
 	})
 }
@@ -119,9 +133,9 @@ func TestUserRepository_GetTeam(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		teamName := "backend"
-		rows := sqlmock.NewRows([]string{"id", "name", "is_active"}).
-			AddRow("u1", "Alice", true).
-			AddRow("u2", "Bob", true)
+		rows := sqlmock.NewRows([]string{"id", "name", "is_active", "role"}).
+			AddRow("u1", "Alice", true, "lead").
+			AddRow("u2", "Bob", true, "member")
 		mock.ExpectQuery("(?i)SELECT .* FROM user_teams").WithArgs(teamName).WillReturnRows(rows)
 
 		team, err := repo.GetTeam(ctx, teamName)
@@ -131,10 +145,13 @@ func TestUserRepository_GetTeam(t *testing.T) {
 		if len(team.Members) != 2 {
 			t.Errorf("expected 2 members, got %d", len(team.Members))
 		}
+		if *team.Members[0].Role != api.Lead {
+			t.Errorf("expected u1 to be lead, got %s", *team.Members[0].Role)
+		}
 	})
 
 	t.Run("not found", func(t *testing.T) {
-		mock.ExpectQuery("(?i)SELECT .* FROM user_teams").WithArgs("missing").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "is_active"}))
+		mock.ExpectQuery("(?i)SELECT .* FROM user_teams").WithArgs("missing").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "is_active", "role"}))
 		_, err := repo.GetTeam(ctx, "missing")
 		if !errors.Is(err, repository.ErrTeamNotFound) {
 			t.Fatalf("expected ErrTeamNotFound, got %v", err)
@@ -150,11 +167,13 @@ func TestUserRepository_PullRequestCreate(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mock.ExpectBegin()
 		mock.ExpectExec("insert into pull_requests").WillReturnResult(sqlmock.NewResult(1, 1))
-		mock.ExpectQuery("select u.id from users u join user_teams").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("u2"))
+		mock.ExpectQuery("select u.id from users u join user_teams").
+			WithArgs("u1", 2).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("u2"))
 		mock.ExpectExec("insert into pr_reviewers").WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectCommit()
 
-		pr, err := repo.PullRequestCreate(ctx, "pr1", "Test PR", "u1")
+		pr, err := repo.PullRequestCreate(ctx, "pr1", "Test PR", "u1", 2)
 		if err != nil {
 			t.Fatalf("expected no error, got %v", err)
 		}
@@ -168,7 +187,7 @@ func TestUserRepository_PullRequestCreate(t *testing.T) {
 		mock.ExpectExec("insert into pull_requests").WillReturnResult(sqlmock.NewResult(1, 0))
 		mock.ExpectRollback()
 
-		_, err := repo.PullRequestCreate(ctx, "pr2", "Test PR", "missing")
+		_, err := repo.PullRequestCreate(ctx, "pr2", "Test PR", "missing", 2)
 		if err != repository.ErrUserNotFound {
 			t.Errorf("expected ErrUserNotFound, got %v", err)
 		}
@@ -190,12 +209,30 @@ func TestUserRepository_PullRequestMerge(t *testing.T) {
 		mock.ExpectQuery("select reviewer_id from pr_reviewers").WillReturnRows(sqlmock.NewRows([]string{"reviewer_id"}).AddRow("u2"))
 		mock.ExpectCommit()
 
-		pr, err := repo.PullRequestMerge(ctx, "pr1")
+		pr, merged, err := repo.PullRequestMerge(ctx, "pr1")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if pr.Status != "MERGED" || !merged {
+			t.Errorf("expected a fresh merge, got %v, merged=%v", pr.Status, merged)
+		}
+	})
+
+	t.Run("already merged", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("select status from pull_requests").WithArgs("pr1").WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("MERGED"))
+		mock.ExpectQuery("select id, title, author_id, status, created_at, merged_at from pull_requests").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "author_id", "status", "created_at", "merged_at"}).
+				AddRow("pr1", "Test PR", "u1", "MERGED", globalTime, globalTime))
+		mock.ExpectQuery("select reviewer_id from pr_reviewers").WillReturnRows(sqlmock.NewRows([]string{"reviewer_id"}).AddRow("u2"))
+		mock.ExpectRollback()
+
+		pr, merged, err := repo.PullRequestMerge(ctx, "pr1")
 		if err != nil {
 			t.Fatalf("expected no error, got %v", err)
 		}
-		if pr.Status != "MERGED" {
-			t.Errorf("expected MERGED, got %v", pr.Status)
+		if pr.Status != "MERGED" || merged {
+			t.Errorf("expected the merged PR unchanged, got %v, merged=%v", pr.Status, merged)
 		}
 	})
 }
@@ -211,16 +248,21 @@ func TestUserRepository_PullRequestReassign(t *testing.T) {
 			WithArgs("pr1").
 			WillReturnRows(sqlmock.NewRows([]string{"author_id", "status", "title", "created_at"}).
 				AddRow("u1", "OPEN", "Test PR", globalTime))
+		mock.ExpectQuery("select count\\(\\*\\), .* from pr_reassignments").WithArgs("pr1").
+			WillReturnRows(sqlmock.NewRows([]string{"count", "retry_after"}).AddRow(3, 1200.0))
 		mock.ExpectQuery("select 1 from pr_reviewers").
 			WithArgs("pr1", "u2").
 			WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
 		mock.ExpectQuery("select u.id from users u join user_teams").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("u3"))
 		mock.ExpectExec("delete from pr_reviewers").WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectExec("insert into pr_reviewers").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("insert into pr_reassignments").
+			WithArgs("pr1", "u2", "u3").
+			WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectQuery("select reviewer_id from pr_reviewers").WillReturnRows(sqlmock.NewRows([]string{"reviewer_id"}).AddRow("u3"))
 		mock.ExpectCommit()
 
-		pr, newReviewer, err := repo.PullRequestReassign(ctx, "pr1", "u2")
+		pr, newReviewer, err := repo.PullRequestReassign(ctx, "pr1", "u2", 10)
 		if err != nil {
 			t.Fatalf("expected no error, got %v", err)
 		}
@@ -231,6 +273,30 @@ func TestUserRepository_PullRequestReassign(t *testing.T) {
 			t.Errorf("unexpected assigned reviewers: %+v", pr.AssignedReviewers)
 		}
 	})
+
+	t.Run("limit reached", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("select author_id, status, title, created_at from pull_requests where id = \\$1 for update").
+			WithArgs("pr1").
+			WillReturnRows(sqlmock.NewRows([]string{"author_id", "status", "title", "created_at"}).
+				AddRow("u1", "OPEN", "Test PR", globalTime))
+		mock.ExpectQuery("select count\\(\\*\\), .* from pr_reassignments").WithArgs("pr1").
+			WillReturnRows(sqlmock.NewRows([]string{"count", "retry_after"}).AddRow(10, 1200.5))
+		mock.ExpectRollback()
+
+		_, _, err := repo.PullRequestReassign(ctx, "pr1", "u2", 10)
+		var limitErr *repository.ReassignLimitError
+		if !errors.As(err, &limitErr) {
+			t.Fatalf("expected ReassignLimitError, got %v", err)
+		}
+		if limitErr.RetryAfter != 1200500*time.Millisecond {
+			t.Errorf("unexpected retry after %v", limitErr.RetryAfter)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
 }
 
 func TestUserRepository_GetPRsByReviewer(t *testing.T) {
@@ -238,11 +304,64 @@ func TestUserRepository_GetPRsByReviewer(t *testing.T) {
 	defer db.Close()
 	ctx := context.Background()
 
-	mock.ExpectQuery("select pr.id, pr.title, pr.author_id, pr.status from pull_requests pr join pr_reviewers prr").
-		WithArgs("u2").
-		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "author_id", "status"}).AddRow("pr1", "Test PR", "u1", "OPEN"))
+	status := "OPEN"
+	after := &repository.PageCursor{CreatedAt: globalTime, ID: "pr0"}
+	mock.ExpectQuery(`select pr.id, pr.title, pr.author_id, pr.status, pr.created_at, prr.assigned_at from pull_requests pr join pr_reviewers prr .* order by pr.created_at asc, pr.id asc`).
+		WithArgs("u2", &status, nil, nil, &after.CreatedAt, &after.ID, 11).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "author_id", "status", "created_at", "assigned_at"}).
+			AddRow("pr1", "Test PR", "u1", "OPEN", globalTime, globalTime))
+
+	prs, err := repo.GetPRsByReviewer(ctx, "u2", repository.ReviewFilter{
+		Status:    &status,
+		Ascending: true,
+		Limit:     11,
+		After:     after,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(prs) != 1 || prs[0].PullRequestId != "pr1" || !prs[0].AssignedAt.Equal(globalTime) {
+		t.Errorf("unexpected PRs: %+v", prs)
+	}
+}
+
+func TestUserRepository_GetPullRequest(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+	ctx := context.Background()
 
-	prs, err := repo.GetPRsByReviewer(ctx, "u2")
+	mock.ExpectQuery("select .* from pull_requests pr where pr.id = ").
+		WithArgs("pr1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "author_id", "status", "created_at", "merged_at", "array"}).
+			AddRow("pr1", "Test PR", "u1", "OPEN", globalTime, nil, "{u2,u3}"))
+	mock.ExpectQuery("select .* from pull_requests pr where pr.id = ").
+		WithArgs("missing").
+		WillReturnError(sql.ErrNoRows)
+
+	pr, err := repo.GetPullRequest(ctx, "pr1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(pr.AssignedReviewers) != 2 || pr.AssignedReviewers[1] != "u3" {
+		t.Errorf("unexpected reviewers: %v", pr.AssignedReviewers)
+	}
+	if _, err := repo.GetPullRequest(ctx, "missing"); !errors.Is(err, repository.ErrPRNotFound) {
+		t.Errorf("expected ErrPRNotFound, got %v", err)
+	}
+}
+
+func TestUserRepository_ListPullRequests(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	team, title, pattern := "backend", "50%_off", `%50\%\_off%`
+	mock.ExpectQuery("from pull_requests pr where .* order by pr.created_at desc, pr.id desc").
+		WithArgs(nil, &team, nil, nil, &pattern, nil, nil, nil, nil, 51).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "author_id", "status", "created_at", "merged_at", "array"}).
+			AddRow("pr1", "50%_off banner", "u1", "OPEN", globalTime, nil, "{}"))
+
+	prs, err := repo.ListPullRequests(ctx, repository.PullRequestFilter{TeamName: &team, Title: &title, Limit: 51})
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
@@ -250,3 +369,106 @@ func TestUserRepository_GetPRsByReviewer(t *testing.T) {
 		t.Errorf("unexpected PRs: %+v", prs)
 	}
 }
+
+func TestUserRepository_GetReviewerStats(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	from := globalTime.Add(-24 * time.Hour)
+	mock.ExpectQuery("with assigned as .* from counts c join users u").
+		WithArgs(&from, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "assigned", "open", "merged", "reassigned_out", "reassigned_in"}).
+			AddRow("u1", "Alice", 2, 1, 1, 0, 1).
+			AddRow("u2", "Bob", 0, 0, 0, 1, 0))
+
+	stats, err := repo.GetReviewerStats(ctx, &from, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(stats) != 2 || stats[0].Assigned != 2 || stats[1].ReassignedOut != 1 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestUserRepository_GetTeamStats(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	mock.ExpectQuery("with assigned as .* from team t").
+		WithArgs(nil, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "assigned", "open", "merged", "reassigned_out", "reassigned_in"}).
+			AddRow("backend", 3, 1, 2, 1, 1))
+
+	stats, err := repo.GetTeamStats(ctx, nil, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(stats) != 1 || stats[0].TeamName != "backend" || stats[0].Merged != 2 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestUserRepository_GetCycleTimeReport(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	cols := []string{"key", "count", "p50", "p90", "p99"}
+	mock.ExpectQuery("select ut.team_name, count").WithArgs(nil, nil).
+		WillReturnRows(sqlmock.NewRows(cols).AddRow("backend", 2, 60.0, 120.0, 120.0))
+	mock.ExpectQuery("select pr.author_id, count").WithArgs(nil, nil).
+		WillReturnRows(sqlmock.NewRows(cols).AddRow("u1", 2, 60.0, 120.0, 120.0))
+	mock.ExpectQuery("select prr.reviewer_id, count").WithArgs(nil, nil).
+		WillReturnRows(sqlmock.NewRows(cols).AddRow("u2", 2, 30.0, 90.0, 90.0))
+	mock.ExpectQuery("select date_trunc").WithArgs(nil, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"week_start", "count", "p50", "p90", "p99"}).AddRow(globalTime, 2, 60.0, 120.0, 120.0))
+
+	report, err := repo.GetCycleTimeReport(ctx, nil, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(report.ByTeam) != 1 || report.ByReviewer[0].P50Seconds != 30 || len(report.Weekly) != 1 {
+		t.Errorf("unexpected report: %+v", report)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestUserRepository_GetTeamActivity(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	mock.ExpectQuery("select t.name, .* from team t").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "open_prs", "active_users"}).
+			AddRow("backend", 3, 2).
+			AddRow("frontend", 0, 1))
+
+	activity, err := repo.GetTeamActivity(ctx)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(activity) != 2 || activity[0].OpenPRs != 3 || activity[1].ActiveUsers != 1 {
+		t.Errorf("unexpected activity: %+v", activity)
+	}
+}
+
+func TestUserRepository_LeadsPullRequest(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	mock.ExpectQuery("select exists").WithArgs("u1", "pr1").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	ok, err := repo.LeadsPullRequest(ctx, "u1", "pr1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !ok {
+		t.Errorf("expected u1 to lead pr1")
+	}
+}
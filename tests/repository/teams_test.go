@@ -0,0 +1,434 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/chimort/avito_test_task/iternal/repository"
+	"github.com/lib/pq"
+)
+
+var memberCols = []string{"id", "name", "is_active", "role"}
+
+func TestUserRepository_AddMembers(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		members := []api.TeamMember{{UserId: "u3", Username: "Carol", IsActive: true}}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("select name from team where name = .* for update").WithArgs("backend").
+			WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("backend"))
+		// Existing users keep their is_active.
+		mock.ExpectExec("(?i)INSERT INTO users .* set is_active = users.is_active").
+			WithArgs(pq.Array([]string{"u3"}), pq.Array([]string{"Carol"}), pq.Array([]bool{true})).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("insert into user_teams .* on conflict").WithArgs("u3", "backend", nil).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery("from user_teams ut join users u").WithArgs("backend").
+			WillReturnRows(sqlmock.NewRows(memberCols).
+				AddRow("u1", "Alice", true, "lead").
+				AddRow("u3", "Carol", true, "member"))
+		mock.ExpectCommit()
+
+		team, err := repo.AddMembers(ctx, "backend", members)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(team.Members) != 2 || *team.Members[0].Role != api.Lead {
+			t.Errorf("unexpected team: %+v", team)
+		}
+	})
+
+	t.Run("team not found", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("select name from team").WithArgs("ghost").
+			WillReturnRows(sqlmock.NewRows([]string{"name"}))
+		mock.ExpectRollback()
+
+		_, err := repo.AddMembers(ctx, "ghost", []api.TeamMember{{UserId: "u3", Username: "Carol"}})
+		if !errors.Is(err, repository.ErrTeamNotFound) {
+			t.Errorf("expected ErrTeamNotFound, got %v", err)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUserRepository_UsersOutsideTeam(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery("select u.id from users u where u.id = any").
+		WithArgs("backend", pq.Array([]string{"u1", "u2", "u9"})).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("u2"))
+
+	ids, err := repo.UsersOutsideTeam(context.Background(), "backend", []string{"u1", "u2", "u9"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(ids) != 1 || ids[0] != "u2" {
+		t.Errorf("unexpected users: %v", ids)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUserRepository_RemoveMembers(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	t.Run("reassigns and drops reviews", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("select name from team").WithArgs("backend").
+			WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("backend"))
+		mock.ExpectQuery("select count").WithArgs("backend", sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery("select pr.id, pr.author_id, prr.reviewer_id").WithArgs("backend", sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "author_id", "reviewer_id"}).
+				AddRow("pr1", "u1", "u2").
+				AddRow("pr2", "u1", "u2"))
+		mock.ExpectQuery("select u.id from users u join user_teams").WithArgs("pr1", "backend", sqlmock.AnyArg(), "u1").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("u3"))
+		mock.ExpectExec("delete from pr_reviewers").WithArgs("pr1", "u2").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("insert into pr_reviewers").WithArgs("pr1", "u3").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("insert into pr_reassignments").WithArgs("pr1", "u2", "u3").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery("select u.id from users u join user_teams").WithArgs("pr2", "backend", sqlmock.AnyArg(), "u1").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectExec("delete from pr_reviewers").WithArgs("pr2", "u2").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("delete from user_teams").WithArgs("backend", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery("from user_teams ut join users u").WithArgs("backend").
+			WillReturnRows(sqlmock.NewRows(memberCols).AddRow("u1", "Alice", true, "lead"))
+		mock.ExpectCommit()
+
+		res, err := repo.RemoveMembers(ctx, "backend", []string{"u2"})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if res.ReassignedReviews != 1 || res.RemovedReviews != 1 || len(res.Team.Members) != 1 {
+			t.Errorf("unexpected result: %+v", res)
+		}
+	})
+
+	t.Run("not a member", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("select name from team").WithArgs("backend").
+			WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("backend"))
+		mock.ExpectQuery("select count").WithArgs("backend", sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectRollback()
+
+		if _, err := repo.RemoveMembers(ctx, "backend", []string{"u9"}); !errors.Is(err, repository.ErrUserNotFound) {
+			t.Errorf("expected ErrUserNotFound, got %v", err)
+		}
+	})
+
+	t.Run("repeated user", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("select name from team").WithArgs("backend").
+			WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("backend"))
+		mock.ExpectQuery("select count").WithArgs("backend", pq.Array([]string{"u2"})).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery("select pr.id, pr.author_id, prr.reviewer_id").WithArgs("backend", sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "author_id", "reviewer_id"}))
+		mock.ExpectExec("delete from user_teams").WithArgs("backend", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery("from user_teams ut join users u").WithArgs("backend").
+			WillReturnRows(sqlmock.NewRows(memberCols).AddRow("u1", "Alice", true, "lead"))
+		mock.ExpectCommit()
+
+		if _, err := repo.RemoveMembers(ctx, "backend", []string{"u2", "u2"}); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUserRepository_RenameTeam(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("update team set name").WithArgs("backend", "platform").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("from user_teams ut join users u").WithArgs("platform").
+		WillReturnRows(sqlmock.NewRows(memberCols).AddRow("u1", "Alice", true, "lead"))
+	mock.ExpectCommit()
+
+	team, err := repo.RenameTeam(ctx, "backend", "platform")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if team.TeamName != "platform" || len(team.Members) != 1 {
+		t.Errorf("unexpected team: %+v", team)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("update team set name").WithArgs("backend", "frontend").
+		WillReturnError(&pq.Error{Code: "23505"})
+	mock.ExpectRollback()
+	if _, err := repo.RenameTeam(ctx, "backend", "frontend"); !errors.Is(err, repository.ErrTeamExists) {
+		t.Errorf("expected ErrTeamExists, got %v", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("update team set name").WithArgs("ghost", "platform").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+	if _, err := repo.RenameTeam(ctx, "ghost", "platform"); !errors.Is(err, repository.ErrTeamNotFound) {
+		t.Errorf("expected ErrTeamNotFound, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUserRepository_PatchTeam(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	t.Run("rename, add and remove", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("select name from team where name = .* for update").WithArgs("backend").
+			WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("backend"))
+		mock.ExpectExec("update team set name").WithArgs("backend", "platform").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery("select count.* from users").WithArgs(pq.Array([]string{"u3"})).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectExec("insert into user_teams").WithArgs("platform", pq.Array([]string{"u3"})).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery("select count.* from user_teams").WithArgs("platform", pq.Array([]string{"u2"})).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery("select pr.id, pr.author_id, prr.reviewer_id").WithArgs("platform", sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "author_id", "reviewer_id"}))
+		mock.ExpectExec("delete from user_teams").WithArgs("platform", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery("from user_teams ut join users u").WithArgs("platform").
+			WillReturnRows(sqlmock.NewRows(memberCols).
+				AddRow("u1", "Alice", true, "lead").
+				AddRow("u3", "Carol", true, "member"))
+		mock.ExpectCommit()
+
+		res, err := repo.PatchTeam(ctx, "backend", "platform", []string{"u3"}, []string{"u2"})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if res.Team.TeamName != "platform" || len(res.Team.Members) != 2 {
+			t.Errorf("unexpected result: %+v", res.Team)
+		}
+	})
+
+	t.Run("failed remove rolls back the rename", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("select name from team").WithArgs("backend").
+			WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("backend"))
+		mock.ExpectExec("update team set name").WithArgs("backend", "platform").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery("select count.* from user_teams").WithArgs("platform", pq.Array([]string{"u9"})).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectRollback()
+
+		_, err := repo.PatchTeam(ctx, "backend", "platform", nil, []string{"u9"})
+		if !errors.Is(err, repository.ErrUserNotFound) {
+			t.Errorf("expected ErrUserNotFound, got %v", err)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUserRepository_DeleteTeam(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	mock.ExpectExec("delete from team").WithArgs("backend").WillReturnResult(sqlmock.NewResult(0, 1))
+	if err := repo.DeleteTeam(ctx, "backend"); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+
+	mock.ExpectExec("delete from team").WithArgs("ghost").WillReturnResult(sqlmock.NewResult(0, 0))
+	if err := repo.DeleteTeam(ctx, "ghost"); !errors.Is(err, repository.ErrTeamNotFound) {
+		t.Errorf("expected ErrTeamNotFound, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUserRepository_SyncTeams(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	lead := api.Lead
+	teams := []api.Team{{
+		TeamName: "backend",
+		Members: []api.TeamMember{
+			{UserId: "u1", Username: "Alice", IsActive: true, Role: &lead},
+			{UserId: "u3", Username: "Carol", IsActive: false},
+			{UserId: "u4", Username: "Dan", IsActive: true},
+		},
+	}}
+
+	expectSync := func() {
+		mock.ExpectBegin()
+		mock.ExpectExec("insert into team .* on conflict do nothing").WithArgs("backend").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery("select name from team").WithArgs("backend").
+			WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("backend"))
+		mock.ExpectQuery("from user_teams ut join users u").WithArgs("backend").
+			WillReturnRows(sqlmock.NewRows(memberCols).
+				AddRow("u1", "Alice", true, "lead").
+				AddRow("u2", "Bob", true, "member").
+				AddRow("u3", "Carol", true, "member"))
+		mock.ExpectQuery("select id, is_active from users").WithArgs(sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "is_active"}).
+				AddRow("u1", true).
+				AddRow("u3", true))
+		mock.ExpectExec("(?i)INSERT INTO users").
+			WithArgs(pq.Array([]string{"u1", "u3", "u4"}), pq.Array([]string{"Alice", "Carol", "Dan"}), pq.Array([]bool{true, false, true})).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		for _, m := range teams[0].Members {
+			// Without a role the current one is kept, so leads are not demoted.
+			mock.ExpectExec("insert into user_teams .* do update set role = coalesce\\(\\$3, user_teams.role\\)").
+				WithArgs(m.UserId, "backend", m.Role).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+		}
+		mock.ExpectQuery("select pr.id, pr.author_id, prr.reviewer_id").WithArgs("backend", sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "author_id", "reviewer_id"}).AddRow("pr1", "u1", "u2"))
+		mock.ExpectQuery("select u.id from users u join user_teams").WithArgs("pr1", "backend", sqlmock.AnyArg(), "u1").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("u4"))
+		mock.ExpectExec("delete from pr_reviewers").WithArgs("pr1", "u2").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("insert into pr_reviewers").WithArgs("pr1", "u4").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("insert into pr_reassignments").WithArgs("pr1", "u2", "u4").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("delete from user_teams").WithArgs("backend", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+
+	check := func(t *testing.T, diffs []api.TeamSyncDiff) {
+		t.Helper()
+		if len(diffs) != 1 {
+			t.Fatalf("expected one diff, got %+v", diffs)
+		}
+		d := diffs[0]
+		if d.Created || len(d.Added) != 1 || d.Added[0] != "u4" ||
+			len(d.Removed) != 1 || d.Removed[0] != "u2" ||
+			len(d.Deactivated) != 1 || d.Deactivated[0] != "u3" ||
+			len(d.Activated) != 0 || d.ReassignedReviews != 1 {
+			t.Errorf("unexpected diff: %+v", d)
+		}
+	}
+
+	t.Run("apply", func(t *testing.T) {
+		expectSync()
+		mock.ExpectCommit()
+
+		diffs, err := repo.SyncTeams(ctx, teams, false)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		check(t, diffs)
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		expectSync()
+		mock.ExpectRollback()
+
+		diffs, err := repo.SyncTeams(ctx, teams, true)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		check(t, diffs)
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUserRepository_CreateTeam(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("insert into team").WithArgs("qa").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery("select count.* from users where id = any").WithArgs(pq.Array([]string{"u1"})).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectExec("insert into user_teams .* select id, \\$1, 'member' from users").
+			WithArgs("qa", pq.Array([]string{"u1"})).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery("from user_teams ut join users u").WithArgs("qa").
+			WillReturnRows(sqlmock.NewRows(memberCols).AddRow("u1", "Alice", true, "member"))
+		mock.ExpectCommit()
+
+		team, err := repo.CreateTeam(ctx, "qa", []string{"u1"})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(team.Members) != 1 {
+			t.Errorf("unexpected team: %+v", team)
+		}
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("insert into team").WithArgs("qa2").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery("select count.* from users where id = any").WithArgs(pq.Array([]string{"ghost"})).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectRollback()
+
+		if _, err := repo.CreateTeam(ctx, "qa2", []string{"ghost"}); !errors.Is(err, repository.ErrUserNotFound) {
+			t.Errorf("expected ErrUserNotFound, got %v", err)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUserRepository_ListTeams(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery("select name from team").WithArgs(nil, 2, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("backend").AddRow("empty"))
+	mock.ExpectQuery("select ut.team_name, u.id").WithArgs(pq.Array([]string{"backend", "empty"})).
+		WillReturnRows(sqlmock.NewRows([]string{"team_name", "id", "name", "is_active", "role"}).
+			AddRow("backend", "u1", "Alice", true, "lead").
+			AddRow("backend", "u2", "Bob", true, "member"))
+
+	teams, err := repo.ListTeams(context.Background(), repository.TeamFilter{Limit: 2})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(teams) != 2 || len(teams[0].Members) != 2 || len(teams[1].Members) != 0 {
+		t.Errorf("unexpected teams: %+v", teams)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
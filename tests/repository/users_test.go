@@ -0,0 +1,244 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/chimort/avito_test_task/iternal/repository"
+	"github.com/lib/pq"
+)
+
+var userCols = []string{"id", "name", "is_active", "deleted_at", "team_name"}
+
+func TestUserRepository_UpdateUser(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	name := "Alicia"
+	mock.ExpectBegin()
+	mock.ExpectExec("update users set name = coalesce").
+		WithArgs("u1", &name).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery("select .* from users u where u.id = ").
+		WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows(userCols).AddRow("u1", "Alicia", true, nil, "backend"))
+
+	user, err := repo.UpdateUser(ctx, "u1", &name, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if user.Username != "Alicia" || user.TeamName != "backend" {
+		t.Errorf("unexpected user: %+v", user)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("update users set name = coalesce").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+	if _, err := repo.UpdateUser(ctx, "gone", &name, nil); !errors.Is(err, repository.ErrUserNotFound) {
+		t.Errorf("expected ErrUserNotFound, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+// Deactivation through UpdateUser, as SCIM does it, must run the statement
+// of UpdateActive inside the same transaction as the rename.
+func TestUserRepository_UpdateUserActive(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	name, active := "Alicia", false
+	mock.ExpectBegin()
+	mock.ExpectExec("update users set name = coalesce").
+		WithArgs("u1", &name).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE users SET is_active = \\$1 WHERE id = \\$2 AND deleted_at IS NULL").
+		WithArgs(false, "u1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery("select .* from users u where u.id = ").
+		WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows(userCols).AddRow("u1", "Alicia", false, nil, "backend"))
+
+	user, err := repo.UpdateUser(ctx, "u1", &name, &active)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if user.IsActive {
+		t.Errorf("expected inactive user, got %+v", user)
+	}
+
+	t.Run("rename rolled back", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("update users set name = coalesce").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE users SET is_active").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+		if _, err := repo.UpdateUser(ctx, "u1", &name, &active); !errors.Is(err, repository.ErrUserNotFound) {
+			t.Errorf("expected ErrUserNotFound, got %v", err)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestUserRepository_ListUsers(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	team, after := "backend", "u1"
+	mock.ExpectQuery("from users u where .* order by u.id limit").
+		WithArgs(&team, nil, nil, false, &after, nil, 3, 0).
+		WillReturnRows(sqlmock.NewRows(userCols).
+			AddRow("u2", "Bob", true, nil, "backend").
+			AddRow("u3", "Carol", false, nil, "backend"))
+
+	users, err := repo.ListUsers(ctx, repository.UserFilter{TeamName: &team, Limit: 3, After: &after})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(users) != 2 || users[1].UserId != "u3" {
+		t.Errorf("unexpected users: %+v", users)
+	}
+}
+
+func TestUserRepository_CountUsers(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	id := "u1"
+	mock.ExpectQuery("select count.* from users u where").
+		WithArgs(nil, nil, nil, false, nil, &id).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	n, err := repo.CountUsers(context.Background(), repository.UserFilter{UserID: &id, Limit: 10, Offset: 5})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if n != 1 {
+		t.Errorf("expected 1, got %d", n)
+	}
+}
+
+func TestUserRepository_CreateUser(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	mock.ExpectExec("insert into users").WithArgs("u7", "Grace", true).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("select .* from users u where u.id = ").WithArgs("u7").
+		WillReturnRows(sqlmock.NewRows(userCols).AddRow("u7", "Grace", true, nil, ""))
+
+	user, err := repo.CreateUser(ctx, "u7", "Grace", true)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if user.UserId != "u7" || user.TeamName != "" {
+		t.Errorf("unexpected user: %+v", user)
+	}
+
+	mock.ExpectExec("insert into users").WithArgs("u7", "Grace", true).
+		WillReturnError(&pq.Error{Code: "23505"})
+	if _, err := repo.CreateUser(ctx, "u7", "Grace", true); !errors.Is(err, repository.ErrUserExists) {
+		t.Errorf("expected ErrUserExists, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUserRepository_DeleteUser(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	t.Run("block with activity", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("select deleted_at is not null from users").WithArgs("u1").
+			WillReturnRows(sqlmock.NewRows([]string{"deleted"}).AddRow(false))
+		mock.ExpectQuery("select exists").WithArgs("u1").
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectRollback()
+
+		_, err := repo.DeleteUser(ctx, "u1", repository.DeleteBlock)
+		if !errors.Is(err, repository.ErrUserHasActivity) {
+			t.Errorf("expected ErrUserHasActivity, got %v", err)
+		}
+	})
+
+	t.Run("block without activity", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("select deleted_at is not null from users").WithArgs("u9").
+			WillReturnRows(sqlmock.NewRows([]string{"deleted"}).AddRow(false))
+		mock.ExpectQuery("select exists").WithArgs("u9").
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		mock.ExpectExec("delete from users").WithArgs("u9").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		res, err := repo.DeleteUser(ctx, "u9", repository.DeleteBlock)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if res.Anonymized {
+			t.Errorf("expected the row to be deleted")
+		}
+	})
+
+	t.Run("reassign", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("select deleted_at is not null from users").WithArgs("u2").
+			WillReturnRows(sqlmock.NewRows([]string{"deleted"}).AddRow(false))
+		mock.ExpectQuery("select pr.id, pr.author_id from pull_requests pr").WithArgs("u2").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "author_id"}).AddRow("pr1", "u1").AddRow("pr2", "u1"))
+		mock.ExpectQuery("select u.id from users u join user_teams").WithArgs("pr1", "u2", "u1").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("u3"))
+		mock.ExpectExec("delete from pr_reviewers").WithArgs("pr1", "u2").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("insert into pr_reviewers").WithArgs("pr1", "u3").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("insert into pr_reassignments").WithArgs("pr1", "u2", "u3").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery("select u.id from users u join user_teams").WithArgs("pr2", "u2", "u1").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectExec("delete from pr_reviewers").WithArgs("pr2", "u2").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery("select exists").WithArgs("u2").
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectExec("update users set name").WithArgs("u2", repository.DeletedUserName).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("delete from user_teams").WithArgs("u2").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("delete from api_keys").WithArgs("u2").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		res, err := repo.DeleteUser(ctx, "u2", repository.DeleteReassign)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !res.Anonymized || res.ReassignedReviews != 1 || res.RemovedReviews != 1 {
+			t.Errorf("unexpected result: %+v", res)
+		}
+	})
+
+	t.Run("already deleted", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("select deleted_at is not null from users").WithArgs("u2").
+			WillReturnRows(sqlmock.NewRows([]string{"deleted"}).AddRow(true))
+		mock.ExpectRollback()
+
+		if _, err := repo.DeleteUser(ctx, "u2", repository.DeleteAnonymize); !errors.Is(err, repository.ErrUserNotFound) {
+			t.Errorf("expected ErrUserNotFound, got %v", err)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
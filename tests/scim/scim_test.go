@@ -0,0 +1,329 @@
+package scim_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/chimort/avito_test_task/iternal/auth"
+	"github.com/chimort/avito_test_task/iternal/pkg/logger"
+	"github.com/chimort/avito_test_task/iternal/repository"
+	"github.com/chimort/avito_test_task/iternal/scim"
+	"github.com/labstack/echo/v4"
+)
+
+// fakeService keeps users and teams in memory and records UpdateUser
+// calls.
+type fakeService struct {
+	users   map[string]*api.User
+	teams   map[string][]string
+	updates []string
+}
+
+func newFakeService() *fakeService {
+	return &fakeService{
+		users: map[string]*api.User{
+			"u1": {UserId: "u1", Username: "Alice", IsActive: true},
+			"u2": {UserId: "u2", Username: "Bob", IsActive: true},
+		},
+		teams: map[string][]string{"backend": {"u1"}},
+	}
+}
+
+func (f *fakeService) GetUser(ctx context.Context, userID string) (*api.User, error) {
+	u, ok := f.users[userID]
+	if !ok {
+		return nil, repository.ErrUserNotFound
+	}
+	cp := *u
+	return &cp, nil
+}
+
+func (f *fakeService) CreateUser(ctx context.Context, userID, username string, isActive bool) (*api.User, error) {
+	if _, ok := f.users[userID]; ok {
+		return nil, repository.ErrUserExists
+	}
+	f.users[userID] = &api.User{UserId: userID, Username: username, IsActive: isActive}
+	return f.GetUser(ctx, userID)
+}
+
+func (f *fakeService) UpdateUser(ctx context.Context, userID string, username *string, isActive *bool) (*api.User, error) {
+	f.updates = append(f.updates, userID)
+	u, ok := f.users[userID]
+	if !ok {
+		return nil, repository.ErrUserNotFound
+	}
+	if username != nil {
+		u.Username = *username
+	}
+	if isActive != nil {
+		u.IsActive = *isActive
+	}
+	return f.GetUser(ctx, userID)
+}
+
+func (f *fakeService) DeleteUser(ctx context.Context, userID string, mode api.UserDeleteMode) (*api.UserDeletion, error) {
+	if _, ok := f.users[userID]; !ok {
+		return nil, repository.ErrUserNotFound
+	}
+	delete(f.users, userID)
+	return &api.UserDeletion{UserId: userID, Mode: mode}, nil
+}
+
+func (f *fakeService) FindUsers(ctx context.Context, filter repository.UserFilter) ([]api.User, int, error) {
+	var ids []string
+	for id, u := range f.users {
+		if filter.UserID != nil && id != *filter.UserID {
+			continue
+		}
+		if filter.IsActive != nil && u.IsActive != *filter.IsActive {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	users := []api.User{}
+	for i, id := range ids {
+		if i >= filter.Offset && len(users) < filter.Limit {
+			users = append(users, *f.users[id])
+		}
+	}
+	return users, len(ids), nil
+}
+
+func (f *fakeService) team(name string) *api.Team {
+	team := &api.Team{TeamName: name, Members: []api.TeamMember{}}
+	for _, id := range f.teams[name] {
+		u := f.users[id]
+		team.Members = append(team.Members, api.TeamMember{UserId: id, Username: u.Username, IsActive: u.IsActive})
+	}
+	return team
+}
+
+func (f *fakeService) CreateTeam(ctx context.Context, teamName string, userIDs []string) (*api.Team, error) {
+	if _, ok := f.teams[teamName]; ok {
+		return nil, repository.ErrTeamExists
+	}
+	f.teams[teamName] = nil
+	return f.AddTeamUsers(ctx, teamName, userIDs)
+}
+
+func (f *fakeService) AddTeamUsers(ctx context.Context, teamName string, userIDs []string) (*api.Team, error) {
+	if _, ok := f.teams[teamName]; !ok {
+		return nil, repository.ErrTeamNotFound
+	}
+	for _, id := range userIDs {
+		if _, ok := f.users[id]; !ok {
+			return nil, repository.ErrUserNotFound
+		}
+	}
+	f.teams[teamName] = append(f.teams[teamName], userIDs...)
+	return f.team(teamName), nil
+}
+
+// PatchTeam checks every change before making any, as the repository's
+// single transaction does.
+func (f *fakeService) PatchTeam(ctx context.Context, teamName, newTeamName string, add, remove []string) (*repository.MembersRemoval, error) {
+	members, ok := f.teams[teamName]
+	if !ok {
+		return nil, repository.ErrTeamNotFound
+	}
+	if _, ok := f.teams[newTeamName]; ok && newTeamName != teamName {
+		return nil, repository.ErrTeamExists
+	}
+	for _, id := range add {
+		if _, ok := f.users[id]; !ok {
+			return nil, repository.ErrUserNotFound
+		}
+	}
+	for _, id := range remove {
+		if !contains(members, id) {
+			return nil, repository.ErrUserNotFound
+		}
+	}
+
+	var kept []string
+	for _, id := range append(members, add...) {
+		if !contains(remove, id) {
+			kept = append(kept, id)
+		}
+	}
+	delete(f.teams, teamName)
+	f.teams[newTeamName] = kept
+	return &repository.MembersRemoval{Team: f.team(newTeamName)}, nil
+}
+
+func (f *fakeService) DeleteTeam(ctx context.Context, teamName string) error {
+	if _, ok := f.teams[teamName]; !ok {
+		return repository.ErrTeamNotFound
+	}
+	delete(f.teams, teamName)
+	return nil
+}
+
+func (f *fakeService) FindTeams(ctx context.Context, filter repository.TeamFilter) ([]api.Team, int, error) {
+	var names []string
+	for name := range f.teams {
+		if filter.Name == nil || name == *filter.Name {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	teams := []api.Team{}
+	for i, name := range names {
+		if i >= filter.Offset && len(teams) < filter.Limit {
+			teams = append(teams, *f.team(name))
+		}
+	}
+	return teams, len(names), nil
+}
+
+func contains(ids []string, id string) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+func setup() (*echo.Echo, *fakeService) {
+	e := echo.New()
+	svc := newFakeService()
+	scim.NewHandler(svc, logger.NewLogger("app", logger.LevelInfo)).Register(e)
+	return e, svc
+}
+
+func do(e *echo.Echo, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, scim.MediaType)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestUsers(t *testing.T) {
+	e, svc := setup()
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		code   int
+		want   string
+	}{
+		{"create", http.MethodPost, "/scim/v2/Users", `{"schemas":["urn:ietf:params:scim:schemas:core:2.0:User"],"userName":"u3","name":{"givenName":"Carol","familyName":"Smith"}}`, http.StatusCreated, `"displayName":"Carol Smith"`},
+		{"create duplicate", http.MethodPost, "/scim/v2/Users", `{"userName":"u1"}`, http.StatusConflict, `"scimType":"uniqueness"`},
+		{"create without userName", http.MethodPost, "/scim/v2/Users", `{"displayName":"Nobody"}`, http.StatusBadRequest, "invalidValue"},
+		{"get", http.MethodGet, "/scim/v2/Users/u1", "", http.StatusOK, `"userName":"u1"`},
+		{"get missing", http.MethodGet, "/scim/v2/Users/ghost", "", http.StatusNotFound, `"status":"404"`},
+		{"filter", http.MethodGet, `/scim/v2/Users?filter=userName+eq+%22u2%22`, "", http.StatusOK, `"totalResults":1`},
+		{"page", http.MethodGet, "/scim/v2/Users?startIndex=2&count=1", "", http.StatusOK, `"startIndex":2,"itemsPerPage":1`},
+		{"bad filter", http.MethodGet, `/scim/v2/Users?filter=emails+co+%22x%22`, "", http.StatusBadRequest, "invalidFilter"},
+		{"deactivate", http.MethodPatch, "/scim/v2/Users/u2", `{"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],"Operations":[{"op":"Replace","path":"active","value":"False"}]}`, http.StatusOK, `"active":false`},
+		{"rename", http.MethodPatch, "/scim/v2/Users/u1", `{"Operations":[{"op":"replace","value":{"displayName":"Alicia"}}]}`, http.StatusOK, `"displayName":"Alicia"`},
+		{"rename and reactivate", http.MethodPatch, "/scim/v2/Users/u2", `{"Operations":[{"op":"replace","path":"displayName","value":"Robert"},{"op":"replace","path":"active","value":true}]}`, http.StatusOK, `"displayName":"Robert"`},
+		{"patch unknown attribute", http.MethodPatch, "/scim/v2/Users/u1", `{"Operations":[{"op":"replace","path":"emails","value":[]}]}`, http.StatusBadRequest, "invalidPath"},
+		{"patch missing", http.MethodPatch, "/scim/v2/Users/ghost", `{"Operations":[{"op":"replace","path":"active","value":false}]}`, http.StatusNotFound, ""},
+		{"delete", http.MethodDelete, "/scim/v2/Users/u3", "", http.StatusNoContent, ""},
+		{"delete missing", http.MethodDelete, "/scim/v2/Users/u3", "", http.StatusNotFound, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := do(e, tt.method, tt.path, tt.body)
+			if rec.Code != tt.code {
+				t.Fatalf("expected %d, got %d: %s", tt.code, rec.Code, rec.Body.String())
+			}
+			if !strings.Contains(rec.Body.String(), tt.want) {
+				t.Errorf("expected %q in body: %s", tt.want, rec.Body.String())
+			}
+		})
+	}
+
+	// Each PATCH, however many operations it has, is a single update.
+	if want := []string{"u2", "u1", "u2", "ghost"}; strings.Join(svc.updates, ",") != strings.Join(want, ",") {
+		t.Errorf("expected updates %v, got %v", want, svc.updates)
+	}
+}
+
+func TestGroups(t *testing.T) {
+	e, svc := setup()
+
+	rec := do(e, http.MethodPost, "/scim/v2/Groups", `{"displayName":"qa","members":[{"value":"u2"}]}`)
+	if rec.Code != http.StatusCreated || rec.Header().Get(echo.HeaderLocation) != "/scim/v2/Groups/qa" {
+		t.Fatalf("unexpected create response %d: %s", rec.Code, rec.Body.String())
+	}
+	if rec := do(e, http.MethodPost, "/scim/v2/Groups", `{"displayName":"qa"}`); rec.Code != http.StatusConflict {
+		t.Errorf("expected 409 for a duplicate group, got %d", rec.Code)
+	}
+	if rec := do(e, http.MethodPost, "/scim/v2/Groups", `{"displayName":"ops","members":[{"value":"ghost"}]}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unknown member, got %d", rec.Code)
+	}
+
+	// A PATCH that fails partway changes nothing.
+	rec = do(e, http.MethodPatch, "/scim/v2/Groups/backend", `{"Operations":[
+		{"op":"replace","path":"displayName","value":"platform"},
+		{"op":"add","path":"members","value":[{"value":"ghost"}]}]}`)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for an unknown member, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if members, ok := svc.teams["backend"]; !ok || len(members) != 1 || members[0] != "u1" {
+		t.Errorf("expected backend to be unchanged, got %v", svc.teams)
+	}
+
+	rec = do(e, http.MethodPatch, "/scim/v2/Groups/backend", `{"Operations":[
+		{"op":"add","path":"members","value":[{"value":"u2"}]},
+		{"op":"remove","path":"members[value eq \"u1\"]"},
+		{"op":"replace","path":"displayName","value":"platform"}]}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var group scim.Group
+	if err := json.Unmarshal(rec.Body.Bytes(), &group); err != nil {
+		t.Fatal(err)
+	}
+	if group.ID != "platform" || len(group.Members) != 1 || group.Members[0].Value != "u2" {
+		t.Errorf("unexpected group: %+v", group)
+	}
+	if _, ok := svc.teams["backend"]; ok {
+		t.Error("expected backend to be renamed")
+	}
+
+	rec = do(e, http.MethodGet, `/scim/v2/Groups?filter=displayName+eq+%22platform%22`, "")
+	var list scim.ListResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &list); err != nil {
+		t.Fatal(err)
+	}
+	if list.TotalResults != 1 {
+		t.Errorf("expected one group, got %+v", list)
+	}
+
+	if rec := do(e, http.MethodDelete, "/scim/v2/Groups/platform", ""); rec.Code != http.StatusNoContent {
+		t.Errorf("expected 204, got %d", rec.Code)
+	}
+	if rec := do(e, http.MethodGet, "/scim/v2/Groups/platform", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404 after delete, got %d", rec.Code)
+	}
+}
+
+func TestTeamScopedKeyRejected(t *testing.T) {
+	e, _ := setup()
+
+	req := httptest.NewRequest(http.MethodGet, "/scim/v2/Users", nil)
+	p := &auth.Principal{Name: "key", Role: auth.RoleAdmin, Team: "backend"}
+	req = req.WithContext(auth.WithPrincipal(req.Context(), p))
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("expected 403, got %d", rec.Code)
+	}
+	if ct := rec.Header().Get(echo.HeaderContentType); ct != scim.MediaType {
+		t.Errorf("expected %s, got %s", scim.MediaType, ct)
+	}
+}
@@ -0,0 +1,104 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/chimort/avito_test_task/iternal/auth"
+	"github.com/chimort/avito_test_task/iternal/pkg/logger"
+	"github.com/chimort/avito_test_task/iternal/repository"
+	"github.com/chimort/avito_test_task/iternal/service"
+)
+
+type mockAPIKeyRepo struct {
+	keys map[string]api.ApiKey
+	err  error
+}
+
+func (m *mockAPIKeyRepo) CreateAPIKey(ctx context.Context, key api.ApiKey, keyHash string) (*api.ApiKey, error) {
+	key.Id = int64(len(m.keys) + 1)
+	key.CreatedAt = now
+	m.keys[keyHash] = key
+	return &key, nil
+}
+
+func (m *mockAPIKeyRepo) ListAPIKeys(ctx context.Context, teamName *string) ([]api.ApiKey, error) {
+	var keys []api.ApiKey
+	for _, k := range m.keys {
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
+
+func (m *mockAPIKeyRepo) RevokeAPIKey(ctx context.Context, id int64, teamName *string) (*api.ApiKey, error) {
+	for hash, k := range m.keys {
+		if k.Id == id {
+			delete(m.keys, hash)
+			k.RevokedAt = &now
+			return &k, nil
+		}
+	}
+	return nil, repository.ErrAPIKeyNotFound
+}
+
+func (m *mockAPIKeyRepo) UseAPIKey(ctx context.Context, keyHash string) (*api.ApiKey, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	k, ok := m.keys[keyHash]
+	if !ok {
+		return nil, repository.ErrAPIKeyNotFound
+	}
+	return &k, nil
+}
+
+func TestAPIKeyService_CreateAndAuthenticate(t *testing.T) {
+	repo := &mockAPIKeyRepo{keys: map[string]api.ApiKey{}}
+	svc := service.NewAPIKeyService(repo, logger.NewLogger("app", logger.LevelInfo))
+	ctx := context.Background()
+
+	team := "payments"
+	key, token, err := svc.CreateAPIKey(ctx, api.ApiKey{Name: "ci", Role: api.ApiKeyRoleAdmin, TeamName: &team})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(token, "prs_") {
+		t.Errorf("expected prs_ prefix, got %s", token)
+	}
+	if _, stored := repo.keys[token]; stored {
+		t.Errorf("token must be stored hashed")
+	}
+
+	p, err := svc.Authenticate(ctx, token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Name != "ci" || !p.IsAdmin() || p.Team != "payments" {
+		t.Errorf("unexpected principal: %+v", p)
+	}
+
+	if _, err := svc.RevokeAPIKey(ctx, key.Id, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.Authenticate(ctx, token); !errors.Is(err, auth.ErrInvalidToken) {
+		t.Errorf("expected ErrInvalidToken after revoke, got %v", err)
+	}
+}
+
+func TestAPIKeyService_Authenticate(t *testing.T) {
+	repo := &mockAPIKeyRepo{keys: map[string]api.ApiKey{}}
+	svc := service.NewAPIKeyService(repo, logger.NewLogger("app", logger.LevelInfo))
+	ctx := context.Background()
+
+	if _, err := svc.Authenticate(ctx, "static-token"); !errors.Is(err, auth.ErrInvalidToken) {
+		t.Errorf("expected ErrInvalidToken for foreign token, got %v", err)
+	}
+
+	repo.err = errors.New("db error")
+	if _, err := svc.Authenticate(ctx, "prs_abc"); err == nil || errors.Is(err, auth.ErrInvalidToken) {
+		t.Errorf("expected db error, got %v", err)
+	}
+}
@@ -2,12 +2,13 @@ package service_test
 
 import (
 	"context"
-	"database/sql"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/chimort/avito_test_task/iternal/config"
 	"github.com/chimort/avito_test_task/iternal/pkg/logger"
 	"github.com/chimort/avito_test_task/iternal/repository"
 	"github.com/chimort/avito_test_task/iternal/service"
@@ -19,7 +20,7 @@ type mockRepo struct{}
 
 func (m *mockRepo) UpdateActive(ctx context.Context, userID string, isActive bool) (*api.User, error) {
 	if userID == "notfound" {
-		return nil, sql.ErrNoRows
+		return nil, repository.ErrUserNotFound
 	}
 	return &api.User{UserId: userID, Username: "test", IsActive: isActive}, nil
 }
@@ -31,6 +32,76 @@ func (m *mockRepo) TeamAdd(ctx context.Context, teamName string, members []api.T
 	return &api.Team{TeamName: teamName, Members: members}, nil
 }
 
+func (m *mockRepo) AddMembers(ctx context.Context, teamName string, members []api.TeamMember) (*api.Team, error) {
+	return &api.Team{TeamName: teamName, Members: members}, nil
+}
+
+func (m *mockRepo) RemoveMembers(ctx context.Context, teamName string, userIDs []string) (*repository.MembersRemoval, error) {
+	if teamName == "notfound" {
+		return nil, repository.ErrTeamNotFound
+	}
+	return &repository.MembersRemoval{Team: &api.Team{TeamName: teamName}, RemovedReviews: len(userIDs)}, nil
+}
+
+func (m *mockRepo) RenameTeam(ctx context.Context, teamName, newTeamName string) (*api.Team, error) {
+	return &api.Team{TeamName: newTeamName}, nil
+}
+
+func (m *mockRepo) DeleteTeam(ctx context.Context, teamName string) error {
+	return nil
+}
+
+func (m *mockRepo) SyncTeams(ctx context.Context, teams []api.Team, dryRun bool) ([]api.TeamSyncDiff, error) {
+	return []api.TeamSyncDiff{}, nil
+}
+
+func (m *mockRepo) ImportTeams(ctx context.Context, teams []api.Team) (*api.ImportResult, error) {
+	return &api.ImportResult{Teams: len(teams)}, nil
+}
+
+func (m *mockRepo) CreateTeam(ctx context.Context, teamName string, userIDs []string) (*api.Team, error) {
+	return &api.Team{TeamName: teamName}, nil
+}
+
+func (m *mockRepo) AddTeamUsers(ctx context.Context, teamName string, userIDs []string) (*api.Team, error) {
+	return &api.Team{TeamName: teamName}, nil
+}
+
+func (m *mockRepo) PatchTeam(ctx context.Context, teamName, newTeamName string, add, remove []string) (*repository.MembersRemoval, error) {
+	return &repository.MembersRemoval{Team: &api.Team{TeamName: newTeamName}}, nil
+}
+
+func (m *mockRepo) ListTeams(ctx context.Context, filter repository.TeamFilter) ([]api.Team, error) {
+	return []api.Team{{TeamName: "backend"}}, nil
+}
+
+func (m *mockRepo) CountTeams(ctx context.Context, filter repository.TeamFilter) (int, error) {
+	return 1, nil
+}
+
+func (m *mockRepo) CountUsers(ctx context.Context, filter repository.UserFilter) (int, error) {
+	return 42, nil
+}
+
+func (m *mockRepo) CreateUser(ctx context.Context, userID, username string, isActive bool) (*api.User, error) {
+	if userID == "existing" {
+		return nil, repository.ErrUserExists
+	}
+	return &api.User{UserId: userID, Username: username, IsActive: isActive}, nil
+}
+
+func (m *mockRepo) Export(ctx context.Context, from, to *time.Time, sink repository.ExportSink) error {
+	return nil
+}
+
+func (m *mockRepo) Backup(ctx context.Context, sink repository.BackupSink) error {
+	return sink.Begin(10)
+}
+
+func (m *mockRepo) Restore(ctx context.Context, src repository.RestoreSource) (map[string]int, error) {
+	return nil, repository.ErrDatabaseNotEmpty
+}
+
 func (m *mockRepo) GetTeam(ctx context.Context, teamName string) (*api.Team, error) {
 	if teamName == "notfound" {
 		return nil, repository.ErrTeamNotFound
@@ -44,7 +115,7 @@ func (m *mockRepo) GetTeam(ctx context.Context, teamName string) (*api.Team, err
 	}, nil
 }
 
-func (m *mockRepo) PullRequestCreate(ctx context.Context, prID, prName, authorID string) (*api.PullRequest, error) {
+func (m *mockRepo) PullRequestCreate(ctx context.Context, prID, prName, authorID string, reviewersCount int) (*api.PullRequest, error) {
 	if prID == "pr-existing" {
 		return nil, repository.ErrPRExists
 	}
@@ -57,9 +128,9 @@ func (m *mockRepo) PullRequestCreate(ctx context.Context, prID, prName, authorID
 	}, nil
 }
 
-func (m *mockRepo) PullRequestMerge(ctx context.Context, prID string) (*api.PullRequest, error) {
+func (m *mockRepo) PullRequestMerge(ctx context.Context, prID string) (*api.PullRequest, bool, error) {
 	if prID == "pr-notfound" {
-		return nil, repository.ErrPRNotFound
+		return nil, false, repository.ErrPRNotFound
 	}
 	t := now
 	return &api.PullRequest{
@@ -67,10 +138,13 @@ func (m *mockRepo) PullRequestMerge(ctx context.Context, prID string) (*api.Pull
 		Status:        "merged",
 		CreatedAt:     &t,
 		MergedAt:      &t,
-	}, nil
+	}, true, nil
 }
 
-func (m *mockRepo) PullRequestReassign(ctx context.Context, prID, oldUserID string) (*api.PullRequest, string, error) {
+func (m *mockRepo) PullRequestReassign(ctx context.Context, prID, oldUserID string, maxPerHour int) (*api.PullRequest, string, error) {
+	if prID == "pr-limited" && maxPerHour > 0 {
+		return nil, "", &repository.ReassignLimitError{RetryAfter: 30 * time.Minute}
+	}
 	switch prID {
 	case "pr-notfound":
 		return nil, "", repository.ErrPRNotFound
@@ -89,18 +163,127 @@ func (m *mockRepo) PullRequestReassign(ctx context.Context, prID, oldUserID stri
 	}, "u5", nil
 }
 
-func (m *mockRepo) GetPRsByReviewer(ctx context.Context, reviewerID string) ([]*api.PullRequestShort, error) {
+func (m *mockRepo) GetPullRequest(ctx context.Context, prID string) (*api.PullRequest, error) {
+	if prID == "pr-notfound" {
+		return nil, repository.ErrPRNotFound
+	}
+	return &api.PullRequest{PullRequestId: prID, Status: "OPEN", CreatedAt: &now}, nil
+}
+
+func (m *mockRepo) ListPullRequests(ctx context.Context, filter repository.PullRequestFilter) ([]api.PullRequest, error) {
+	prs := make([]api.PullRequest, 0, filter.Limit)
+	for i := 0; i < 3 && i < filter.Limit; i++ {
+		createdAt := now.Add(-time.Duration(i) * time.Hour)
+		prs = append(prs, api.PullRequest{PullRequestId: fmt.Sprintf("pr-%d", i), Status: "OPEN", CreatedAt: &createdAt})
+	}
+	return prs, nil
+}
+
+func (m *mockRepo) GetUser(ctx context.Context, userID string) (*api.User, error) {
+	if userID == "notfound" {
+		return nil, repository.ErrUserNotFound
+	}
+	return &api.User{UserId: userID, Username: "test", IsActive: true}, nil
+}
+
+func (m *mockRepo) UpdateUser(ctx context.Context, userID string, username *string, isActive *bool) (*api.User, error) {
+	return m.GetUser(ctx, userID)
+}
+
+func (m *mockRepo) ListUsers(ctx context.Context, filter repository.UserFilter) ([]api.User, error) {
+	users := []api.User{}
+	for _, id := range []string{"u1", "u2", "u3"} {
+		if filter.After != nil && id <= *filter.After {
+			continue
+		}
+		if len(users) == filter.Limit {
+			break
+		}
+		users = append(users, api.User{UserId: id})
+	}
+	return users, nil
+}
+
+func (m *mockRepo) DeleteUser(ctx context.Context, userID string, mode repository.DeleteMode) (*repository.UserDeletion, error) {
+	if userID == "busy" && mode == repository.DeleteBlock {
+		return nil, repository.ErrUserHasActivity
+	}
+	return &repository.UserDeletion{Anonymized: true, ReassignedReviews: 2}, nil
+}
+
+func (m *mockRepo) GetPRsByReviewer(ctx context.Context, reviewerID string, filter repository.ReviewFilter) ([]api.ReviewAssignment, error) {
 	if reviewerID == "empty" {
-		return []*api.PullRequestShort{}, nil
+		return []api.ReviewAssignment{}, nil
+	}
+	prs := []api.ReviewAssignment{
+		{PullRequestId: "pr-1", PullRequestName: "Fix bug", AuthorId: "u1", Status: "OPEN", CreatedAt: now.Add(-2 * time.Hour)},
+		{PullRequestId: "pr-2", PullRequestName: "Add feature", AuthorId: "u2", Status: "OPEN", CreatedAt: now.Add(-time.Hour)},
+		{PullRequestId: "pr-3", PullRequestName: "Refactor", AuthorId: "u2", Status: "MERGED", CreatedAt: now},
+	}
+	if filter.After != nil {
+		for i, pr := range prs {
+			if pr.PullRequestId == filter.After.ID {
+				prs = prs[i+1:]
+				break
+			}
+		}
+	}
+	if len(prs) > filter.Limit {
+		prs = prs[:filter.Limit]
+	}
+	return prs, nil
+}
+
+func (m *mockRepo) GetReviewerStats(ctx context.Context, from, to *time.Time) ([]api.ReviewerStats, error) {
+	if from != nil && from.After(now) {
+		return []api.ReviewerStats{}, nil
+	}
+	return []api.ReviewerStats{
+		{UserId: "u1", Username: "Alice", Assigned: 2, Open: 1, Merged: 1},
+		{UserId: "u2", Username: "Bob", Assigned: 1, Merged: 1, ReassignedIn: 1},
+	}, nil
+}
+
+func (m *mockRepo) GetTeamStats(ctx context.Context, from, to *time.Time) ([]api.TeamStats, error) {
+	return []api.TeamStats{{TeamName: "backend", Assigned: 3, Open: 1, Merged: 2, ReassignedIn: 1}}, nil
+}
+
+func (m *mockRepo) GetCycleTimeReport(ctx context.Context, from, to *time.Time) (*api.CycleTimeReport, error) {
+	if to != nil && to.Before(now) {
+		return nil, errors.New("db error")
 	}
-	return []*api.PullRequestShort{
-		{PullRequestId: "pr-1", PullRequestName: "Fix bug", AuthorId: "u1", Status: "open"},
-		{PullRequestId: "pr-2", PullRequestName: "Add feature", AuthorId: "u2", Status: "open"},
+	return &api.CycleTimeReport{
+		ByTeam: []api.CycleTimeStats{{Key: "backend", Count: 1, P50Seconds: 60, P90Seconds: 60, P99Seconds: 60}},
+		Weekly: []api.CycleTimeBucket{{WeekStart: now, Count: 1, P50Seconds: 60, P90Seconds: 60, P99Seconds: 60}},
 	}, nil
 }
 
+func (m *mockRepo) UserInTeam(ctx context.Context, userID, teamName string) (bool, error) {
+	return userID == "u1" && teamName == "backend", nil
+}
+
+func (m *mockRepo) PullRequestInTeam(ctx context.Context, prID, teamName string) (bool, error) {
+	return prID == "pr1" && teamName == "backend", nil
+}
+
+func (m *mockRepo) IsTeamLead(ctx context.Context, userID, teamName string) (bool, error) {
+	return userID == "u1" && teamName == "backend", nil
+}
+
+func (m *mockRepo) UsersOutsideTeam(ctx context.Context, teamName string, userIDs []string) ([]string, error) {
+	return nil, nil
+}
+
+func (m *mockRepo) LeadsUser(ctx context.Context, leadID, userID string) (bool, error) {
+	return leadID == "u1", nil
+}
+
+func (m *mockRepo) LeadsPullRequest(ctx context.Context, leadID, prID string) (bool, error) {
+	return leadID == "u1" && prID == "pr1", nil
+}
+
 func TestUserService_SetIsActive(t *testing.T) {
-	svc := service.NewUserService(&mockRepo{}, logger.NewLogger("app", logger.LevelInfo))
+	svc := service.NewUserService(&mockRepo{}, config.Default().Assignment, logger.NewLogger("app", logger.LevelInfo))
 	user, err := svc.SetIsActive(context.Background(), "123", true)
 	if err != nil {
 		t.Fatal(err)
@@ -115,7 +298,7 @@ func TestUserService_SetIsActive(t *testing.T) {
 }
 
 func TestUserService_TeamAdd(t *testing.T) {
-	svc := service.NewUserService(&mockRepo{}, logger.NewLogger("app", logger.LevelInfo))
+	svc := service.NewUserService(&mockRepo{}, config.Default().Assignment, logger.NewLogger("app", logger.LevelInfo))
 	members := []api.TeamMember{
 		{UserId: "u1", Username: "Alice", IsActive: true},
 		{UserId: "u2", Username: "Bob", IsActive: true},
@@ -134,7 +317,7 @@ func TestUserService_TeamAdd(t *testing.T) {
 }
 
 func TestUserService_GetTeam(t *testing.T) {
-	svc := service.NewUserService(&mockRepo{}, logger.NewLogger("app", logger.LevelInfo))
+	svc := service.NewUserService(&mockRepo{}, config.Default().Assignment, logger.NewLogger("app", logger.LevelInfo))
 	team, err := svc.GetTeam(context.Background(), "backend")
 	if err != nil {
 		t.Fatal(err)
@@ -149,7 +332,7 @@ func TestUserService_GetTeam(t *testing.T) {
 }
 
 func TestUserService_PullRequestCreate(t *testing.T) {
-	svc := service.NewUserService(&mockRepo{}, logger.NewLogger("app", logger.LevelInfo))
+	svc := service.NewUserService(&mockRepo{}, config.Default().Assignment, logger.NewLogger("app", logger.LevelInfo))
 	pr, err := svc.PullRequestCreate(context.Background(), "pr-new", "Test PR", "u1")
 	if err != nil {
 		t.Fatal(err)
@@ -164,7 +347,7 @@ func TestUserService_PullRequestCreate(t *testing.T) {
 }
 
 func TestUserService_PullRequestMerge(t *testing.T) {
-	svc := service.NewUserService(&mockRepo{}, logger.NewLogger("app", logger.LevelInfo))
+	svc := service.NewUserService(&mockRepo{}, config.Default().Assignment, logger.NewLogger("app", logger.LevelInfo))
 	pr, err := svc.PullRequestMerge(context.Background(), "pr-1")
 	if err != nil {
 		t.Fatal(err)
@@ -179,7 +362,7 @@ func TestUserService_PullRequestMerge(t *testing.T) {
 }
 
 func TestUserService_PullRequestReassign(t *testing.T) {
-	svc := service.NewUserService(&mockRepo{}, logger.NewLogger("app", logger.LevelInfo))
+	svc := service.NewUserService(&mockRepo{}, config.Default().Assignment, logger.NewLogger("app", logger.LevelInfo))
 	_, newUser, err := svc.PullRequestReassign(context.Background(), "pr-1", "u1")
 	if err != nil {
 		t.Fatal(err)
@@ -193,16 +376,35 @@ func TestUserService_PullRequestReassign(t *testing.T) {
 	}
 }
 
+func TestUserService_PullRequestReassign_Limit(t *testing.T) {
+	svc := service.NewUserService(&mockRepo{}, config.Default().Assignment, logger.NewLogger("app", logger.LevelInfo))
+	_, _, err := svc.PullRequestReassign(context.Background(), "pr-limited", "u1")
+	var limitErr *repository.ReassignLimitError
+	if !errors.As(err, &limitErr) {
+		t.Fatalf("expected ReassignLimitError, got %v", err)
+	}
+	if limitErr.RetryAfter <= 0 || limitErr.RetryAfter > 30*time.Minute {
+		t.Errorf("unexpected retry after %v", limitErr.RetryAfter)
+	}
+
+	assignment := config.Default().Assignment
+	assignment.MaxReassignmentsPerHour = 0
+	svc = service.NewUserService(&mockRepo{}, assignment, logger.NewLogger("app", logger.LevelInfo))
+	if _, _, err := svc.PullRequestReassign(context.Background(), "pr-limited", "u1"); err != nil {
+		t.Errorf("expected no limit, got %v", err)
+	}
+}
+
 func TestUserService_GetPRsByReviewer(t *testing.T) {
-	svc := service.NewUserService(&mockRepo{}, logger.NewLogger("app", logger.LevelInfo))
-	prs, err := svc.GetPRsByReviewer(context.Background(), "u3")
+	svc := service.NewUserService(&mockRepo{}, config.Default().Assignment, logger.NewLogger("app", logger.LevelInfo))
+	prs, next, err := svc.GetPRsByReviewer(context.Background(), "u3", api.GetUsersGetReviewParams{})
 	if err != nil {
 		t.Fatal(err)
 	}
-	if len(prs) != 2 {
-		t.Errorf("expected 2 PRs")
+	if len(prs) != 3 || next != "" {
+		t.Errorf("expected 3 PRs on a single page, got %d, next %q", len(prs), next)
 	}
-	prs, err = svc.GetPRsByReviewer(context.Background(), "empty")
+	prs, _, err = svc.GetPRsByReviewer(context.Background(), "empty", api.GetUsersGetReviewParams{})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -210,3 +412,176 @@ func TestUserService_GetPRsByReviewer(t *testing.T) {
 		t.Errorf("expected 0 PRs")
 	}
 }
+
+func TestUserService_GetPRsByReviewer_Pages(t *testing.T) {
+	svc := service.NewUserService(&mockRepo{}, config.Default().Assignment, logger.NewLogger("app", logger.LevelInfo))
+	limit := 2
+	params := api.GetUsersGetReviewParams{Limit: &limit}
+
+	prs, next, err := svc.GetPRsByReviewer(context.Background(), "u3", params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prs) != 2 || next == "" {
+		t.Fatalf("expected a full first page with a cursor, got %d, next %q", len(prs), next)
+	}
+
+	params.Cursor = &next
+	prs, next, err = svc.GetPRsByReviewer(context.Background(), "u3", params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prs) != 1 || prs[0].PullRequestId != "pr-3" || next != "" {
+		t.Errorf("unexpected last page %+v, next %q", prs, next)
+	}
+
+	bad := "not-a-cursor"
+	params.Cursor = &bad
+	if _, _, err := svc.GetPRsByReviewer(context.Background(), "u3", params); !errors.Is(err, service.ErrInvalidCursor) {
+		t.Errorf("expected ErrInvalidCursor, got %v", err)
+	}
+}
+
+func TestUserService_GetPullRequest(t *testing.T) {
+	svc := service.NewUserService(&mockRepo{}, config.Default().Assignment, logger.NewLogger("app", logger.LevelInfo))
+	pr, err := svc.GetPullRequest(context.Background(), "pr-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pr.PullRequestId != "pr-1" {
+		t.Errorf("unexpected PR %+v", pr)
+	}
+	if _, err := svc.GetPullRequest(context.Background(), "pr-notfound"); !errors.Is(err, repository.ErrPRNotFound) {
+		t.Errorf("expected ErrPRNotFound, got %v", err)
+	}
+}
+
+func TestUserService_ListPullRequests(t *testing.T) {
+	svc := service.NewUserService(&mockRepo{}, config.Default().Assignment, logger.NewLogger("app", logger.LevelInfo))
+	limit := 2
+	prs, next, err := svc.ListPullRequests(context.Background(), api.GetPullRequestListParams{Limit: &limit})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prs) != 2 || next == "" {
+		t.Errorf("expected 2 PRs and a cursor, got %d, next %q", len(prs), next)
+	}
+
+	prs, next, err = svc.ListPullRequests(context.Background(), api.GetPullRequestListParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prs) != 3 || next != "" {
+		t.Errorf("expected 3 PRs on one page, got %d, next %q", len(prs), next)
+	}
+}
+
+func TestUserService_DeleteUser(t *testing.T) {
+	svc := service.NewUserService(&mockRepo{}, config.Default().Assignment, logger.NewLogger("app", logger.LevelInfo))
+	res, err := svc.DeleteUser(context.Background(), "busy", api.Reassign)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.UserId != "busy" || res.Mode != api.Reassign || !res.Anonymized || res.ReassignedReviews != 2 {
+		t.Errorf("unexpected result %+v", res)
+	}
+	if _, err := svc.DeleteUser(context.Background(), "busy", api.Block); !errors.Is(err, repository.ErrUserHasActivity) {
+		t.Errorf("expected ErrUserHasActivity, got %v", err)
+	}
+}
+
+func TestUserService_RemoveMembers(t *testing.T) {
+	svc := service.NewUserService(&mockRepo{}, config.Default().Assignment, logger.NewLogger("app", logger.LevelInfo))
+	res, err := svc.RemoveMembers(context.Background(), "backend", []string{"u1", "u2", "u1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.RemovedReviews != 2 {
+		t.Errorf("expected duplicate user_ids to be dropped, got %+v", res)
+	}
+	if _, err := svc.RemoveMembers(context.Background(), "notfound", []string{"u1"}); !errors.Is(err, repository.ErrTeamNotFound) {
+		t.Errorf("expected ErrTeamNotFound, got %v", err)
+	}
+}
+
+func TestUserService_FindUsers(t *testing.T) {
+	svc := service.NewUserService(&mockRepo{}, config.Default().Assignment, logger.NewLogger("app", logger.LevelInfo))
+	users, total, err := svc.FindUsers(context.Background(), repository.UserFilter{Limit: 3, Offset: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 42 || len(users) == 0 {
+		t.Errorf("unexpected result: %d users, total %d", len(users), total)
+	}
+	if _, err := svc.CreateUser(context.Background(), "existing", "Eve", true); !errors.Is(err, repository.ErrUserExists) {
+		t.Errorf("expected ErrUserExists, got %v", err)
+	}
+}
+
+func TestUserService_ListUsers(t *testing.T) {
+	svc := service.NewUserService(&mockRepo{}, config.Default().Assignment, logger.NewLogger("app", logger.LevelInfo))
+	limit := 2
+	params := api.GetUsersListParams{Limit: &limit}
+	users, next, err := svc.ListUsers(context.Background(), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 2 || next == "" {
+		t.Fatalf("expected 2 users and a cursor, got %d, next %q", len(users), next)
+	}
+
+	params.Cursor = &next
+	users, next, err = svc.ListUsers(context.Background(), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 1 || users[0].UserId != "u3" || next != "" {
+		t.Errorf("unexpected last page %+v, next %q", users, next)
+	}
+}
+
+func TestUserService_GetReviewerStats(t *testing.T) {
+	svc := service.NewUserService(&mockRepo{}, config.Default().Assignment, logger.NewLogger("app", logger.LevelInfo))
+	stats, err := svc.GetReviewerStats(context.Background(), nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stats) != 2 {
+		t.Errorf("expected 2 reviewers")
+	}
+	future := now.Add(time.Hour)
+	stats, err = svc.GetReviewerStats(context.Background(), &future, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stats) != 0 {
+		t.Errorf("expected 0 reviewers")
+	}
+}
+
+func TestUserService_GetTeamStats(t *testing.T) {
+	svc := service.NewUserService(&mockRepo{}, config.Default().Assignment, logger.NewLogger("app", logger.LevelInfo))
+	stats, err := svc.GetTeamStats(context.Background(), nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stats) != 1 || stats[0].Assigned != 3 {
+		t.Errorf("unexpected team stats: %+v", stats)
+	}
+}
+
+func TestUserService_GetCycleTimeReport(t *testing.T) {
+	svc := service.NewUserService(&mockRepo{}, config.Default().Assignment, logger.NewLogger("app", logger.LevelInfo))
+	report, err := svc.GetCycleTimeReport(context.Background(), nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.ByTeam) != 1 || len(report.Weekly) != 1 {
+		t.Errorf("unexpected report: %+v", report)
+	}
+	past := now.Add(-time.Hour)
+	_, err = svc.GetCycleTimeReport(context.Background(), nil, &past)
+	if err == nil {
+		t.Errorf("expected error")
+	}
+}
@@ -0,0 +1,103 @@
+package tracing_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chimort/avito_test_task/iternal/api"
+	"github.com/chimort/avito_test_task/iternal/config"
+	"github.com/chimort/avito_test_task/iternal/handlers"
+	"github.com/chimort/avito_test_task/iternal/pkg/logger"
+	"github.com/chimort/avito_test_task/iternal/pkg/tracing"
+	"github.com/chimort/avito_test_task/iternal/repository"
+	"github.com/chimort/avito_test_task/iternal/service"
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type teamRepo struct {
+	repository.UserRepo
+}
+
+func (teamRepo) GetTeam(ctx context.Context, teamName string) (*api.Team, error) {
+	return &api.Team{TeamName: teamName}, nil
+}
+
+func TestTraceparentPropagatesToService(t *testing.T) {
+	shutdown, err := tracing.Init(context.Background(), tracing.Config{Exporter: tracing.ExporterNone})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = shutdown(context.Background()) }()
+
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	log := logger.NewLogger("app", logger.LevelInfo)
+	h := handlers.NewHandlers(service.NewUserService(teamRepo{}, config.Default().Assignment, log), nil, nil, log)
+	e := echo.New()
+	e.Use(otelecho.Middleware("test"))
+	api.RegisterHandlers(e, h)
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	req := httptest.NewRequest(http.MethodGet, "/team/get?team_name=backend", nil)
+	req.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+
+	var found bool
+	for _, s := range recorder.Ended() {
+		if s.Name() == "UserService.GetTeam" {
+			found = true
+			if got := s.SpanContext().TraceID().String(); got != traceID {
+				t.Errorf("expected trace %s, got %s", traceID, got)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("expected UserService.GetTeam span")
+	}
+}
+
+func TestInit_FileExporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.json")
+	shutdown, err := tracing.Init(context.Background(), tracing.Config{
+		Exporter:    tracing.ExporterFile,
+		FilePath:    path,
+		ServiceName: "test",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, span := otel.Tracer("test").Start(context.Background(), "file-span")
+	span.End()
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "file-span") {
+		t.Errorf("expected span in file, got %s", data)
+	}
+}
+
+func TestInit_UnknownExporter(t *testing.T) {
+	if _, err := tracing.Init(context.Background(), tracing.Config{Exporter: "jaeger"}); err == nil {
+		t.Errorf("expected error for unknown exporter")
+	}
+}